@@ -7,8 +7,10 @@ import (
 	"telkom_coin_back_end/internal/blockchain"
 	"telkom_coin_back_end/internal/handler"
 	"telkom_coin_back_end/internal/middleware"
+	"telkom_coin_back_end/internal/payment"
 	"telkom_coin_back_end/internal/repository"
 	service "telkom_coin_back_end/internal/services"
+	"telkom_coin_back_end/internal/web3"
 
 	"github.com/gin-gonic/gin"
 )
@@ -24,30 +26,120 @@ func NewApp() *App {
 	userRepo := repository.NewUserRepository(config.GetDB())
 	balanceRepo := repository.NewBalanceRepository(config.GetDB())
 	txRepo := repository.NewTransactionRepository(config.GetDB())
+	eventRepo := repository.NewContractEventRepository(config.GetDB())
+	idempotencyRepo := repository.NewIdempotencyRepository(config.GetDB())
+	outboxRepo := repository.NewOutboxRepository(config.GetDB())
+	reconciliationRepo := repository.NewBalanceReconciliationRepository(config.GetDB())
+	paymentRequestRepo := repository.NewPaymentRequestRepository(config.GetDB())
+	scheduledTransferRepo := repository.NewScheduledTransferRepository(config.GetDB())
+	topupOrderRepo := repository.NewTopupOrderRepository(config.GetDB())
+	pendingTopupRepo := repository.NewPendingTopupRepository(config.GetDB())
+	virtualAccountRepo := repository.NewVirtualAccountRepository(config.GetDB())
+	bankStatementRepo := repository.NewBankStatementRepository(config.GetDB())
+	limitReservationRepo := repository.NewLimitReservationRepository(config.GetDB())
+	transferQuoteClaimRepo := repository.NewTransferQuoteClaimRepository(config.GetDB())
 
 	// Blockchain service
-	blockchainService, err := blockchain.NewBlockchainService("1337")
+	blockchainService, err := blockchain.NewActiveBlockchainService()
 	if err != nil {
 		log.Printf("Warning: Failed to initialize blockchain service: %v", err)
 	}
-	if blockchainService.IsWeb3Enabled() {
-		log.Println("✅ Web3 blockchain integration enabled")
+	if blockchainService.IsSimulated() {
+		log.Println("🧪 Web3 running on in-process simulated chain")
+	} else if blockchainService.IsWeb3Enabled() {
+		log.Printf("✅ Web3 blockchain integration enabled on %s", blockchainService.Network.Name)
 	} else {
-		log.Println("⚠️  Web3 disabled, using simulation mode")
+		log.Println("⚠️  Web3 disabled, blockchain features unavailable (set BLOCKCHAIN_NETWORK=simulated to run without a node)")
 	}
 
 	// Services
 	authService := service.NewAuthService(userRepo)
 	authService.SetBalanceRepo(balanceRepo) // Set balance repo for creating initial balance
 	pinService := service.NewPinService(userRepo)
+	healthService := service.NewHealthService(config.GetDB(), blockchainService)
+	idempotencyService := service.NewIdempotencyService(idempotencyRepo)
+	idempotencyService.Start()
 	userService := service.NewUserService(userRepo, balanceRepo)
-	topupService := service.NewTopupService(userRepo, balanceRepo, txRepo, blockchainService)
-	withdrawService := service.NewWithdrawService(userRepo, balanceRepo, txRepo, blockchainService)
-	blockchainExplorerService, err := service.NewBlockchainExplorerService()
+
+	// Outbox: topup/withdraw intents are saved first, then the relay broadcasts
+	// them and the recorder settles balances from the receipt
+	// Nonces of stored signed transactions are never handed out again
+	web3.SetHeldNonces(service.HeldNonces(outboxRepo, pendingTopupRepo))
+	outboxRelay := service.NewOutboxRelay(outboxRepo, userRepo, balanceRepo, txRepo, blockchainService)
+	outboxRelay.Start()
+	outboxRecorder := service.NewOutboxRecorder(outboxRepo, balanceRepo, txRepo, pendingTopupRepo, blockchainService, outboxRelay)
+	outboxRecorder.Start()
+
+	// Balance reconciler keeps the balances table in line with the chain
+	balanceReconciler := service.NewBalanceReconciler(balanceRepo, outboxRepo, txRepo, pendingTopupRepo, reconciliationRepo, blockchainService)
+	balanceReconciler.Start()
+
+	// Per-tier transfer, withdraw and topup limits over rolling daily/monthly usage
+	limitService := service.NewLimitService(userRepo, txRepo, topupOrderRepo, outboxRepo, limitReservationRepo)
+	limitService.Start()
+
+	// Topups are orders at the payment provider, minted after its signed webhook
+	paymentProvider, err := payment.NewProvider(config.AppConfig.PaymentProvider, config.AppConfig.PaymentWebhookSecret, payment.QRISMerchant{
+		Name:         config.AppConfig.QRISMerchantName,
+		City:         config.AppConfig.QRISMerchantCity,
+		PostalCode:   config.AppConfig.QRISMerchantPostalCode,
+		NMID:         config.AppConfig.QRISMerchantNMID,
+		CategoryCode: config.AppConfig.QRISMerchantCategory,
+	})
+	if err != nil {
+		log.Fatal("Failed to set up payment provider: " + err.Error())
+	}
+
+	// Development simulator paying mock QRIS charges through the real webhook.
+	// It signs paid webhooks, so it only exists on the simulated chain.
+	var paymentSimulator *payment.Simulator
+	mockProvider, isMock := paymentProvider.(*payment.MockProvider)
+	if isMock && blockchainService.IsSimulated() && config.AppConfig.PaymentSimulatorWebhookURL != "" {
+		paymentSimulator = payment.NewSimulator(mockProvider, config.AppConfig.PaymentSimulatorWebhookURL)
+		log.Printf("🧪 Payment simulator enabled, webhooks go to %s", config.AppConfig.PaymentSimulatorWebhookURL)
+	}
+	topupService := service.NewTopupService(userRepo, balanceRepo, txRepo, blockchainService, outboxRepo, topupOrderRepo, pendingTopupRepo, outboxRelay, paymentProvider, limitService)
+	topupService.Start()
+
+	// Per-user virtual account numbers; transfers to them are minted without an order
+	virtualAccountService := service.NewVirtualAccountService(virtualAccountRepo, topupOrderRepo, userRepo, topupService, limitService, paymentProvider)
+
+	// Bank statement imports reconcile IDR received with topup orders
+	bankStatementService := service.NewBankStatementService(bankStatementRepo, topupOrderRepo)
+
+	// Keeper calls processTopup for delayed topups once the contract's hour has passed
+	topupKeeper := service.NewTopupKeeper(pendingTopupRepo, topupOrderRepo, balanceRepo, txRepo, blockchainService)
+	topupKeeper.Start()
+	withdrawService := service.NewWithdrawService(userRepo, balanceRepo, txRepo, blockchainService, outboxRepo, outboxRelay, limitService)
+	blockchainExplorerService, err := service.NewBlockchainExplorerService(eventRepo)
 	if err != nil {
 		log.Printf("Warning: Blockchain explorer not available: %v", err)
 	}
 
+	// Contract event indexer feeds the explorer tables
+	eventIndexer, err := service.NewContractEventIndexer(eventRepo)
+	if err != nil {
+		log.Printf("Warning: Contract event indexer not available: %v", err)
+	} else {
+		eventIndexer.Start()
+	}
+
+	// Confirmation tracker moves broadcast transfers from pending to confirmed/failed
+	txTracker := service.NewTransactionTracker(txRepo, blockchainService)
+	txTracker.Start()
+
+	// Reconciler settles pending/processing topups, withdrawals and transfers from their receipts
+	txReconciler := service.NewTransactionReconciler(txRepo, blockchainService, txTracker)
+	txReconciler.Start()
+
+	// Resolves @username, email and phone recipients (shared lookup limit)
+	recipientResolver := service.NewRecipientResolver(userRepo)
+	recipientResolver.Start()
+
+	// Signed transfer quotes, each executed once (claims kept in the DB)
+	transferQuoteSigner := service.NewTransferQuoteSigner(transferQuoteClaimRepo)
+	transferQuoteSigner.Start()
+
 	// TLC Wallet Service (blockchain-based)
 	tlcWalletService := service.NewTLCWalletService(
 		userRepo,
@@ -55,20 +147,39 @@ func NewApp() *App {
 		txRepo,
 		blockchainService,
 		blockchainExplorerService,
+		txTracker,
+		recipientResolver,
+		transferQuoteSigner,
+		limitService,
 	)
 
+	// Payment requests between users, paid through the TLC wallet transfer
+	paymentRequestService := service.NewPaymentRequestService(paymentRequestRepo, userRepo, txRepo, recipientResolver, tlcWalletService)
+	txTracker.OnFinal(paymentRequestService.OnTransactionFinal)
+
+	// Scheduled and recurring transfers, sent by the runner through the TLC wallet transfer
+	scheduledTransferService := service.NewScheduledTransferService(scheduledTransferRepo, userRepo, recipientResolver)
+	scheduledTransferRunner := service.NewScheduledTransferRunner(scheduledTransferRepo, tlcWalletService)
+	scheduledTransferRunner.Start()
+
 	// Blockchain Explorer Service
 	
 
 	// Handlers
 	authHandler := handler.NewAuthHandler(authService)
 	pinHandler := handler.NewPinHandler(pinService)
+	healthHandler := handler.NewHealthHandler(healthService)
+	adminHandler := handler.NewAdminHandler(balanceReconciler, virtualAccountService, bankStatementService)
 	userHandler := handler.NewUserHandler(userService, authService)
-	topupHandler := handler.NewTopupHandler(topupService)
+	topupHandler := handler.NewTopupHandler(topupService, virtualAccountService)
+	paymentWebhookHandler := handler.NewPaymentWebhookHandler(topupService, virtualAccountService)
 	withdrawHandler := handler.NewWithdrawHandler(withdrawService, userService)
+	limitHandler := handler.NewLimitHandler(limitService)
 
 	// TLC Wallet Handler (blockchain-based)
 	tlcWalletHandler := handler.NewTLCWalletHandler(tlcWalletService, userService)
+	paymentRequestHandler := handler.NewPaymentRequestHandler(paymentRequestService)
+	scheduledTransferHandler := handler.NewScheduledTransferHandler(scheduledTransferService)
 
 	// Blockchain Explorer Handler
 	var blockchainExplorerHandler *handler.BlockchainExplorerHandler
@@ -77,12 +188,19 @@ func NewApp() *App {
 	}
 
 	// Public routes
+	r.GET("/health", healthHandler.GetHealth)
 	r.POST("/register", authHandler.Register)
 	r.POST("/login", authHandler.Login)
 
+	// Payment provider webhooks (authenticated by their signature)
+	r.POST("/webhooks/payment", paymentWebhookHandler.HandlePaymentWebhook)
+	r.POST("/webhooks/payment/va", paymentWebhookHandler.HandleVACreditNotification)
+
 	// Protected routes
 	auth := r.Group("/api")
 	auth.Use(middleware.JWTMiddleware())
+	// Retry-safe money-moving endpoints (Idempotency-Key header)
+	idempotent := middleware.IdempotencyMiddleware(idempotencyService)
 	{
 		// User management
 		auth.GET("/profile", userHandler.GetProfile)
@@ -100,20 +218,58 @@ func NewApp() *App {
 		// Balance (Unified - only blockchain balance)
 		auth.GET("/balance", tlcWalletHandler.GetTLCBalance)
 
-		// Topup (Simplified - Auto processed, no proof needed)
-		auth.POST("/topup", topupHandler.RequestTopup)
+		// Transfer, withdraw and topup limits with remaining allowance
+		auth.GET("/limits", limitHandler.GetLimits)
+
+		// Topup (order paid at the payment provider, minted after its webhook)
+		auth.POST("/topup", idempotent, topupHandler.RequestTopup)
 		auth.GET("/topup/history", topupHandler.GetTopupHistory)
+		auth.GET("/topup/orders", topupHandler.GetTopupOrders)
+		auth.GET("/topup/orders/:id", topupHandler.GetTopupDetail)
+		auth.GET("/topup/orders/:id/qr", topupHandler.GetTopupQRCode)
+		auth.POST("/topup/qris/decode", topupHandler.DecodeQRIS)
+		auth.GET("/topup/virtual-accounts", topupHandler.GetVirtualAccounts)
 
 		transferGroup := auth.Group("/transfer")
 		{
 			transferGroup.POST("/validate", tlcWalletHandler.ValidateTransfer)
-			transferGroup.POST("/execute", tlcWalletHandler.TransferTLC)
+			transferGroup.POST("/validate-uri", tlcWalletHandler.ValidatePaymentURI)
+			transferGroup.POST("/execute", idempotent, tlcWalletHandler.TransferTLC)
+			transferGroup.GET("/status/:hash", tlcWalletHandler.GetTransferStatus)
+		}
+
+		// Receive (EIP-681 payment URI and QR code for scan-to-pay)
+		auth.GET("/receive/uri", tlcWalletHandler.GetReceivePaymentURI)
+		auth.GET("/receive/qr", tlcWalletHandler.GetReceiveQRCode)
+
+		// Payment requests (ask another user for TLC)
+		paymentRequestGroup := auth.Group("/payment-requests")
+		{
+			paymentRequestGroup.POST("", paymentRequestHandler.CreatePaymentRequest)
+			paymentRequestGroup.GET("", paymentRequestHandler.GetPaymentRequests)
+			paymentRequestGroup.GET("/:id", paymentRequestHandler.GetPaymentRequest)
+			paymentRequestGroup.POST("/:id/pay", idempotent, paymentRequestHandler.PayPaymentRequest)
+			paymentRequestGroup.POST("/:id/decline", paymentRequestHandler.DeclinePaymentRequest)
+		}
+
+		// Scheduled transfers (future-dated or daily, weekly, monthly)
+		scheduledTransferGroup := auth.Group("/scheduled-transfers")
+		{
+			scheduledTransferGroup.POST("", idempotent, scheduledTransferHandler.CreateScheduledTransfer)
+			scheduledTransferGroup.GET("", scheduledTransferHandler.GetScheduledTransfers)
+			scheduledTransferGroup.GET("/:id", scheduledTransferHandler.GetScheduledTransfer)
+			scheduledTransferGroup.PATCH("/:id", scheduledTransferHandler.UpdateScheduledTransfer)
+			scheduledTransferGroup.POST("/:id/pause", scheduledTransferHandler.PauseScheduledTransfer)
+			scheduledTransferGroup.POST("/:id/resume", scheduledTransferHandler.ResumeScheduledTransfer)
+			scheduledTransferGroup.POST("/:id/cancel", scheduledTransferHandler.CancelScheduledTransfer)
+			scheduledTransferGroup.GET("/:id/executions", scheduledTransferHandler.GetScheduledTransferExecutions)
 		}
 
 		// Transfer (Direct blockchain only)
 
 		// Withdraw (Direct blockchain only)
-		auth.POST("/withdraw", withdrawHandler.RequestWithdraw)
+		auth.POST("/withdraw", idempotent, withdrawHandler.RequestWithdraw)
+		auth.GET("/withdraw/limits", withdrawHandler.GetWithdrawLimits)
 
 		// Transactions (Blockchain only)
 		auth.GET("/transactions", tlcWalletHandler.GetTransactionHistory)
@@ -140,7 +296,29 @@ func NewApp() *App {
 		}
 	}
 
-	// Admin routes removed - no admin system needed for pure blockchain
+	// Admin routes (X-Admin-Key, disabled without ADMIN_API_KEY)
+	admin := r.Group("/admin")
+	admin.Use(middleware.AdminMiddleware())
+	{
+		admin.GET("/reconciliation/balances", adminHandler.GetBalanceReconciliation)
+		admin.POST("/reconciliation/balances/run", adminHandler.RunBalanceReconciliation)
+
+		// Bank statement (CSV/MT940) vs topup orders
+		admin.POST("/reconciliation/bank-statements", adminHandler.ImportBankStatement)
+		admin.GET("/reconciliation/bank-statements", adminHandler.GetBankStatementReports)
+		admin.GET("/reconciliation/bank-statements/:id", adminHandler.GetBankStatementReport)
+
+		// Manual review of virtual account transfers that matched no user
+		admin.GET("/topups/va-credits", adminHandler.GetVACredits)
+		admin.POST("/topups/va-credits/:id/assign", adminHandler.AssignVACredit)
+		admin.POST("/topups/va-credits/:id/reject", adminHandler.RejectVACredit)
+
+		// Development payment simulator (mock provider on the simulated chain only)
+		if paymentSimulator != nil {
+			paymentSimulatorHandler := handler.NewPaymentSimulatorHandler(paymentSimulator)
+			admin.POST("/simulator/qris/pay", paymentSimulatorHandler.PayQRIS)
+		}
+	}
 
 	return &App{Router: r}
 }
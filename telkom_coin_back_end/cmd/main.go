@@ -10,6 +10,16 @@ func main() {
 	// Load environment variables
 	config.LoadEnv()
 
+	// Load limits; an invalid LIMITS_FILE must not start the server
+	if err := config.LoadLimits(); err != nil {
+		log.Fatal("Failed to load limits: " + err.Error())
+	}
+
+	// Load networks; a bad NETWORKS_FILE or BLOCKCHAIN_NETWORK must not start the server
+	if err := config.LoadNetworks(); err != nil {
+		log.Fatal("Failed to load networks: " + err.Error())
+	}
+
 	// Initialize database
 	config.InitDB()
 
@@ -3,6 +3,8 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -16,6 +18,96 @@ type AppConfigType struct {
 	DBPort    string
 	DBName    string
 	JWTSecret string
+
+	// Signs mints, burns and processTopup; the simulated chain generates one when empty
+	AdminPrivateKey string
+
+	// Contract event indexer
+	IndexerStartBlock   uint64
+	IndexerBatchSize    uint64
+	IndexerPollInterval time.Duration
+
+	// Transaction fees: caps in whole gwei, margin added on top of EstimateGas
+	GasFeeCapGwei         uint64
+	GasTipCapGwei         uint64
+	GasLimitMarginPercent uint64
+
+	// Simulated chain (BLOCKCHAIN_NETWORK=simulated): TLC minted to the admin on
+	// deploy, and whole ETH given to a wallet that is low on gas
+	SimulatedInitialSupply string
+	SimulatedGasFunding    string
+
+	// RPC failover between the endpoints of the active network
+	RPCMaxRetries     int
+	RPCRetryBackoff   time.Duration
+	RPCRequestTimeout time.Duration
+	RPCHealthInterval time.Duration
+	RPCMaxBlockLag    uint64
+
+	// Transaction confirmation tracker
+	TxTrackerPollInterval  time.Duration
+	TxTrackerMaxAge        time.Duration
+	TransferCallbackSecret string
+	TransferCallbackHosts  []string
+
+	// Signed quotes linking /api/transfer/validate and /api/transfer/execute
+	TransferQuoteSecret string
+	TransferQuoteTTL    time.Duration
+
+	// Pending/processing transaction reconciler
+	ReconcilerInterval        time.Duration
+	ReconcilerBatchSize       int
+	ReconcilerNotFoundTimeout time.Duration
+
+	// How long an Idempotency-Key response is kept for replays
+	IdempotencyKeyTTL time.Duration
+
+	// Chain outbox relay/recorder for topup and withdraw
+	OutboxPollInterval time.Duration
+	OutboxMaxAttempts  int
+
+	// On-chain vs database balance reconciliation
+	BalanceReconcileInterval time.Duration
+	BalanceReconcileFixLimit string // TLC; larger mismatches are flagged instead of fixed
+
+	// Scheduled and recurring transfers
+	ScheduledTransferPollInterval  time.Duration
+	ScheduledTransferRetryInterval time.Duration // Wait before retrying a failed run (e.g. balance short)
+	ScheduledTransferMaxRetries    int
+
+	// Fiat topup orders, minted after the payment provider's signed webhook
+	PaymentProvider      string
+	PaymentWebhookSecret string // Empty rejects every webhook
+	TopupOrderTTL        time.Duration
+	TopupMintMaxAttempts int // Failed mints before a paid order is left for review
+
+	// Merchant that QRIS topup payloads pay
+	QRISMerchantName       string
+	QRISMerchantCity       string
+	QRISMerchantPostalCode string
+	QRISMerchantNMID       string
+	QRISMerchantCategory   string // MCC
+
+	// Webhook the development payment simulator posts to (mock provider on the simulated chain only); empty disables it
+	PaymentSimulatorWebhookURL string
+
+	// Topups of at least this amount (TLC) use requestTopup/processTopup; 0 mints every topup instantly
+	TopupDelayedThreshold string
+	TopupKeeperInterval   time.Duration
+
+	// Bank statement import: a credit without a topup reference matches an order
+	// of the same amount paid at most this far from the booking date
+	BankStatementMatchWindow time.Duration
+
+	// JSON file with per-tier limits merged over the built-in ones; empty uses the built-in limits
+	LimitsFile string
+
+	// Transfer recipient lookups by @username, email or phone, per sender
+	RecipientLookupLimit  int
+	RecipientLookupWindow time.Duration
+
+	// Shared key for /admin endpoints (X-Admin-Key header); empty disables them
+	AdminAPIKey string
 }
 
 var AppConfig AppConfigType
@@ -35,6 +127,74 @@ func LoadEnv() {
 		DBPort:    os.Getenv("DB_PORT"),
 		DBName:    os.Getenv("DB_NAME"),
 		JWTSecret: os.Getenv("JWT_SECRET"),
+
+		AdminPrivateKey: os.Getenv("ADMIN_PRIVATE_KEY"),
+
+		IndexerStartBlock:   getEnvUint("INDEXER_START_BLOCK", 0),
+		IndexerBatchSize:    getEnvUint("INDEXER_BATCH_SIZE", 2000),
+		IndexerPollInterval: getEnvDuration("INDEXER_POLL_INTERVAL", 5*time.Second),
+
+		GasFeeCapGwei:         getEnvUint("GAS_FEE_CAP_GWEI", 100),
+		GasTipCapGwei:         getEnvUint("GAS_TIP_CAP_GWEI", 2),
+		GasLimitMarginPercent: getEnvUint("GAS_LIMIT_MARGIN_PERCENT", 20),
+
+		SimulatedInitialSupply: os.Getenv("SIMULATED_INITIAL_SUPPLY"),
+		SimulatedGasFunding:    os.Getenv("SIMULATED_GAS_FUNDING"),
+
+		RPCMaxRetries:     int(getEnvUint("RPC_MAX_RETRIES", 3)),
+		RPCRetryBackoff:   getEnvDuration("RPC_RETRY_BACKOFF", 200*time.Millisecond),
+		RPCRequestTimeout: getEnvDuration("RPC_REQUEST_TIMEOUT", 10*time.Second),
+		RPCHealthInterval: getEnvDuration("RPC_HEALTH_INTERVAL", 15*time.Second),
+		RPCMaxBlockLag:    getEnvUint("RPC_MAX_BLOCK_LAG", 5),
+
+		TxTrackerPollInterval:  getEnvDuration("TX_TRACKER_POLL_INTERVAL", 3*time.Second),
+		TxTrackerMaxAge:        getEnvDuration("TX_TRACKER_MAX_AGE", 30*time.Minute),
+		TransferCallbackSecret: os.Getenv("TRANSFER_CALLBACK_SECRET"),
+		TransferCallbackHosts:  splitList(os.Getenv("TRANSFER_CALLBACK_ALLOWED_HOSTS")),
+
+		ReconcilerInterval:        getEnvDuration("RECONCILER_INTERVAL", time.Minute),
+		ReconcilerBatchSize:       int(getEnvUint("RECONCILER_BATCH_SIZE", 200)),
+		ReconcilerNotFoundTimeout: getEnvDuration("RECONCILER_NOT_FOUND_TIMEOUT", 15*time.Minute),
+
+		TransferQuoteSecret: os.Getenv("TRANSFER_QUOTE_SECRET"),
+		TransferQuoteTTL:    getEnvDuration("TRANSFER_QUOTE_TTL", 2*time.Minute),
+
+		IdempotencyKeyTTL: getEnvDuration("IDEMPOTENCY_KEY_TTL", 24*time.Hour),
+
+		OutboxPollInterval: getEnvDuration("OUTBOX_POLL_INTERVAL", 5*time.Second),
+		OutboxMaxAttempts:  int(getEnvUint("OUTBOX_MAX_ATTEMPTS", 5)),
+
+		BalanceReconcileInterval: getEnvDuration("BALANCE_RECONCILE_INTERVAL", time.Hour),
+		BalanceReconcileFixLimit: os.Getenv("BALANCE_RECONCILE_FIX_LIMIT"),
+
+		ScheduledTransferPollInterval:  getEnvDuration("SCHEDULED_TRANSFER_POLL_INTERVAL", time.Minute),
+		ScheduledTransferRetryInterval: getEnvDuration("SCHEDULED_TRANSFER_RETRY_INTERVAL", time.Hour),
+		ScheduledTransferMaxRetries:    int(getEnvUint("SCHEDULED_TRANSFER_MAX_RETRIES", 3)),
+
+		PaymentProvider:      os.Getenv("PAYMENT_PROVIDER"),
+		PaymentWebhookSecret: os.Getenv("PAYMENT_WEBHOOK_SECRET"),
+		TopupOrderTTL:        getEnvDuration("TOPUP_ORDER_TTL", 24*time.Hour),
+		TopupMintMaxAttempts: int(getEnvUint("TOPUP_MINT_MAX_ATTEMPTS", 3)),
+
+		QRISMerchantName:       os.Getenv("QRIS_MERCHANT_NAME"),
+		QRISMerchantCity:       os.Getenv("QRIS_MERCHANT_CITY"),
+		QRISMerchantPostalCode: os.Getenv("QRIS_MERCHANT_POSTAL_CODE"),
+		QRISMerchantNMID:       os.Getenv("QRIS_MERCHANT_NMID"),
+		QRISMerchantCategory:   os.Getenv("QRIS_MERCHANT_CATEGORY"),
+
+		PaymentSimulatorWebhookURL: os.Getenv("PAYMENT_SIMULATOR_WEBHOOK_URL"),
+
+		TopupDelayedThreshold: os.Getenv("TOPUP_DELAYED_THRESHOLD"),
+		TopupKeeperInterval:   getEnvDuration("TOPUP_KEEPER_INTERVAL", time.Minute),
+
+		BankStatementMatchWindow: getEnvDuration("BANK_STATEMENT_MATCH_WINDOW", 72*time.Hour),
+
+		LimitsFile: os.Getenv("LIMITS_FILE"),
+
+		RecipientLookupLimit:  int(getEnvUint("RECIPIENT_LOOKUP_LIMIT", 30)),
+		RecipientLookupWindow: getEnvDuration("RECIPIENT_LOOKUP_WINDOW", time.Hour),
+
+		AdminAPIKey: os.Getenv("ADMIN_API_KEY"),
 	}
 
 	if AppConfig.Port == "" {
@@ -45,4 +205,98 @@ func LoadEnv() {
 		AppConfig.JWTSecret = "your-secret-key-change-this-in-production"
 		log.Println("Warning: Using default JWT secret. Set JWT_SECRET environment variable in production")
 	}
+
+	if AppConfig.TransferQuoteSecret == "" {
+		AppConfig.TransferQuoteSecret = AppConfig.JWTSecret
+	}
+
+	if AppConfig.SimulatedInitialSupply == "" {
+		AppConfig.SimulatedInitialSupply = "1000000" // Sama dengan ignition/modules/PaymentToken.ts
+	}
+
+	if AppConfig.SimulatedGasFunding == "" {
+		AppConfig.SimulatedGasFunding = "10"
+	}
+
+	if AppConfig.IndexerBatchSize == 0 {
+		AppConfig.IndexerBatchSize = 2000
+	}
+
+	if AppConfig.ReconcilerBatchSize == 0 {
+		AppConfig.ReconcilerBatchSize = 200
+	}
+
+	if AppConfig.OutboxMaxAttempts == 0 {
+		AppConfig.OutboxMaxAttempts = 5
+	}
+
+	if AppConfig.TopupMintMaxAttempts == 0 {
+		AppConfig.TopupMintMaxAttempts = 3
+	}
+
+	if AppConfig.BalanceReconcileFixLimit == "" {
+		AppConfig.BalanceReconcileFixLimit = "10000"
+	}
+
+	if AppConfig.RecipientLookupLimit == 0 {
+		AppConfig.RecipientLookupLimit = 30
+	}
+
+	if AppConfig.TopupDelayedThreshold == "" {
+		AppConfig.TopupDelayedThreshold = "10000000"
+	}
+
+	if AppConfig.PaymentProvider == "" {
+		AppConfig.PaymentProvider = "mock"
+	}
+
+	if AppConfig.QRISMerchantName == "" {
+		AppConfig.QRISMerchantName = "TELKOM COIN"
+	}
+
+	if AppConfig.QRISMerchantCity == "" {
+		AppConfig.QRISMerchantCity = "JAKARTA"
+	}
+
+	if AppConfig.QRISMerchantNMID == "" {
+		AppConfig.QRISMerchantNMID = "ID1026000000001"
+	}
+
+	if AppConfig.QRISMerchantCategory == "" {
+		AppConfig.QRISMerchantCategory = "6540" // Stored value card purchase/load
+	}
+
+	if AppConfig.PaymentWebhookSecret == "" {
+		log.Println("Warning: PAYMENT_WEBHOOK_SECRET is not set, payment webhooks will be rejected and topups never minted")
+	}
+}
+
+// getEnvUint reads an unsigned integer env var, falling back to def when unset or invalid
+func getEnvUint(key string, def uint64) uint64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return def
+	}
+
+	parsed, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		log.Printf("Warning: invalid %s=%q, using %d", key, value, def)
+		return def
+	}
+	return parsed
+}
+
+// getEnvDuration reads a duration env var (e.g. "5s"), falling back to def when unset or invalid
+func getEnvDuration(key string, def time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return def
+	}
+
+	parsed, err := time.ParseDuration(value)
+	if err != nil || parsed <= 0 {
+		log.Printf("Warning: invalid %s=%q, using %s", key, value, def)
+		return def
+	}
+	return parsed
 }
@@ -60,12 +60,37 @@ func InitDB() *gorm.DB {
 		log.Println("✅ Connected to SQLite database")
 	}
 
+	// contract_events rows are unique per deployment; the old unique index on
+	// (tx_hash, log_index) alone would skip the same log of another deployment
+	if db.Migrator().HasIndex(&models.ContractEvent{}, "idx_contract_events_tx_log") {
+		if err := db.Migrator().DropIndex(&models.ContractEvent{}, "idx_contract_events_tx_log"); err != nil {
+			log.Fatal("Failed to drop old contract event index: " + err.Error())
+		}
+	}
+
 	// Auto migrate tables
 	log.Println("Running auto migration...")
 	err = db.AutoMigrate(
 		&models.User{},
 		&models.Transaction{},
 		&models.Balance{},
+		&models.ContractEvent{},
+		&models.IndexerCheckpoint{},
+		&models.IdempotencyKey{},
+		&models.TransferQuoteClaim{},
+		&models.LimitReservation{},
+		&models.ChainOutbox{},
+		&models.BalanceReconciliationReport{},
+		&models.BalanceMismatch{},
+		&models.PaymentRequest{},
+		&models.ScheduledTransfer{},
+		&models.ScheduledTransferExecution{},
+		&models.TopupOrder{},
+		&models.PendingTopup{},
+		&models.VirtualAccount{},
+		&models.VirtualAccountCredit{},
+		&models.BankStatementReport{},
+		&models.BankStatementItem{},
 	)
 	if err != nil {
 		log.Fatal("Failed to auto migrate: " + err.Error())
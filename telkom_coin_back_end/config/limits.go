@@ -0,0 +1,135 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+)
+
+// Operations with limits; the names match transactions.tx_type
+const (
+	LimitOperationTransfer = "transfer"
+	LimitOperationWithdraw = "withdraw"
+	LimitOperationTopup    = "topup"
+)
+
+// Account tiers follow the user's KYC status
+const (
+	TierUnverified = "unverified"
+	TierPending    = "pending"
+	TierVerified   = "verified"
+)
+
+// OperationLimit holds the limits of one operation in whole TLC. An empty value
+// means no limit. Daily and monthly are rolling 24 hour and 30 day windows.
+type OperationLimit struct {
+	PerTransaction string `json:"per_transaction"`
+	Daily          string `json:"daily"`
+	Monthly        string `json:"monthly"`
+}
+
+// TierLimits maps an operation to its limits
+type TierLimits map[string]OperationLimit
+
+// LimitRegistry holds the limits of every account tier
+type LimitRegistry struct {
+	tiers map[string]TierLimits
+}
+
+var (
+	limitsOnce     sync.Once
+	limitsRegistry *LimitRegistry
+	limitsErr      error
+)
+
+// builtinTierLimits are used for every tier and operation LIMITS_FILE leaves out
+func builtinTierLimits() map[string]TierLimits {
+	tier := func(perTransaction, daily, monthly string) TierLimits {
+		limit := OperationLimit{PerTransaction: perTransaction, Daily: daily, Monthly: monthly}
+		return TierLimits{
+			LimitOperationTransfer: limit,
+			LimitOperationWithdraw: limit,
+			LimitOperationTopup:    limit,
+		}
+	}
+
+	return map[string]TierLimits{
+		TierVerified:   tier("100000000", "500000000", "2000000000"),
+		TierPending:    tier("10000000", "50000000", "200000000"),
+		TierUnverified: tier("1000000", "5000000", "20000000"),
+	}
+}
+
+// LoadLimits loads LIMITS_FILE. Called at startup after LoadEnv, so an unreadable or invalid
+// file stops the server instead of silently changing the limits.
+func LoadLimits() error {
+	limitsOnce.Do(func() {
+		limitsRegistry, limitsErr = loadLimitRegistry()
+	})
+	return limitsErr
+}
+
+// Limits returns the limit registry, loading LIMITS_FILE on first use
+func Limits() *LimitRegistry {
+	if err := LoadLimits(); err != nil {
+		log.Fatalf("Failed to load limits: %v", err)
+	}
+	return limitsRegistry
+}
+
+// Get returns the limits of an operation for a tier; unknown tiers get the
+// unverified limits
+func (r *LimitRegistry) Get(tier, operation string) OperationLimit {
+	limits, ok := r.tiers[tier]
+	if !ok {
+		limits = r.tiers[TierUnverified]
+	}
+	return limits[operation]
+}
+
+// loadLimitRegistry merges LIMITS_FILE over the built-in limits
+func loadLimitRegistry() (*LimitRegistry, error) {
+	registry := &LimitRegistry{tiers: builtinTierLimits()}
+
+	path := AppConfig.LimitsFile
+	if path == "" {
+		return registry, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read LIMITS_FILE: %v", err)
+	}
+
+	var tiers map[string]TierLimits
+	if err := json.Unmarshal(data, &tiers); err != nil {
+		return nil, fmt.Errorf("invalid LIMITS_FILE: %v", err)
+	}
+
+	for tier, operations := range tiers {
+		if _, ok := registry.tiers[tier]; !ok {
+			registry.tiers[tier] = TierLimits{}
+		}
+		for operation, limit := range operations {
+			for _, value := range []string{limit.PerTransaction, limit.Daily, limit.Monthly} {
+				if value != "" && !wholeAmount(value) {
+					return nil, fmt.Errorf("invalid LIMITS_FILE: %s %s limit %q", tier, operation, value)
+				}
+			}
+			registry.tiers[tier][operation] = limit
+		}
+	}
+	return registry, nil
+}
+
+// wholeAmount reports whether value is a whole TLC amount written in digits only
+func wholeAmount(value string) bool {
+	for _, c := range value {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return value != ""
+}
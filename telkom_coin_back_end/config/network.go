@@ -0,0 +1,213 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	NetworkLocal     = "local"
+	NetworkSimulated = "simulated"
+)
+
+// Network describes one chain the backend can run against
+type Network struct {
+	Key             string   `json:"key"`
+	Name            string   `json:"name"`
+	ChainID         int64    `json:"chain_id"`
+	RPCURLs         []string `json:"rpc_urls"`
+	ContractAddress string   `json:"contract_address"`
+	Confirmations   uint64   `json:"confirmations"` // Blocks on top of a tx before it counts as final
+	Simulated       bool     `json:"simulated"`     // In-process chain, no RPC node
+}
+
+// NetworkRegistry holds every known network and the one selected with BLOCKCHAIN_NETWORK
+type NetworkRegistry struct {
+	networks map[string]Network
+	order    []string
+	active   string
+}
+
+var (
+	networksOnce     sync.Once
+	networksRegistry *NetworkRegistry
+	networksErr      error
+)
+
+// builtinNetworks are always available; NETWORKS_FILE can override them
+func builtinNetworks() []Network {
+	return []Network{
+		{
+			Key:           NetworkLocal,
+			Name:          "Ganache Local",
+			ChainID:       1337,
+			RPCURLs:       []string{"http://127.0.0.1:8545"},
+			Confirmations: 0,
+		},
+		{
+			Key:           NetworkSimulated,
+			Name:          "Simulated Chain",
+			ChainID:       1337,
+			Confirmations: 0,
+			Simulated:     true,
+		},
+	}
+}
+
+// LoadNetworks builds the network registry from NETWORKS_FILE and the BLOCKCHAIN_*
+// variables. Called at startup, so a bad file or an unknown BLOCKCHAIN_NETWORK
+// stops the server instead of running against another chain.
+func LoadNetworks() error {
+	networksOnce.Do(func() {
+		networksRegistry, networksErr = loadNetworkRegistry()
+	})
+	return networksErr
+}
+
+// Networks returns the network registry, loading it from the environment on first use
+func Networks() *NetworkRegistry {
+	if err := LoadNetworks(); err != nil {
+		log.Fatalf("Failed to load networks: %v", err)
+	}
+	return networksRegistry
+}
+
+// ActiveNetwork returns the network selected with BLOCKCHAIN_NETWORK
+func ActiveNetwork() Network {
+	return Networks().Active()
+}
+
+func newNetworkRegistry(networks []Network) *NetworkRegistry {
+	registry := &NetworkRegistry{networks: make(map[string]Network)}
+	for _, network := range networks {
+		registry.add(network)
+	}
+	return registry
+}
+
+func (r *NetworkRegistry) add(network Network) {
+	if _, exists := r.networks[network.Key]; !exists {
+		r.order = append(r.order, network.Key)
+	}
+	r.networks[network.Key] = network
+}
+
+// Get returns a network by key
+func (r *NetworkRegistry) Get(key string) (Network, bool) {
+	network, ok := r.networks[key]
+	return network, ok
+}
+
+// Active returns the selected network
+func (r *NetworkRegistry) Active() Network {
+	return r.networks[r.active]
+}
+
+// List returns every network in registration order
+func (r *NetworkRegistry) List() []Network {
+	networks := make([]Network, 0, len(r.order))
+	for _, key := range r.order {
+		networks = append(networks, r.networks[key])
+	}
+	return networks
+}
+
+// loadNetworkRegistry builds the registry from the built-in networks, NETWORKS_FILE
+// and the BLOCKCHAIN_* overrides for the active network
+func loadNetworkRegistry() (*NetworkRegistry, error) {
+	registry := newNetworkRegistry(builtinNetworks())
+
+	if path := os.Getenv("NETWORKS_FILE"); path != "" {
+		networks, err := readNetworksFile(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, network := range networks {
+			registry.add(network)
+		}
+	}
+
+	active := strings.TrimSpace(os.Getenv("BLOCKCHAIN_NETWORK"))
+	if active == "" {
+		active = NetworkLocal
+		// BLOCKCHAIN_MODE=simulated is kept as a shortcut for the simulated network
+		if strings.EqualFold(os.Getenv("BLOCKCHAIN_MODE"), "simulated") {
+			active = NetworkSimulated
+		}
+	}
+
+	network, ok := registry.Get(active)
+	if !ok {
+		return nil, fmt.Errorf("unknown BLOCKCHAIN_NETWORK %q", active)
+	}
+
+	// Env overrides for the active network only
+	if rpcURLs := splitList(os.Getenv("BLOCKCHAIN_RPC_URL")); len(rpcURLs) > 0 {
+		network.RPCURLs = rpcURLs
+	}
+	if contractAddress := os.Getenv("CONTRACT_ADDRESS"); contractAddress != "" {
+		network.ContractAddress = contractAddress
+	}
+	if chainID := os.Getenv("CHAIN_ID"); chainID != "" {
+		parsed, err := strconv.ParseInt(chainID, 10, 64)
+		if err != nil || parsed <= 0 {
+			return nil, fmt.Errorf("invalid CHAIN_ID %q", chainID)
+		}
+		network.ChainID = parsed
+	}
+	if os.Getenv("BLOCKCHAIN_CONFIRMATIONS") != "" {
+		network.Confirmations = getEnvUint("BLOCKCHAIN_CONFIRMATIONS", network.Confirmations)
+	}
+
+	registry.add(network)
+	registry.active = active
+
+	log.Printf("⛓️  Active network: %s (%s, chain ID %d)", network.Name, network.Key, network.ChainID)
+	return registry, nil
+}
+
+// readNetworksFile reads a JSON array of networks
+func readNetworksFile(path string) ([]Network, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read NETWORKS_FILE: %v", err)
+	}
+
+	var networks []Network
+	if err := json.Unmarshal(data, &networks); err != nil {
+		return nil, fmt.Errorf("invalid NETWORKS_FILE: %v", err)
+	}
+
+	for i := range networks {
+		network := &networks[i]
+		if network.Key == "" {
+			return nil, fmt.Errorf("invalid NETWORKS_FILE: network without key")
+		}
+		if network.ChainID <= 0 {
+			return nil, fmt.Errorf("invalid NETWORKS_FILE: network %q has no chain_id", network.Key)
+		}
+		if !network.Simulated && len(network.RPCURLs) == 0 {
+			return nil, fmt.Errorf("invalid NETWORKS_FILE: network %q has no rpc_urls", network.Key)
+		}
+		if network.Name == "" {
+			network.Name = network.Key
+		}
+	}
+	return networks, nil
+}
+
+// splitList splits a comma separated env var, dropping empty items
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
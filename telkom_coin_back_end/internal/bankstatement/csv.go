@@ -0,0 +1,157 @@
+package bankstatement
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Header names (lower case) accepted for each CSV column, English and Indonesian
+var csvColumns = map[string][]string{
+	"date":        {"date", "transaction_date", "booking_date", "posting_date", "tanggal", "tgl"},
+	"description": {"description", "remark", "remarks", "narrative", "keterangan", "uraian"},
+	"amount":      {"amount", "nominal", "jumlah", "mutasi"},
+	"type":        {"type", "dc", "cr/db", "db/cr", "d/k", "jenis"},
+	"credit":      {"credit", "kredit", "cr"},
+	"debit":       {"debit", "debet", "db"},
+	"reference":   {"reference", "ref", "bank_reference", "referensi", "no_ref"},
+	"account":     {"account", "account_number", "rekening", "no_rekening"},
+}
+
+var csvDateLayouts = []struct {
+	layout  string
+	hasTime bool
+}{
+	{time.RFC3339, true},
+	{"2006-01-02 15:04:05", true},
+	{"2006-01-02 15:04", true},
+	{"02/01/2006 15:04:05", true},
+	{"02/01/2006 15:04", true},
+	{"2006-01-02", false},
+	{"02/01/2006", false},
+	{"02-01-2006", false},
+	{"02/01/06", false},
+}
+
+// parseCSV reads a statement export with a header row. It needs date and
+// either amount (negative or typed D/DB for debits) or credit and debit columns.
+func parseCSV(r io.Reader) (*Statement, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %v", err)
+	}
+	// Semicolon exports read as one column
+	if len(header) == 1 && strings.Contains(header[0], ";") {
+		return nil, errors.New("CSV must be comma separated")
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		for column, aliases := range csvColumns {
+			for _, alias := range aliases {
+				if name == alias {
+					if _, seen := columns[column]; !seen {
+						columns[column] = i
+					}
+				}
+			}
+		}
+	}
+	_, hasAmount := columns["amount"]
+	_, hasCredit := columns["credit"]
+	if _, ok := columns["date"]; !ok || (!hasAmount && !hasCredit) {
+		return nil, errors.New("CSV needs a date column and an amount or credit column")
+	}
+
+	statement := &Statement{Format: FormatCSV}
+	line := 1
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		line++
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+		field := func(column string) string {
+			if i, ok := columns[column]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		if strings.Join(record, "") == "" {
+			continue
+		}
+
+		entry := Entry{
+			Line:          line,
+			Description:   field("description"),
+			BankReference: field("reference"),
+		}
+		if entry.Date, entry.HasTime, err = parseCSVDate(field("date")); err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+
+		if hasCredit {
+			entry.Amount, entry.Credit, err = creditDebitAmount(field("credit"), field("debit"))
+		} else {
+			var negative bool
+			entry.Amount, negative, err = ParseAmount(field("amount"))
+			entry.Credit = !negative && !isDebitType(field("type"))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+
+		if statement.AccountNumber == "" {
+			statement.AccountNumber = field("account")
+		}
+		statement.Entries = append(statement.Entries, entry)
+	}
+	return statement, nil
+}
+
+func parseCSVDate(value string) (time.Time, bool, error) {
+	for _, f := range csvDateLayouts {
+		if t, err := time.ParseInLocation(f.layout, value, bankLocation); err == nil {
+			return t, f.hasTime, nil
+		}
+	}
+	return time.Time{}, false, fmt.Errorf("invalid date %q", value)
+}
+
+// creditDebitAmount reads a row of a statement with separate credit and debit
+// columns; a zero credit counts as empty
+func creditDebitAmount(credit, debit string) (string, bool, error) {
+	if credit != "" {
+		amount, _, err := ParseAmount(credit)
+		if err != nil {
+			return "", false, err
+		}
+		if amount != "0" {
+			return amount, true, nil
+		}
+	}
+	if debit == "" {
+		return "", false, errors.New("row has no credit or debit amount")
+	}
+	amount, _, err := ParseAmount(debit)
+	return amount, false, err
+}
+
+func isDebitType(value string) bool {
+	switch strings.ToUpper(value) {
+	case "D", "DB", "DR", "DEBIT", "DEBET":
+		return true
+	}
+	return false
+}
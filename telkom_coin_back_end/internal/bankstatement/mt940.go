@@ -0,0 +1,132 @@
+package bankstatement
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// :61: statement line: value date YYMMDD, optional entry date MMDD, debit/credit
+// mark (C, D, RC, RD), optional funds code, amount with a decimal comma,
+// transaction type (N + 3 characters), customer reference, then //bank reference
+var mt940StatementLine = regexp.MustCompile(`^(\d{6})(\d{4})?(RC|RD|C|D)([A-Z])?(\d+,\d{0,2})([NFS][A-Z0-9]{3})(.*)$`)
+
+var mt940Tag = regexp.MustCompile(`^:(\d{2}[A-Z]?):`)
+
+type mt940Field struct {
+	tag   string
+	value string
+	line  int
+}
+
+// parseMT940 reads a SWIFT MT940 customer statement. The :86: information
+// after a :61: line becomes the entry's description.
+func parseMT940(r io.Reader) (*Statement, error) {
+	fields, err := readMT940Fields(r)
+	if err != nil {
+		return nil, err
+	}
+
+	statement := &Statement{Format: FormatMT940}
+	var last *Entry
+	for _, field := range fields {
+		switch field.tag {
+		case "25":
+			if statement.AccountNumber == "" {
+				statement.AccountNumber = strings.TrimSpace(field.value)
+			}
+		case "61":
+			entry, err := parseMT940StatementLine(field)
+			if err != nil {
+				return nil, err
+			}
+			statement.Entries = append(statement.Entries, *entry)
+			last = &statement.Entries[len(statement.Entries)-1]
+			continue
+		case "86":
+			if last != nil {
+				info := strings.Join(strings.Fields(field.value), " ")
+				last.Description = strings.TrimSpace(last.Description + " " + info)
+			}
+		}
+		last = nil
+	}
+	return statement, nil
+}
+
+func parseMT940StatementLine(field mt940Field) (*Entry, error) {
+	first, supplementary, _ := strings.Cut(field.value, "\n")
+	match := mt940StatementLine.FindStringSubmatch(strings.TrimSpace(first))
+	if match == nil {
+		return nil, fmt.Errorf("line %d: invalid :61: statement line", field.line)
+	}
+
+	date, err := time.ParseInLocation("060102", match[1], bankLocation)
+	if err != nil {
+		return nil, fmt.Errorf("line %d: invalid value date %q", field.line, match[1])
+	}
+	amount, _, err := ParseAmount(match[5])
+	if err != nil {
+		return nil, fmt.Errorf("line %d: %v", field.line, err)
+	}
+
+	customerRef, bankRef, _ := strings.Cut(match[7], "//")
+	description := []string{}
+	if ref := strings.TrimSpace(customerRef); ref != "" && ref != "NONREF" {
+		description = append(description, ref)
+	}
+	if details := strings.Join(strings.Fields(supplementary), " "); details != "" {
+		description = append(description, details)
+	}
+
+	return &Entry{
+		Line: field.line,
+		Date: date,
+		// A reversed debit (RD) brings money back in
+		Credit:        match[3] == "C" || match[3] == "RD",
+		Amount:        amount,
+		Description:   strings.Join(description, " "),
+		BankReference: strings.TrimSpace(bankRef),
+	}, nil
+}
+
+// readMT940Fields splits the file into :tag: fields, joining continuation
+// lines and skipping SWIFT block headers and message separators
+func readMT940Fields(r io.Reader) ([]mt940Field, error) {
+	var fields []mt940Field
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimRight(scanner.Text(), "\r ")
+		if lineNo == 1 {
+			line = strings.TrimPrefix(line, "\ufeff")
+		}
+		if i := strings.Index(line, "{4:"); strings.HasPrefix(line, "{") && i >= 0 {
+			line = line[i+3:]
+		}
+		if line == "" || line == "-" || line == "-}" || strings.HasPrefix(line, "{") {
+			continue
+		}
+
+		if tag := mt940Tag.FindStringSubmatch(line); tag != nil {
+			fields = append(fields, mt940Field{tag: tag[1], value: line[len(tag[0]):], line: lineNo})
+			continue
+		}
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("line %d: MT940 must start with a :20: field", lineNo)
+		}
+		fields[len(fields)-1].value += "\n" + line
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, errors.New("no MT940 fields found")
+	}
+	return fields, nil
+}
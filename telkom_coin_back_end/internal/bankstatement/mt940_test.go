@@ -0,0 +1,128 @@
+package bankstatement
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseMT940StatementLine(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    Entry
+		wantErr bool
+	}{
+		{
+			name:  "credit without reference",
+			value: "2410151015C1500000,00NTRFNONREF//B123",
+			want:  Entry{Date: time.Date(2024, 10, 15, 0, 0, 0, 0, bankLocation), Credit: true, Amount: "1500000", BankReference: "B123"},
+		},
+		{
+			name:  "debit without entry date",
+			value: "241015D25000,50NTRFREF-1",
+			want:  Entry{Date: time.Date(2024, 10, 15, 0, 0, 0, 0, bankLocation), Amount: "25000.50", Description: "REF-1"},
+		},
+		{
+			name:  "reversed debit is a credit",
+			value: "2410151015RD100,NMSCREF-2//B2",
+			want:  Entry{Date: time.Date(2024, 10, 15, 0, 0, 0, 0, bankLocation), Credit: true, Amount: "100", Description: "REF-2", BankReference: "B2"},
+		},
+		{
+			name:  "reversed credit is a debit",
+			value: "2410151015RC100,NMSCREF-3",
+			want:  Entry{Date: time.Date(2024, 10, 15, 0, 0, 0, 0, bankLocation), Amount: "100", Description: "REF-3"},
+		},
+		{
+			name:  "funds code and supplementary details",
+			value: "2410151015CR50000,NTRFINV-7//BR1\nTOPUP  TLC-123",
+			want:  Entry{Date: time.Date(2024, 10, 15, 0, 0, 0, 0, bankLocation), Credit: true, Amount: "50000", Description: "INV-7 TOPUP TLC-123", BankReference: "BR1"},
+		},
+		{name: "amount without decimal comma", value: "2410151015C100NTRFREF", wantErr: true},
+		{name: "short value date", value: "24101C100,00NTRFREF", wantErr: true},
+		{name: "invalid value date", value: "241315C100,00NTRFREF", wantErr: true},
+		{name: "missing transaction type", value: "241015C100,00", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry, err := parseMT940StatementLine(mt940Field{tag: "61", value: tt.value, line: 4})
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseMT940StatementLine(%q) = %+v, want error", tt.value, entry)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseMT940StatementLine(%q) error = %v", tt.value, err)
+			}
+			tt.want.Line = 4
+			if !entry.Date.Equal(tt.want.Date) {
+				t.Errorf("Date = %s, want %s", entry.Date, tt.want.Date)
+			}
+			entry.Date = tt.want.Date
+			if *entry != tt.want {
+				t.Errorf("parseMT940StatementLine(%q) = %+v, want %+v", tt.value, *entry, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseMT940(t *testing.T) {
+	file := strings.Join([]string{
+		"{1:F01BANKIDJAXXXX0000000000}{2:O9400000000000BANKIDJAXXXX00000000000000000000N}{4:",
+		":20:STMT241015",
+		":25:1234567890",
+		":28C:1/1",
+		":60F:C241014IDR1000000,00",
+		":61:2410151015C150000,00NTRFNONREF//B1",
+		":86:TRANSFER DARI BUDI",
+		"TOPUP TLC-AB12",
+		":61:2410161016D20000,00NTRFFEE",
+		":62F:C241016IDR1130000,00",
+		":86:closing balance information",
+		"-}",
+	}, "\r\n")
+
+	statement, err := Parse(FormatMT940, strings.NewReader(file))
+	if err != nil {
+		t.Fatalf("Parse error = %v", err)
+	}
+	if statement.AccountNumber != "1234567890" {
+		t.Errorf("AccountNumber = %q", statement.AccountNumber)
+	}
+	if len(statement.Entries) != 2 {
+		t.Fatalf("got %d entries, want 2", len(statement.Entries))
+	}
+
+	credit, debit := statement.Entries[0], statement.Entries[1]
+	if !credit.Credit || credit.Amount != "150000" || credit.Line != 6 || credit.BankReference != "B1" {
+		t.Errorf("credit entry = %+v", credit)
+	}
+	if credit.Description != "TRANSFER DARI BUDI TOPUP TLC-AB12" {
+		t.Errorf("credit description = %q", credit.Description)
+	}
+	// :86: after a balance field does not belong to an entry
+	if debit.Credit || debit.Amount != "20000" || debit.Description != "FEE" {
+		t.Errorf("debit entry = %+v", debit)
+	}
+	if want := time.Date(2024, 10, 15, 0, 0, 0, 0, bankLocation); !statement.From.Equal(want) {
+		t.Errorf("From = %s, want %s", statement.From, want)
+	}
+	if want := time.Date(2024, 10, 16, 0, 0, 0, 0, bankLocation); !statement.To.Equal(want) {
+		t.Errorf("To = %s, want %s", statement.To, want)
+	}
+}
+
+func TestParseMT940Rejects(t *testing.T) {
+	tests := map[string]string{
+		"empty":                  "",
+		"no leading tag":         "hello\n:20:X",
+		"no statement lines":     ":20:STMT\n:25:123",
+		"invalid statement line": ":20:STMT\n:61:garbage",
+	}
+	for name, file := range tests {
+		if _, err := Parse(FormatMT940, strings.NewReader(file)); err == nil {
+			t.Errorf("%s: Parse accepted %q", name, file)
+		}
+	}
+}
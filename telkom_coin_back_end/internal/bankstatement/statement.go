@@ -0,0 +1,137 @@
+// Package bankstatement reads bank account statements (CSV exports and SWIFT
+// MT940) into a list of dated credit and debit entries.
+package bankstatement
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	FormatCSV   = "csv"
+	FormatMT940 = "mt940"
+)
+
+var ErrUnknownFormat = errors.New("unknown statement format, use csv or mt940")
+
+// Statement dates have no time zone; our banks book in WIB
+var bankLocation = time.FixedZone("WIB", 7*60*60)
+
+// Entry is one booked line of a statement
+type Entry struct {
+	Line          int       // Line in the file, for error messages and the report
+	Date          time.Time // Booking date (midnight WIB when the file has no time)
+	HasTime       bool
+	Credit        bool   // Money in; debits are kept but not reconciled
+	Amount        string // IDR, e.g. "50000" or "50000.50"
+	Description   string
+	BankReference string
+}
+
+// Statement is a parsed statement file
+type Statement struct {
+	Format        string
+	AccountNumber string
+	From          time.Time // First booking day
+	To            time.Time // Last booking day
+	Entries       []Entry
+}
+
+// DetectFormat guesses the format from the file name
+func DetectFormat(fileName string) string {
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".csv":
+		return FormatCSV
+	case ".sta", ".mt940", ".940", ".txt":
+		return FormatMT940
+	}
+	return ""
+}
+
+// Parse reads a statement in the given format
+func Parse(format string, r io.Reader) (*Statement, error) {
+	var (
+		statement *Statement
+		err       error
+	)
+	switch strings.ToLower(format) {
+	case FormatCSV:
+		statement, err = parseCSV(r)
+	case FormatMT940:
+		statement, err = parseMT940(r)
+	default:
+		return nil, ErrUnknownFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(statement.Entries) == 0 {
+		return nil, errors.New("statement has no entries")
+	}
+
+	for _, entry := range statement.Entries {
+		day := startOfDay(entry.Date)
+		if statement.From.IsZero() || day.Before(statement.From) {
+			statement.From = day
+		}
+		if day.After(statement.To) {
+			statement.To = day
+		}
+	}
+	return statement, nil
+}
+
+// ParseAmount reads a bank amount such as "1.500.000,00", "1,500,000.00",
+// "IDR 50000" or "-25.000" into a normalized decimal string and its sign
+func ParseAmount(raw string) (amount string, negative bool, err error) {
+	s := strings.ToUpper(strings.TrimSpace(raw))
+	s = strings.TrimPrefix(s, "IDR")
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "RP"), ".") // "Rp. 50.000"
+	s = strings.NewReplacer(" ", "", "\u00a0", "").Replace(strings.TrimSpace(s))
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		s, negative = s[1:len(s)-1], true
+	}
+	if strings.HasPrefix(s, "-") {
+		s, negative = s[1:], true
+	} else if strings.HasSuffix(s, "-") {
+		s, negative = s[:len(s)-1], true
+	}
+
+	// The last separator is the decimal one when two digits or fewer follow it
+	// and, if both kinds are used, it is the last kind; otherwise all are grouping
+	whole, fraction := s, ""
+	if i := strings.LastIndexAny(s, ".,"); i >= 0 {
+		other := ","
+		if s[i] == ',' {
+			other = "."
+		}
+		digitsAfter := len(s) - i - 1
+		single := strings.Count(s, string(s[i])) == 1
+		if digitsAfter <= 2 && (strings.Contains(s[:i], other) || single) {
+			whole, fraction = s[:i], s[i+1:]
+		}
+	}
+	whole = strings.NewReplacer(".", "", ",", "").Replace(whole)
+
+	value, ok := new(big.Rat).SetString(whole + "." + fraction + "0")
+	if whole == "" || !ok || strings.ContainsAny(whole+fraction, "+-eE/") {
+		return "", false, fmt.Errorf("invalid amount %q", raw)
+	}
+	return FormatAmount(value), negative, nil
+}
+
+// FormatAmount prints an IDR amount with two decimals, or none when whole
+func FormatAmount(value *big.Rat) string {
+	formatted := value.FloatString(2)
+	return strings.TrimSuffix(formatted, ".00")
+}
+
+func startOfDay(t time.Time) time.Time {
+	t = t.In(bankLocation)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, bankLocation)
+}
@@ -0,0 +1,68 @@
+package bankstatement
+
+import "testing"
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		raw          string
+		want         string
+		wantNegative bool
+		wantErr      bool
+	}{
+		{raw: "50000", want: "50000"},
+		{raw: "1.500.000,00", want: "1500000"},
+		{raw: "1,500,000.00", want: "1500000"},
+		{raw: "1.234,5", want: "1234.50"},
+		{raw: "50000,5", want: "50000.50"},
+		{raw: "1.50", want: "1.50"},
+		{raw: "100,", want: "100"},
+		{raw: "1.000.000", want: "1000000"}, // Several points are grouping
+		{raw: "12,345", want: "12345"},      // Three digits after a single separator are grouping
+		{raw: "IDR 50000", want: "50000"},
+		{raw: "Rp. 50.000", want: "50000"},
+		{raw: "Rp 1 000", want: "1000"},
+		{raw: "-25.000", want: "25000", wantNegative: true},
+		{raw: "25.000-", want: "25000", wantNegative: true},
+		{raw: "(1.000)", want: "1000", wantNegative: true},
+		{raw: "0,00", want: "0"},
+		{raw: "", wantErr: true},
+		{raw: "IDR", wantErr: true},
+		{raw: "abc", wantErr: true},
+		{raw: "1e5", wantErr: true},
+		{raw: "+500", wantErr: true},
+		{raw: "1/2", wantErr: true},
+	}
+	for _, tt := range tests {
+		amount, negative, err := ParseAmount(tt.raw)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseAmount(%q) = %q, want error", tt.raw, amount)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseAmount(%q) error = %v", tt.raw, err)
+			continue
+		}
+		if amount != tt.want || negative != tt.wantNegative {
+			t.Errorf("ParseAmount(%q) = %q, %v, want %q, %v", tt.raw, amount, negative, tt.want, tt.wantNegative)
+		}
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	tests := map[string]string{
+		"statement.csv":  FormatCSV,
+		"STATEMENT.CSV":  FormatCSV,
+		"bca_2024.sta":   FormatMT940,
+		"export.940":     FormatMT940,
+		"statement.txt":  FormatMT940,
+		"statement.xlsx": "",
+		"statement":      "",
+	}
+	for fileName, want := range tests {
+		if got := DetectFormat(fileName); got != want {
+			t.Errorf("DetectFormat(%q) = %q, want %q", fileName, got, want)
+		}
+	}
+}
@@ -2,34 +2,62 @@ package blockchain
 
 import (
 	"context"
-	"crypto/sha256"
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"log"
 	"math/big"
-	"strconv"
-	"time"
 
+	"telkom_coin_back_end/config"
 	"telkom_coin_back_end/internal/web3"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Transaction statuses reported from chain receipts
+const (
+	TxStatusPending   = "pending"
+	TxStatusConfirmed = "confirmed"
+	TxStatusFailed    = "failed"
+	TxStatusNotFound  = "not_found"
 )
 
 // BlockchainService handles blockchain operations
 type BlockchainService struct {
-	NetworkID       string
+	Network         config.Network
 	web3Client      *web3.Web3Client
 	contractService *web3.ContractService
 }
 
-// NewBlockchainService creates a new blockchain service
-func NewBlockchainService(networkID string) (*BlockchainService, error) {
+// TransactionReceipt is the on-chain state of a transaction
+type TransactionReceipt struct {
+	TxHash            string
+	Status            string // pending, confirmed, failed or not_found
+	BlockNumber       uint64
+	BlockHash         string
+	GasUsed           uint64
+	EffectiveGasPrice *big.Int
+	Confirmations     uint64 // blocks since inclusion, counting the block itself
+}
+
+// NewBlockchainService creates a blockchain service for a network from the registry
+func NewBlockchainService(networkKey string) (*BlockchainService, error) {
+	network, ok := config.Networks().Get(networkKey)
+	if !ok {
+		return nil, fmt.Errorf("unknown network: %s", networkKey)
+	}
+
 	// Initialize web3 client
-	web3Client, err := web3.NewWeb3Client()
+	web3Client, err := web3.NewWeb3ClientForNetwork(network)
 	if err != nil {
+		log.Printf("⚠️  Web3 client unavailable on %s: %v", network.Name, err)
 		// Fallback to simulation mode if web3 fails
 		return &BlockchainService{
-			NetworkID:       networkID,
+			Network:         network,
 			web3Client:      nil,
 			contractService: nil,
 		}, nil
@@ -40,29 +68,60 @@ func NewBlockchainService(networkID string) (*BlockchainService, error) {
 	if err != nil {
 		// Fallback to simulation mode if contract fails
 		return &BlockchainService{
-			NetworkID:       networkID,
+			Network:         network,
 			web3Client:      nil,
 			contractService: nil,
 		}, nil
 	}
 
 	return &BlockchainService{
-		NetworkID:       networkID,
+		Network:         network,
 		web3Client:      web3Client,
 		contractService: contractService,
 	}, nil
 }
 
+// NewActiveBlockchainService creates a blockchain service for the active network (BLOCKCHAIN_NETWORK)
+func NewActiveBlockchainService() (*BlockchainService, error) {
+	return NewBlockchainService(config.ActiveNetwork().Key)
+}
+
 // IsWeb3Enabled checks if web3 is available
 func (bs *BlockchainService) IsWeb3Enabled() bool {
 	return bs.web3Client != nil && bs.contractService != nil
 }
 
+// IsSimulated checks if web3 runs on the in-process simulated chain (BLOCKCHAIN_NETWORK=simulated)
+func (bs *BlockchainService) IsSimulated() bool {
+	return bs.IsWeb3Enabled() && bs.web3Client.IsSimulated()
+}
+
+// ContractAddress returns the PaymentToken address, empty when web3 is disabled
+func (bs *BlockchainService) ContractAddress() string {
+	if bs.web3Client == nil {
+		return ""
+	}
+	return bs.web3Client.GetContractAddress().Hex()
+}
+
+// ChainStatus reports the connectivity of the network's RPC endpoints
+func (bs *BlockchainService) ChainStatus() web3.ChainStatus {
+	if bs.web3Client == nil {
+		// Client could not be created (missing config), nothing is reachable
+		return web3.ChainStatus{
+			Network:        bs.Network.Key,
+			ChainID:        bs.Network.ChainID,
+			Status:         web3.ChainStatusDown,
+			TotalEndpoints: len(bs.Network.RPCURLs),
+		}
+	}
+	return bs.web3Client.ChainStatus()
+}
+
 // GetTokenBalance gets token balance from smart contract
 func (bs *BlockchainService) GetTokenBalance(address string) (*big.Int, error) {
 	if !bs.IsWeb3Enabled() {
-		// Fallback to simulation
-		return big.NewInt(0), nil
+		return nil, errors.New("blockchain not available")
 	}
 
 	addr := common.HexToAddress(address)
@@ -100,8 +159,6 @@ func (bs *BlockchainService) TransferTokens(fromAddress, toAddress string, amoun
 	return bs.contractService.Transfer("", to, amount)
 }
 
-// Removed simulation functions - pure blockchain mode only
-
 // ValidateTransactionHash validates if a transaction hash is valid
 func (bs *BlockchainService) ValidateTransactionHash(hash string) bool {
 	// Check if hash starts with 0x and has correct length (66 characters)
@@ -114,27 +171,6 @@ func (bs *BlockchainService) ValidateTransactionHash(hash string) bool {
 	return err == nil
 }
 
-// GenerateBlockHash generates a hash for a block of transactions
-func (bs *BlockchainService) GenerateBlockHash(txHashes []string, blockNumber int64, previousHash string) string {
-	// Combine all transaction hashes
-	var combinedHashes string
-	for _, hash := range txHashes {
-		combinedHashes += hash
-	}
-
-	// Create block data
-	blockData := fmt.Sprintf("%s:%s:%d:%d",
-		previousHash,
-		combinedHashes,
-		blockNumber,
-		time.Now().Unix(),
-	)
-
-	// Generate SHA256 hash
-	hash := sha256.Sum256([]byte(blockData))
-	return "0x" + hex.EncodeToString(hash[:])
-}
-
 // GetNextNonce gets real nonce from blockchain
 func (bs *BlockchainService) GetNextNonce(address string) (uint64, error) {
 	if !bs.IsWeb3Enabled() {
@@ -146,104 +182,247 @@ func (bs *BlockchainService) GetNextNonce(address string) (uint64, error) {
 	return bs.web3Client.GetClient().PendingNonceAt(context.Background(), addr)
 }
 
-// EstimateGas estimates gas needed for a transaction
-func (bs *BlockchainService) EstimateGas(txType string, amount string) int64 {
-	// Simple gas estimation based on transaction type
-	baseGas := int64(21000) // Base gas for simple transfer
+// EstimateGas estimates the gas limit (including the safety margin) of a contract call
+func (bs *BlockchainService) EstimateGas(txType, fromAddress, toAddress string, amountWei *big.Int) (uint64, error) {
+	fee, err := bs.CalculateTransactionFee(txType, fromAddress, toAddress, amountWei)
+	if err != nil {
+		return 0, err
+	}
+	return fee.GasLimit, nil
+}
 
+// GetGasPrice returns the current effective gas price (base fee + tip, or legacy gas price)
+func (bs *BlockchainService) GetGasPrice() (*big.Int, error) {
+	if !bs.IsWeb3Enabled() {
+		return nil, errors.New("blockchain not available")
+	}
+
+	ctx := context.Background()
+	client := bs.web3Client.GetClient()
+
+	header, err := client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	if header.BaseFee == nil {
+		return client.SuggestGasPrice(ctx)
+	}
+
+	tip, err := client.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).Add(header.BaseFee, tip), nil
+}
+
+// CalculateTransactionFee estimates the real network fee of a transaction before it is sent.
+// txType is one of transfer, topup or withdraw; toAddress is only used for transfers.
+func (bs *BlockchainService) CalculateTransactionFee(txType, fromAddress, toAddress string, amountWei *big.Int) (*web3.FeeEstimate, error) {
+	if !bs.IsWeb3Enabled() {
+		return nil, errors.New("blockchain not available")
+	}
+
+	from := common.HexToAddress(fromAddress)
 	switch txType {
 	case "transfer":
-		return baseGas
+		return bs.contractService.EstimateTransferFee(from, common.HexToAddress(toAddress), amountWei)
 	case "topup":
-		return baseGas + 10000 // Additional gas for topup operations
+		return bs.contractService.EstimateTopupFee(from, amountWei, "FEE_ESTIMATE")
 	case "withdraw":
-		return baseGas + 15000 // Additional gas for withdraw operations
+		return bs.contractService.EstimateWithdrawFee(from, amountWei, "FEE_ESTIMATE")
 	default:
-		return baseGas
+		return nil, fmt.Errorf("unsupported transaction type: %s", txType)
 	}
 }
 
-// GetGasPrice returns current gas price
-func (bs *BlockchainService) GetGasPrice() int64 {
-	// In a real implementation, this would query the network
-	// For now, return a fixed gas price (in wei)
-	return 20000000000 // 20 Gwei
-}
+// VerifyTransactionSignature checks that signature is a secp256k1 signature over
+// the 32-byte txHash made by signer. signer is either an address or a hex public
+// key (compressed or uncompressed); the signature is 65 bytes [R || S || V] with
+// V as 0/1 or 27/28.
+func (bs *BlockchainService) VerifyTransactionSignature(txHash, signature, signer string) (bool, error) {
+	if !bs.ValidateTransactionHash(txHash) {
+		return false, errors.New("invalid transaction hash")
+	}
+	digest := common.HexToHash(txHash)
+
+	sig, err := hexutil.Decode(signature)
+	if err != nil || len(sig) != crypto.SignatureLength {
+		return false, errors.New("invalid signature format")
+	}
+	// Wallets encode the recovery ID as 27/28
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+
+	recovered, err := crypto.SigToPub(digest.Bytes(), sig)
+	if err != nil {
+		return false, nil
+	}
+	recoveredAddress := crypto.PubkeyToAddress(*recovered)
 
-// CalculateTransactionFee calculates the transaction fee
-func (bs *BlockchainService) CalculateTransactionFee(txType string, amount string) int64 {
-	gasLimit := bs.EstimateGas(txType, amount)
-	gasPrice := bs.GetGasPrice()
-	return gasLimit * gasPrice
+	expected, err := signerAddress(signer)
+	if err != nil {
+		return false, err
+	}
+	return recoveredAddress == expected, nil
 }
 
-// VerifyTransactionSignature verifies transaction signature (placeholder)
-func (bs *BlockchainService) VerifyTransactionSignature(txHash, signature, publicKey string) bool {
-	// In a real implementation, this would verify the cryptographic signature
-	// For now, return true as placeholder
-	return len(signature) > 0 && len(publicKey) > 0
+// signerAddress turns an address or a hex public key into an address
+func signerAddress(signer string) (common.Address, error) {
+	if common.IsHexAddress(signer) {
+		return common.HexToAddress(signer), nil
+	}
+
+	raw, err := hexutil.Decode(signer)
+	if err != nil {
+		return common.Address{}, errors.New("invalid signer format")
+	}
+
+	switch len(raw) {
+	case 33:
+		publicKey, err := crypto.DecompressPubkey(raw)
+		if err != nil {
+			return common.Address{}, errors.New("invalid public key")
+		}
+		return crypto.PubkeyToAddress(*publicKey), nil
+	case 65:
+		publicKey, err := crypto.UnmarshalPubkey(raw)
+		if err != nil {
+			return common.Address{}, errors.New("invalid public key")
+		}
+		return crypto.PubkeyToAddress(*publicKey), nil
+	default:
+		return common.Address{}, errors.New("invalid signer format")
+	}
 }
 
-// BroadcastTransaction validates transaction hash (pure blockchain mode)
-func (bs *BlockchainService) BroadcastTransaction(txHash string) error {
-	// In pure blockchain mode, transactions are automatically broadcast by Web3
-	if !bs.ValidateTransactionHash(txHash) {
-		return fmt.Errorf("invalid transaction hash format")
+// BroadcastTransaction sends a signed raw transaction (hex encoded, as produced
+// by eth_signTransaction) and returns its hash
+func (bs *BlockchainService) BroadcastTransaction(rawTx string) (string, error) {
+	if !bs.IsWeb3Enabled() {
+		return "", errors.New("blockchain not available")
 	}
-	return nil
+
+	data, err := hexutil.Decode(rawTx)
+	if err != nil {
+		return "", errors.New("invalid raw transaction format")
+	}
+
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(data); err != nil {
+		return "", fmt.Errorf("invalid raw transaction: %v", err)
+	}
+
+	if err := bs.web3Client.GetClient().SendTransaction(context.Background(), tx); err != nil {
+		return "", fmt.Errorf("failed to broadcast transaction: %v", err)
+	}
+	return tx.Hash().Hex(), nil
 }
 
-// GetTransactionStatus gets transaction status from blockchain
-func (bs *BlockchainService) GetTransactionStatus(txHash string) (string, error) {
-	// In a real implementation, this would query the blockchain
-	// For now, simulate different statuses based on hash
+// GetTransactionReceipt looks a transaction up on chain. A transaction that is
+// not mined yet, or mined with fewer blocks on top than the network's confirmation
+// depth, is pending; a reverted one is failed.
+func (bs *BlockchainService) GetTransactionReceipt(txHash string) (*TransactionReceipt, error) {
+	if !bs.IsWeb3Enabled() {
+		return nil, errors.New("blockchain not available")
+	}
 	if !bs.ValidateTransactionHash(txHash) {
-		return "", fmt.Errorf("invalid transaction hash")
+		return nil, errors.New("invalid transaction hash")
 	}
 
-	// Simple simulation: use last character of hash to determine status
-	lastChar := txHash[len(txHash)-1:]
-	switch lastChar {
-	case "0", "1", "2", "3", "4", "5":
-		return "confirmed", nil
-	case "6", "7", "8":
-		return "pending", nil
+	ctx := context.Background()
+	client := bs.web3Client.GetClient()
+	hash := common.HexToHash(txHash)
+	result := &TransactionReceipt{TxHash: hash.Hex()}
+
+	receipt, err := client.TransactionReceipt(ctx, hash)
+	if errors.Is(err, ethereum.NotFound) {
+		// No receipt yet: still in the mempool, or unknown to the node
+		_, _, err := client.TransactionByHash(ctx, hash)
+		if errors.Is(err, ethereum.NotFound) {
+			result.Status = TxStatusNotFound
+			return result, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get transaction: %v", err)
+		}
+		result.Status = TxStatusPending
+		return result, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction receipt: %v", err)
+	}
+
+	head, err := client.BlockNumber(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest block: %v", err)
+	}
+
+	result.BlockNumber = receipt.BlockNumber.Uint64()
+	result.BlockHash = receipt.BlockHash.Hex()
+	result.GasUsed = receipt.GasUsed
+	result.EffectiveGasPrice = receipt.EffectiveGasPrice
+	if head >= result.BlockNumber {
+		result.Confirmations = head - result.BlockNumber + 1
+	}
+
+	switch {
+	case receipt.Status != types.ReceiptStatusSuccessful:
+		result.Status = TxStatusFailed
+	case result.Confirmations < bs.Network.Confirmations:
+		result.Status = TxStatusPending
 	default:
-		return "failed", nil
+		result.Status = TxStatusConfirmed
+	}
+	return result, nil
+}
+
+// GetTransactionStatus returns pending, confirmed, failed or not_found from the transaction receipt
+func (bs *BlockchainService) GetTransactionStatus(txHash string) (string, error) {
+	receipt, err := bs.GetTransactionReceipt(txHash)
+	if err != nil {
+		return "", err
 	}
+	return receipt.Status, nil
 }
 
-// GetBlockNumber returns current block number
-func (bs *BlockchainService) GetBlockNumber() int64 {
-	// In a real implementation, this would query the blockchain
-	// For now, simulate block number based on time
-	return time.Now().Unix() / 15 // Assuming 15 second block time
+// GetBlockNumber returns the latest block number of the chain
+func (bs *BlockchainService) GetBlockNumber() (uint64, error) {
+	if !bs.IsWeb3Enabled() {
+		return 0, errors.New("blockchain not available")
+	}
+	return bs.web3Client.GetClient().BlockNumber(context.Background())
 }
 
-// ConvertToWei converts amount from token units to wei (smallest unit)
+// ConvertToWei converts a decimal token amount (e.g. "1.5") to wei (smallest unit)
 func (bs *BlockchainService) ConvertToWei(amount string) (string, error) {
-	// Assuming 18 decimals like Ethereum
-	// In a real implementation, this would depend on token decimals
-	return amount + "000000000000000000", nil
+	amountWei, err := ParseTokenAmount(amount)
+	if err != nil {
+		return "", err
+	}
+	return amountWei.String(), nil
 }
 
-// ConvertFromWei converts amount from wei to token units
+// ConvertFromWei converts an amount in wei to a decimal token amount without losing precision
 func (bs *BlockchainService) ConvertFromWei(amountWei string) (string, error) {
-	// Simple conversion assuming 18 decimals
-	if len(amountWei) <= 18 {
-		return "0", nil
+	wei, ok := new(big.Int).SetString(amountWei, 10)
+	if !ok || wei.Sign() < 0 {
+		return "", errors.New("invalid wei amount")
 	}
-
-	return amountWei[:len(amountWei)-18], nil
+	return FormatTokenAmount(wei), nil
 }
 
-// GenerateWalletAddress generates a new wallet address (placeholder)
-func (bs *BlockchainService) GenerateWalletAddress() string {
-	// In a real implementation, this would generate a proper Ethereum address
-	// For now, generate a simple address-like string
-	timestamp := strconv.FormatInt(time.Now().UnixNano(), 16)
-	hash := sha256.Sum256([]byte(timestamp))
-	return "0x" + hex.EncodeToString(hash[:20]) // 20 bytes = 40 hex chars
+// GenerateWalletAddress creates a new secp256k1 key pair and returns its address
+// and hex private key. The private key must be stored encrypted, never logged.
+func (bs *BlockchainService) GenerateWalletAddress() (address string, privateKeyHex string, err error) {
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate key: %v", err)
+	}
+
+	address = crypto.PubkeyToAddress(privateKey.PublicKey).Hex()
+	privateKeyHex = hex.EncodeToString(crypto.FromECDSA(privateKey))
+	return address, privateKeyHex, nil
 }
 
 // IsValidAddress checks if an address is valid
@@ -0,0 +1,143 @@
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// EIP-681 payment URIs for PaymentToken, e.g.
+//
+//	ethereum:0xToken@1337/transfer?address=0xRecipient&uint256=5000000000000000000
+//	ethereum:0xToken@1337/paymentTransfer?address=0xRecipient&uint256=5e18&string=Lunch
+//
+// A memo needs paymentTransfer(address,uint256,string), so the URI names that
+// function whenever a memo is given.
+
+const paymentURIScheme = "ethereum:"
+
+// PaymentURI is a token payment request encoded as an EIP-681 URI
+type PaymentURI struct {
+	ContractAddress string
+	ChainID         int64 // 0 when the URI doesn't name a chain
+	ToAddress       string
+	AmountWei       *big.Int // nil when the payer chooses the amount
+	Memo            string
+}
+
+// String builds the EIP-681 URI
+func (p *PaymentURI) String() string {
+	var b strings.Builder
+	b.WriteString(paymentURIScheme)
+	b.WriteString(common.HexToAddress(p.ContractAddress).Hex())
+	if p.ChainID > 0 {
+		b.WriteString("@" + strconv.FormatInt(p.ChainID, 10))
+	}
+
+	function := "transfer"
+	if p.Memo != "" {
+		function = "paymentTransfer"
+	}
+	b.WriteString("/" + function)
+
+	// Parameters in function argument order; url.Values would sort them
+	params := []string{"address=" + common.HexToAddress(p.ToAddress).Hex()}
+	if p.AmountWei != nil {
+		params = append(params, "uint256="+p.AmountWei.String())
+	}
+	if p.Memo != "" {
+		// %20 rather than +, which some wallets read literally
+		params = append(params, "string="+strings.ReplaceAll(url.QueryEscape(p.Memo), "+", "%20"))
+	}
+	b.WriteString("?" + strings.Join(params, "&"))
+	return b.String()
+}
+
+// ParsePaymentURI parses an EIP-681 transfer or paymentTransfer URI
+func ParsePaymentURI(raw string) (*PaymentURI, error) {
+	raw = strings.TrimSpace(raw)
+	if !strings.HasPrefix(strings.ToLower(raw), paymentURIScheme) {
+		return nil, errors.New("payment URI must start with ethereum:")
+	}
+	rest := strings.TrimPrefix(raw[len(paymentURIScheme):], "pay-")
+
+	rest, query, _ := strings.Cut(rest, "?")
+	target, function, hasFunction := strings.Cut(rest, "/")
+	if !hasFunction {
+		return nil, errors.New("payment URI is not a token transfer")
+	}
+
+	target, chain, hasChain := strings.Cut(target, "@")
+	if !common.IsHexAddress(target) {
+		return nil, errors.New("invalid token address in payment URI")
+	}
+	uri := &PaymentURI{ContractAddress: common.HexToAddress(target).Hex()}
+
+	if hasChain {
+		chainID, err := strconv.ParseInt(chain, 10, 64)
+		if err != nil || chainID <= 0 {
+			return nil, errors.New("invalid chain ID in payment URI")
+		}
+		uri.ChainID = chainID
+	}
+
+	if function != "transfer" && function != "paymentTransfer" {
+		return nil, fmt.Errorf("unsupported payment URI function %q", function)
+	}
+
+	params, err := url.ParseQuery(query)
+	if err != nil {
+		return nil, errors.New("invalid payment URI parameters")
+	}
+
+	to := params.Get("address")
+	if !common.IsHexAddress(to) {
+		return nil, errors.New("invalid recipient address in payment URI")
+	}
+	uri.ToAddress = common.HexToAddress(to).Hex()
+
+	if amount := params.Get("uint256"); amount != "" {
+		uri.AmountWei, err = parseURINumber(amount)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if function == "paymentTransfer" {
+		uri.Memo = params.Get("string")
+	}
+
+	return uri, nil
+}
+
+// parseURINumber parses an EIP-681 number: an integer, optionally in
+// scientific notation such as 2.5e18
+func parseURINumber(value string) (*big.Int, error) {
+	mantissa, exponent, hasExponent := strings.Cut(strings.ToLower(value), "e")
+
+	exp := 0
+	if hasExponent {
+		var err error
+		exp, err = strconv.Atoi(exponent)
+		if err != nil || exp < 0 || exp > 77 {
+			return nil, errors.New("invalid amount in payment URI")
+		}
+	}
+
+	whole, fraction, _ := strings.Cut(mantissa, ".")
+	fraction = strings.TrimRight(fraction, "0")
+	if len(fraction) > exp {
+		return nil, errors.New("amount in payment URI must be a whole number of wei")
+	}
+
+	digits := whole + fraction + strings.Repeat("0", exp-len(fraction))
+	amount, ok := new(big.Int).SetString(digits, 10)
+	if !ok || amount.Sign() < 0 || strings.HasPrefix(digits, "+") || strings.HasPrefix(digits, "-") {
+		return nil, errors.New("invalid amount in payment URI")
+	}
+	return amount, nil
+}
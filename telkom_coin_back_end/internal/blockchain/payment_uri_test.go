@@ -0,0 +1,101 @@
+package blockchain
+
+import (
+	"math/big"
+	"testing"
+)
+
+const (
+	testTokenAddress = "0x5FbDB2315678afecb367f032d93F642f64180aa3"
+	testRecipient    = "0x70997970C51812dc3A010C7d01b50e0d17dc79C8"
+)
+
+func TestParsePaymentURI(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    PaymentURI
+		wantErr bool
+	}{
+		{
+			name: "transfer",
+			raw:  "ethereum:" + testTokenAddress + "@1337/transfer?address=" + testRecipient + "&uint256=5000000000000000000",
+			want: PaymentURI{ContractAddress: testTokenAddress, ChainID: 1337, ToAddress: testRecipient, AmountWei: wei("5000000000000000000")},
+		},
+		{
+			name: "scientific notation",
+			raw:  "ethereum:" + testTokenAddress + "/transfer?address=" + testRecipient + "&uint256=2.5e18",
+			want: PaymentURI{ContractAddress: testTokenAddress, ToAddress: testRecipient, AmountWei: wei("2500000000000000000")},
+		},
+		{
+			name: "paymentTransfer with memo",
+			raw:  "ethereum:pay-" + testTokenAddress + "@1337/paymentTransfer?address=" + testRecipient + "&uint256=1e18&string=Lunch%20money",
+			want: PaymentURI{ContractAddress: testTokenAddress, ChainID: 1337, ToAddress: testRecipient, AmountWei: wei("1000000000000000000"), Memo: "Lunch money"},
+		},
+		{
+			name: "memo ignored on transfer",
+			raw:  "ethereum:" + testTokenAddress + "/transfer?address=" + testRecipient + "&string=Lunch",
+			want: PaymentURI{ContractAddress: testTokenAddress, ToAddress: testRecipient},
+		},
+		{
+			name: "lowercase addresses and uppercase scheme",
+			raw:  "ETHEREUM:0x5fbdb2315678afecb367f032d93f642f64180aa3/transfer?address=0x70997970c51812dc3a010c7d01b50e0d17dc79c8",
+			want: PaymentURI{ContractAddress: testTokenAddress, ToAddress: testRecipient},
+		},
+		{name: "other scheme", raw: "bitcoin:" + testTokenAddress + "/transfer?address=" + testRecipient, wantErr: true},
+		{name: "plain ether payment", raw: "ethereum:" + testRecipient + "?value=1e18", wantErr: true},
+		{name: "unsupported function", raw: "ethereum:" + testTokenAddress + "/approve?address=" + testRecipient, wantErr: true},
+		{name: "invalid token address", raw: "ethereum:0x1234/transfer?address=" + testRecipient, wantErr: true},
+		{name: "invalid chain ID", raw: "ethereum:" + testTokenAddress + "@main/transfer?address=" + testRecipient, wantErr: true},
+		{name: "zero chain ID", raw: "ethereum:" + testTokenAddress + "@0/transfer?address=" + testRecipient, wantErr: true},
+		{name: "missing recipient", raw: "ethereum:" + testTokenAddress + "/transfer?uint256=1", wantErr: true},
+		{name: "fraction of a wei", raw: "ethereum:" + testTokenAddress + "/transfer?address=" + testRecipient + "&uint256=1.5", wantErr: true},
+		{name: "negative amount", raw: "ethereum:" + testTokenAddress + "/transfer?address=" + testRecipient + "&uint256=-1", wantErr: true},
+		{name: "negative exponent", raw: "ethereum:" + testTokenAddress + "/transfer?address=" + testRecipient + "&uint256=1e-2", wantErr: true},
+		{name: "hex amount", raw: "ethereum:" + testTokenAddress + "/transfer?address=" + testRecipient + "&uint256=0x10", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePaymentURI(tt.raw)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParsePaymentURI(%q) = %+v, want error", tt.raw, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParsePaymentURI(%q) error = %v", tt.raw, err)
+			}
+			if got.ContractAddress != tt.want.ContractAddress || got.ChainID != tt.want.ChainID ||
+				got.ToAddress != tt.want.ToAddress || got.Memo != tt.want.Memo || !sameAmount(got.AmountWei, tt.want.AmountWei) {
+				t.Errorf("ParsePaymentURI(%q) = %+v, want %+v", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPaymentURIRoundTrip(t *testing.T) {
+	tests := []PaymentURI{
+		{ContractAddress: testTokenAddress, ChainID: 1337, ToAddress: testRecipient, AmountWei: wei("5000000000000000000")},
+		{ContractAddress: testTokenAddress, ToAddress: testRecipient},
+		{ContractAddress: testTokenAddress, ChainID: 1, ToAddress: testRecipient, AmountWei: wei("1"), Memo: "Invoice #12 & tip+tax"},
+	}
+	for _, uri := range tests {
+		raw := uri.String()
+		got, err := ParsePaymentURI(raw)
+		if err != nil {
+			t.Fatalf("ParsePaymentURI(%q) error = %v", raw, err)
+		}
+		if got.ContractAddress != uri.ContractAddress || got.ChainID != uri.ChainID ||
+			got.ToAddress != uri.ToAddress || got.Memo != uri.Memo || !sameAmount(got.AmountWei, uri.AmountWei) {
+			t.Errorf("round trip of %q = %+v, want %+v", raw, got, uri)
+		}
+	}
+}
+
+func sameAmount(a, b *big.Int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Cmp(b) == 0
+}
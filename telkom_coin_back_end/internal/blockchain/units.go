@@ -0,0 +1,67 @@
+package blockchain
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+)
+
+// TokenDecimals is the number of decimals of PaymentToken
+const TokenDecimals = 18
+
+var tokenUnit = new(big.Int).Exp(big.NewInt(10), big.NewInt(TokenDecimals), nil)
+
+// ParseTokenAmount converts a decimal token amount (e.g. "1.5") to wei. Both
+// parts may only hold digits, so signs and exponents are rejected.
+func ParseTokenAmount(amount string) (*big.Int, error) {
+	amount = strings.TrimSpace(amount)
+	whole, fraction, _ := strings.Cut(amount, ".")
+	if (whole == "" && fraction == "") || !isDigits(whole) || !isDigits(fraction) {
+		return nil, errors.New("invalid amount format")
+	}
+	if whole == "" {
+		whole = "0"
+	}
+	if len(fraction) > TokenDecimals {
+		return nil, errors.New("amount has more than 18 decimals")
+	}
+
+	wholeWei, ok := new(big.Int).SetString(whole, 10)
+	if !ok {
+		return nil, errors.New("invalid amount format")
+	}
+	wholeWei.Mul(wholeWei, tokenUnit)
+
+	if fraction == "" {
+		return wholeWei, nil
+	}
+
+	// Right-pad the fraction to 18 digits, "5" -> 500000000000000000
+	fractionWei, ok := new(big.Int).SetString(fraction+strings.Repeat("0", TokenDecimals-len(fraction)), 10)
+	if !ok {
+		return nil, errors.New("invalid amount format")
+	}
+	return wholeWei.Add(wholeWei, fractionWei), nil
+}
+
+// isDigits reports whether s holds only ASCII digits (true for "")
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// FormatTokenAmount converts wei to a decimal token amount without trailing zeros
+func FormatTokenAmount(amountWei *big.Int) string {
+	whole, fraction := new(big.Int).QuoRem(amountWei, tokenUnit, new(big.Int))
+	if fraction.Sign() == 0 {
+		return whole.String()
+	}
+
+	fractionStr := fraction.String()
+	fractionStr = strings.Repeat("0", TokenDecimals-len(fractionStr)) + fractionStr
+	return whole.String() + "." + strings.TrimRight(fractionStr, "0")
+}
@@ -0,0 +1,84 @@
+package blockchain
+
+import (
+	"math/big"
+	"testing"
+)
+
+func wei(s string) *big.Int {
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		panic("invalid wei amount " + s)
+	}
+	return n
+}
+
+func TestParseTokenAmount(t *testing.T) {
+	tests := []struct {
+		amount  string
+		want    string
+		wantErr bool
+	}{
+		{amount: "1", want: "1000000000000000000"},
+		{amount: "1.5", want: "1500000000000000000"},
+		{amount: "0.000000000000000001", want: "1"},
+		{amount: ".5", want: "500000000000000000"},
+		{amount: "1.", want: "1000000000000000000"},
+		{amount: " 2 ", want: "2000000000000000000"},
+		{amount: "0", want: "0"},
+		{amount: "007.10", want: "7100000000000000000"},
+		{amount: "123456789012345678901234567890", want: "123456789012345678901234567890000000000000000000"},
+		{amount: "", wantErr: true},
+		{amount: ".", wantErr: true},
+		{amount: "-1", wantErr: true},
+		{amount: "+1", wantErr: true},
+		{amount: "1.-5", wantErr: true},
+		{amount: "1.+5", wantErr: true},
+		{amount: "1e18", wantErr: true},
+		{amount: "1.5.5", wantErr: true},
+		{amount: "1,5", wantErr: true},
+		{amount: "0x10", wantErr: true},
+		{amount: "1 000", wantErr: true},
+		{amount: "0.0000000000000000001", wantErr: true}, // 19 decimals
+	}
+	for _, tt := range tests {
+		got, err := ParseTokenAmount(tt.amount)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseTokenAmount(%q) = %s, want error", tt.amount, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseTokenAmount(%q) error = %v", tt.amount, err)
+			continue
+		}
+		if got.Cmp(wei(tt.want)) != 0 {
+			t.Errorf("ParseTokenAmount(%q) = %s, want %s", tt.amount, got, tt.want)
+		}
+	}
+}
+
+func TestFormatTokenAmount(t *testing.T) {
+	tests := []struct {
+		wei  string
+		want string
+	}{
+		{"0", "0"},
+		{"1", "0.000000000000000001"},
+		{"1000000000000000000", "1"},
+		{"1500000000000000000", "1.5"},
+		{"7100000000000000000", "7.1"},
+		{"123456789012345678901234567890000000000000000000", "123456789012345678901234567890"},
+	}
+	for _, tt := range tests {
+		got := FormatTokenAmount(wei(tt.wei))
+		if got != tt.want {
+			t.Errorf("FormatTokenAmount(%s) = %q, want %q", tt.wei, got, tt.want)
+		}
+		back, err := ParseTokenAmount(got)
+		if err != nil || back.Cmp(wei(tt.wei)) != 0 {
+			t.Errorf("ParseTokenAmount(FormatTokenAmount(%s)) = %s, %v", tt.wei, back, err)
+		}
+	}
+}
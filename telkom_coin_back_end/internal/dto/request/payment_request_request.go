@@ -0,0 +1,14 @@
+package request
+
+// CreatePaymentRequestRequest asks another user for TLC
+type CreatePaymentRequestRequest struct {
+	Payer          string `json:"payer" binding:"required"` // Wallet address, @username, email or phone
+	Amount         string `json:"amount" binding:"required"`
+	Memo           string `json:"memo,omitempty" binding:"omitempty,max=100"`
+	ExpiresInHours int    `json:"expires_in_hours,omitempty" binding:"omitempty,min=1,max=720"` // Default 72
+}
+
+// PayPaymentRequestRequest pays an incoming payment request
+type PayPaymentRequestRequest struct {
+	Pin string `json:"pin" binding:"required" validate:"len=6,numeric"`
+}
@@ -0,0 +1,22 @@
+package request
+
+import "time"
+
+// CreateScheduledTransferRequest sets up a future-dated or recurring transfer
+type CreateScheduledTransferRequest struct {
+	ToAddress string     `json:"to_address" binding:"required"` // Wallet address, @username, email or phone
+	Amount    string     `json:"amount" binding:"required"`
+	Memo      string     `json:"memo,omitempty" binding:"omitempty,max=100"`
+	Frequency string     `json:"frequency" binding:"required,oneof=once daily weekly monthly"`
+	StartAt   *time.Time `json:"start_at,omitempty"` // First run, default now
+	EndAt     *time.Time `json:"end_at,omitempty"`   // No runs after this time
+	Pin       string     `json:"pin" binding:"required" validate:"len=6,numeric"`
+}
+
+// UpdateScheduledTransferRequest edits the amount, memo or end of a schedule
+type UpdateScheduledTransferRequest struct {
+	Amount string     `json:"amount,omitempty"`
+	Memo   *string    `json:"memo,omitempty" binding:"omitempty,max=100"`
+	EndAt  *time.Time `json:"end_at,omitempty"`
+	Pin    string     `json:"pin" binding:"required" validate:"len=6,numeric"`
+}
@@ -1,16 +1,25 @@
 package request
 
 // TLCTransferRequest represents a TLC transfer request
+// The recipient, amount and memo come from the quote returned by validate
 type TLCTransferRequest struct {
-	ToAddress string `json:"to_address" binding:"required" validate:"eth_address"`
-	Amount    string `json:"amount" binding:"required" validate:"numeric,gt=0"`
-	Memo      string `json:"memo,omitempty" validate:"max=100"`
-	Pin       string `json:"pin" binding:"required" validate:"len=6,numeric"`
+	QuoteToken string `json:"quote_token" binding:"required"`
+	Pin        string `json:"pin" binding:"required" validate:"len=6,numeric"`
+
+	// Optional webhook called when the transfer is confirmed or failed (host must be allowlisted)
+	CallbackURL string `json:"callback_url,omitempty" binding:"omitempty,url"`
 }
 
 type ValidateTransferRequest struct {
-	ToAddress string `json:"to_address" binding:"required"`
+	ToAddress string `json:"to_address" binding:"required"` // Wallet address, @username, email or phone
 	Amount    string `json:"amount" binding:"required"`
+	Memo      string `json:"memo,omitempty" binding:"omitempty,max=100"` // Stored on chain via paymentTransfer
+}
+
+// ValidatePaymentURIRequest validates a transfer from a scanned EIP-681 payment URI
+type ValidatePaymentURIRequest struct {
+	URI    string `json:"uri" binding:"required"`
+	Amount string `json:"amount,omitempty"` // Only used when the URI has no amount
 }
 
 // TLCTopupRequest represents a TLC topup request
@@ -2,6 +2,11 @@ package request
 
 type TopupRequest struct {
 	Amount        string `json:"amount" binding:"required"`
-	PaymentMethod string `json:"payment_method" binding:"required,oneof=bank_transfer va e-wallet"`
+	PaymentMethod string `json:"payment_method" binding:"required,oneof=bank_transfer va qris"`
 	Pin           string `json:"pin" binding:"required" validate:"len=6,numeric"` // Added PIN for verification
 }
+
+// QRISRequest carries a scanned QRIS string
+type QRISRequest struct {
+	QRString string `json:"qr_string" binding:"required,max=512"`
+}
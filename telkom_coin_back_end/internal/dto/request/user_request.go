@@ -4,4 +4,7 @@ type UpdateProfileRequest struct {
 	Username string `json:"username" binding:"omitempty,min=3,max=50"`
 	Email    string `json:"email" binding:"omitempty,email"`
 	Phone    string `json:"phone" binding:"omitempty,min=10,max=20"`
+
+	// Whether other users can find this account by phone number when transferring
+	PhoneDiscoverable *bool `json:"phone_discoverable"`
 }
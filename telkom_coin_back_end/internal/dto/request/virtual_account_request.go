@@ -0,0 +1,12 @@
+package request
+
+// AssignVACreditRequest credits a reviewed virtual account transfer to a user
+type AssignVACreditRequest struct {
+	UserID int64  `json:"user_id" binding:"required,min=1"`
+	Note   string `json:"note" binding:"max=500"`
+}
+
+// RejectVACreditRequest closes a reviewed virtual account transfer without minting
+type RejectVACreditRequest struct {
+	Note string `json:"note" binding:"required,max=500"`
+}
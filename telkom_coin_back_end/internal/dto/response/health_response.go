@@ -0,0 +1,15 @@
+package response
+
+import (
+	"time"
+
+	"telkom_coin_back_end/internal/web3"
+)
+
+// HealthResponse is returned by GET /health
+type HealthResponse struct {
+	Status    string           `json:"status"` // healthy, degraded or down
+	Database  string           `json:"database"`
+	Chain     web3.ChainStatus `json:"chain"`
+	CheckedAt time.Time        `json:"checked_at"`
+}
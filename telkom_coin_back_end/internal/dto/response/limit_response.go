@@ -0,0 +1,19 @@
+package response
+
+// OperationLimitResponse shows the limits and rolling usage of one operation in
+// TLC. Limit and remaining fields are empty when there is no limit.
+type OperationLimitResponse struct {
+	Operation        string `json:"operation"` // transfer, withdraw or topup
+	PerTransaction   string `json:"per_transaction,omitempty"`
+	DailyLimit       string `json:"daily_limit,omitempty"`
+	DailyUsed        string `json:"daily_used"`
+	DailyRemaining   string `json:"daily_remaining,omitempty"`
+	MonthlyLimit     string `json:"monthly_limit,omitempty"`
+	MonthlyUsed      string `json:"monthly_used"`
+	MonthlyRemaining string `json:"monthly_remaining,omitempty"`
+}
+
+type LimitsResponse struct {
+	Tier   string                   `json:"tier"`
+	Limits []OperationLimitResponse `json:"limits"`
+}
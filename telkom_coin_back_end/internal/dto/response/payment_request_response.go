@@ -0,0 +1,28 @@
+package response
+
+import "time"
+
+type PaymentRequestResponse struct {
+	ID                int64      `json:"id"`
+	Direction         string     `json:"direction"` // incoming (you pay) or outgoing (you get paid)
+	RequesterUsername string     `json:"requester_username"`
+	RequesterAddress  string     `json:"requester_address"`
+	PayerDisplayName  string     `json:"payer_display_name"` // Masked, the payer may have been found by email or phone
+	Amount            string     `json:"amount"`
+	Memo              string     `json:"memo,omitempty"`
+	Status            string     `json:"status"`
+	TxHash            string     `json:"tx_hash,omitempty"`
+	StatusURL         string     `json:"status_url,omitempty"`
+	ExpiresAt         time.Time  `json:"expires_at"`
+	PaidAt            *time.Time `json:"paid_at,omitempty"`
+	DeclinedAt        *time.Time `json:"declined_at,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+}
+
+type PaymentRequestListResponse struct {
+	Requests   []PaymentRequestResponse `json:"requests"`
+	TotalCount int                      `json:"total_count"`
+	Page       int                      `json:"page"`
+	Limit      int                      `json:"limit"`
+	TotalPages int                      `json:"total_pages"`
+}
@@ -0,0 +1,27 @@
+package response
+
+import "telkom_coin_back_end/internal/models"
+
+// BalanceReconciliationResponse is the latest on-chain vs database balance report
+type BalanceReconciliationResponse struct {
+	Report     *models.BalanceReconciliationReport `json:"report"`
+	Mismatches []models.BalanceMismatch            `json:"mismatches"`
+}
+
+// BankStatementReportResponse is an imported bank statement reconciled
+// against topup orders, split by outcome
+type BankStatementReportResponse struct {
+	Report           *models.BankStatementReport `json:"report"`
+	Matched          []models.BankStatementItem  `json:"matched"`
+	UnmatchedCredits []models.BankStatementItem  `json:"unmatched_credits"`
+	UnbackedMints    []models.BankStatementItem  `json:"unbacked_mints"`
+}
+
+// BankStatementReportListResponse lists imported statements, newest first
+type BankStatementReportListResponse struct {
+	Reports    []models.BankStatementReport `json:"reports"`
+	TotalCount int                          `json:"total_count"`
+	Page       int                          `json:"page"`
+	Limit      int                          `json:"limit"`
+	TotalPages int                          `json:"total_pages"`
+}
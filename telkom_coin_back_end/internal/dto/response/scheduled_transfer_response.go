@@ -0,0 +1,46 @@
+package response
+
+import "time"
+
+type ScheduledTransferResponse struct {
+	ID         int64      `json:"id"`
+	ToAddress  string     `json:"to_address"`
+	Amount     string     `json:"amount"`
+	Memo       string     `json:"memo,omitempty"`
+	Frequency  string     `json:"frequency"`
+	StartAt    time.Time  `json:"start_at"`
+	EndAt      *time.Time `json:"end_at,omitempty"`
+	Status     string     `json:"status"`
+	NextRunAt  *time.Time `json:"next_run_at,omitempty"` // Empty once cancelled or completed
+	RetryCount int        `json:"retry_count"`
+	LastRunAt  *time.Time `json:"last_run_at,omitempty"`
+	LastError  string     `json:"last_error,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type ScheduledTransferListResponse struct {
+	Schedules  []ScheduledTransferResponse `json:"schedules"`
+	TotalCount int                         `json:"total_count"`
+	Page       int                         `json:"page"`
+	Limit      int                         `json:"limit"`
+	TotalPages int                         `json:"total_pages"`
+}
+
+type ScheduledTransferExecutionResponse struct {
+	ID           int64     `json:"id"`
+	ScheduledFor time.Time `json:"scheduled_for"`
+	Attempt      int       `json:"attempt"`
+	Status       string    `json:"status"` // sent, retrying or failed
+	TxHash       string    `json:"tx_hash,omitempty"`
+	StatusURL    string    `json:"status_url,omitempty"`
+	Error        string    `json:"error,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type ScheduledTransferExecutionListResponse struct {
+	Executions []ScheduledTransferExecutionResponse `json:"executions"`
+	TotalCount int                                  `json:"total_count"`
+	Page       int                                  `json:"page"`
+	Limit      int                                  `json:"limit"`
+	TotalPages int                                  `json:"total_pages"`
+}
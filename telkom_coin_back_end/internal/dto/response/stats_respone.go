@@ -9,4 +9,5 @@ type BlockchainStatsResponse struct {
 	TotalTransactions int    `json:"total_transactions"`
 	TotalAddresses    int    `json:"total_addresses"`
 	LatestBlock       uint64 `json:"latest_block"`
+	LastIndexedBlock  uint64 `json:"last_indexed_block"`
 }
\ No newline at end of file
@@ -101,14 +101,37 @@ type ValidateTransferResponse struct {
 	FromAddress   string `json:"from_address"`
 	FromUsername  string `json:"from_username"`
 	ToAddress     string `json:"to_address"`
-	ToUsername    string `json:"to_username"` // 👈 Nama penerima untuk popup
+	ToUsername    string `json:"to_username"`     // 👈 Nama penerima untuk popup (kosong kalau dicari lewat email/HP)
+	ToDisplayName string `json:"to_display_name"` // Username yang disamarkan, mis. "b**i"
+	RecipientType string `json:"recipient_type"`  // address, username, email atau phone
 	Amount        string `json:"amount"`
 	AmountWei     string `json:"amount_wei"`
 	SenderBalance string `json:"sender_balance"`
+	Memo          string `json:"memo,omitempty"`
 	IsValid       bool   `json:"is_valid"`
+
+	// Biaya jaringan (ETH, dalam wei), kosong kalau estimasi gagal
+	GasLimit        uint64 `json:"gas_limit,omitempty"`
+	EstimatedFeeWei string `json:"estimated_fee_wei,omitempty"`
+	MaxFeeWei       string `json:"max_fee_wei,omitempty"`
+
+	// Kirim quote_token + PIN ke /api/transfer/execute sebelum quote_expires_at
+	QuoteToken     string    `json:"quote_token"`
+	QuoteExpiresAt time.Time `json:"quote_expires_at"`
 }
 
-// TLCTransferResponse - Response setelah transfer sukses
+// PaymentURIResponse - EIP-681 URI untuk menerima TLC (scan-to-pay)
+type PaymentURIResponse struct {
+	URI             string `json:"uri"`
+	ContractAddress string `json:"contract_address"`
+	ChainID         int64  `json:"chain_id"`
+	ToAddress       string `json:"to_address"`
+	Amount          string `json:"amount,omitempty"`
+	AmountWei       string `json:"amount_wei,omitempty"`
+	Memo            string `json:"memo,omitempty"`
+}
+
+// TLCTransferResponse - Response setelah transfer dikirim ke jaringan (status pending)
 type TLCTransferResponse struct {
 	TxHash      string    `json:"tx_hash"`
 	FromAddress string    `json:"from_address"`
@@ -117,5 +140,22 @@ type TLCTransferResponse struct {
 	AmountWei   string    `json:"amount_wei"`
 	Memo        string    `json:"memo"`
 	Status      string    `json:"status"`
+	StatusURL   string    `json:"status_url"` // Poll this until status is confirmed or failed
 	Timestamp   time.Time `json:"timestamp"`
 }
+
+// TransactionStatusResponse - Status transaksi dari tabel transactions, diisi confirmation tracker
+type TransactionStatusResponse struct {
+	TxHash      string     `json:"tx_hash"`
+	TxType      string     `json:"tx_type"`
+	Status      string     `json:"status"` // pending, confirmed, failed
+	FromAddress string     `json:"from_address"`
+	ToAddress   string     `json:"to_address"`
+	Amount      string     `json:"amount"`
+	Memo        string     `json:"memo,omitempty"`
+	BlockNumber *int64     `json:"block_number,omitempty"`
+	GasUsed     *int64     `json:"gas_used,omitempty"`
+	GasPrice    *int64     `json:"gas_price,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	ConfirmedAt *time.Time `json:"confirmed_at,omitempty"`
+}
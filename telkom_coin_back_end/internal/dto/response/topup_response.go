@@ -4,6 +4,7 @@ import "time"
 
 type TopupResponse struct {
 	ID             int64                  `json:"id"`
+	Reference      string                 `json:"reference,omitempty"` // Topup order reference, quote it when paying
 	Amount         string                 `json:"amount"`
 	Currency       string                 `json:"currency"`
 	PaymentMethod  string                 `json:"payment_method"`
@@ -11,10 +12,13 @@ type TopupResponse struct {
 	Status         string                 `json:"status"`
 	TxHash         string                 `json:"tx_hash,omitempty"` // Added for transaction tracking
 	ExpiredAt      *time.Time             `json:"expired_at,omitempty"`
+	PaidAt         *time.Time             `json:"paid_at,omitempty"`
+	ETA            *time.Time             `json:"eta,omitempty"` // When a delayed topup is expected to be minted
 	CreatedAt      time.Time              `json:"created_at"`
 }
 
 type TopupHistoryResponse struct {
+	Pending    []TopupResponse `json:"pending,omitempty"` // Delayed topups not minted yet
 	Topups     []TopupResponse `json:"topups"`
 	TotalCount int             `json:"total_count"`
 	Page       int             `json:"page"`
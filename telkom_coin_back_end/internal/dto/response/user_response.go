@@ -11,16 +11,20 @@ type UserResponse struct {
 	KYCStatus     string    `json:"kyc_status"`
 	Status        string    `json:"status"`
 	CreatedAt     time.Time `json:"created_at"`
+
+	// Only set on the user's own profile
+	PhoneDiscoverable *bool `json:"phone_discoverable,omitempty"`
 }
 
 type UserDetailResponse struct {
-	ID            int64           `json:"id"`
-	Username      string          `json:"username"`
-	Email         string          `json:"email"`
-	Phone         string          `json:"phone,omitempty"`
-	WalletAddress string          `json:"wallet_address"`
-	KYCStatus     string          `json:"kyc_status"`
-	Status        string          `json:"status"`
-	Balance       BalanceResponse `json:"balance"`
-	CreatedAt     time.Time       `json:"created_at"`
+	ID                int64           `json:"id"`
+	Username          string          `json:"username"`
+	Email             string          `json:"email"`
+	Phone             string          `json:"phone,omitempty"`
+	PhoneDiscoverable bool            `json:"phone_discoverable"`
+	WalletAddress     string          `json:"wallet_address"`
+	KYCStatus         string          `json:"kyc_status"`
+	Status            string          `json:"status"`
+	Balance           BalanceResponse `json:"balance"`
+	CreatedAt         time.Time       `json:"created_at"`
 }
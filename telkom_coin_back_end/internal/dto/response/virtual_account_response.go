@@ -0,0 +1,20 @@
+package response
+
+import "telkom_coin_back_end/internal/models"
+
+// VirtualAccountResponse is one of the user's own virtual account numbers
+type VirtualAccountResponse struct {
+	Bank        string `json:"bank"`
+	Number      string `json:"number"`
+	AccountName string `json:"account_name"`
+	MinAmount   string `json:"min_amount"`
+}
+
+// VirtualAccountCreditListResponse is a page of incoming virtual account credits
+type VirtualAccountCreditListResponse struct {
+	Credits    []models.VirtualAccountCredit `json:"credits"`
+	TotalCount int                           `json:"total_count"`
+	Page       int                           `json:"page"`
+	Limit      int                           `json:"limit"`
+	TotalPages int                           `json:"total_pages"`
+}
@@ -12,3 +12,10 @@ type WithdrawResponse struct {
 	CreatedAt          time.Time  `json:"created_at"`
 	ProcessedAt        *time.Time `json:"processed_at,omitempty"`
 }
+
+// WithdrawLimitsResponse is the withdraw limit of the user's tier and its usage
+type WithdrawLimitsResponse struct {
+	OperationLimitResponse
+	KYCStatus      string `json:"kyc_status"`
+	FiatWithdrawal bool   `json:"fiat_withdrawal"`
+}
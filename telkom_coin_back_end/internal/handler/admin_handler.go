@@ -0,0 +1,235 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"telkom_coin_back_end/internal/dto/request"
+	"telkom_coin_back_end/internal/models"
+	service "telkom_coin_back_end/internal/services"
+	"telkom_coin_back_end/pkg/helpers"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Largest bank statement file accepted for import
+const maxStatementFileSize = 10 << 20
+
+type AdminHandler struct {
+	BalanceReconciler     *service.BalanceReconciler
+	VirtualAccountService *service.VirtualAccountService
+	BankStatementService  *service.BankStatementService
+}
+
+func NewAdminHandler(
+	balanceReconciler *service.BalanceReconciler,
+	virtualAccountService *service.VirtualAccountService,
+	bankStatementService *service.BankStatementService,
+) *AdminHandler {
+	return &AdminHandler{
+		BalanceReconciler:     balanceReconciler,
+		VirtualAccountService: virtualAccountService,
+		BankStatementService:  bankStatementService,
+	}
+}
+
+// GetBalanceReconciliation returns the latest on-chain vs database balance report
+func (h *AdminHandler) GetBalanceReconciliation(c *gin.Context) {
+	report, err := h.BalanceReconciler.LatestReport()
+	if err != nil {
+		helpers.NotFoundResponse(c, err.Error())
+		return
+	}
+
+	helpers.SuccessResponse(c, "Balance reconciliation report retrieved successfully", report)
+}
+
+// RunBalanceReconciliation runs a reconciliation now and returns its report
+func (h *AdminHandler) RunBalanceReconciliation(c *gin.Context) {
+	if _, err := h.BalanceReconciler.RunOnce(); err != nil {
+		helpers.BadRequestResponse(c, "Balance reconciliation failed", err)
+		return
+	}
+
+	report, err := h.BalanceReconciler.LatestReport()
+	if err != nil {
+		helpers.InternalServerErrorResponse(c, "Failed to load reconciliation report", err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Balance reconciliation completed", report)
+}
+
+// ImportBankStatement reconciles an uploaded bank statement (multipart "file",
+// optional "format" csv or mt940) with topup orders and returns the report
+func (h *AdminHandler) ImportBankStatement(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxStatementFileSize)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		helpers.BadRequestResponse(c, "Statement file is required (max 10 MB)", err)
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		helpers.BadRequestResponse(c, "Failed to read statement file", err)
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		helpers.BadRequestResponse(c, "Failed to read statement file", err)
+		return
+	}
+
+	report, err := h.BankStatementService.Import(fileHeader.Filename, c.PostForm("format"), data)
+	if err != nil {
+		if errors.Is(err, service.ErrStatementAlreadyImported) {
+			helpers.ConflictResponse(c, err.Error(), err)
+			return
+		}
+		helpers.BadRequestResponse(c, err.Error(), err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Bank statement reconciled", report)
+}
+
+// GetBankStatementReports lists imported bank statements, newest first
+func (h *AdminHandler) GetBankStatementReports(c *gin.Context) {
+	page, limit, err := helpers.ValidatePagination(c.Query("page"), c.Query("limit"))
+	if err != nil {
+		helpers.BadRequestResponse(c, "Invalid pagination parameters", err)
+		return
+	}
+
+	reports, err := h.BankStatementService.ListReports(page, limit)
+	if err != nil {
+		helpers.InternalServerErrorResponse(c, err.Error(), err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Bank statement reports retrieved", reports)
+}
+
+// GetBankStatementReport returns a statement report with matched items,
+// unmatched credits and unbacked mints; ?format=csv downloads the items
+func (h *AdminHandler) GetBankStatementReport(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		helpers.BadRequestResponse(c, "Invalid report ID", err)
+		return
+	}
+
+	if c.Query("format") == "csv" {
+		data, err := h.BankStatementService.ExportReportCSV(id)
+		if err != nil {
+			h.respondStatementError(c, err)
+			return
+		}
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=bank-statement-report-%d.csv", id))
+		c.Data(http.StatusOK, "text/csv", data)
+		return
+	}
+
+	report, err := h.BankStatementService.GetReport(id)
+	if err != nil {
+		h.respondStatementError(c, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Bank statement report retrieved", report)
+}
+
+func (h *AdminHandler) respondStatementError(c *gin.Context, err error) {
+	if errors.Is(err, service.ErrStatementReportNotFound) {
+		helpers.NotFoundResponse(c, err.Error())
+		return
+	}
+	helpers.InternalServerErrorResponse(c, "Failed to load bank statement report", err)
+}
+
+// GetVACredits lists incoming virtual account transfers, the review queue
+// (?status=needs_review) by default
+func (h *AdminHandler) GetVACredits(c *gin.Context) {
+	page, limit, err := helpers.ValidatePagination(c.Query("page"), c.Query("limit"))
+	if err != nil {
+		helpers.BadRequestResponse(c, "Invalid pagination parameters", err)
+		return
+	}
+
+	credits, err := h.VirtualAccountService.ListCredits(c.DefaultQuery("status", models.VACreditStatusNeedsReview), page, limit)
+	if err != nil {
+		helpers.BadRequestResponse(c, err.Error(), err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Virtual account credits retrieved", credits)
+}
+
+// AssignVACredit credits a reviewed transfer to a user and mints it
+func (h *AdminHandler) AssignVACredit(c *gin.Context) {
+	id, ok := h.parseCreditID(c)
+	if !ok {
+		return
+	}
+
+	var req request.AssignVACreditRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helpers.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	credit, err := h.VirtualAccountService.AssignCredit(id, req.UserID, req.Note)
+	if err != nil {
+		h.respondCreditError(c, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Virtual account credit assigned", credit)
+}
+
+// RejectVACredit closes a reviewed transfer without minting
+func (h *AdminHandler) RejectVACredit(c *gin.Context) {
+	id, ok := h.parseCreditID(c)
+	if !ok {
+		return
+	}
+
+	var req request.RejectVACreditRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helpers.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	credit, err := h.VirtualAccountService.RejectCredit(id, req.Note)
+	if err != nil {
+		h.respondCreditError(c, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Virtual account credit rejected", credit)
+}
+
+func (h *AdminHandler) parseCreditID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		helpers.BadRequestResponse(c, "Invalid credit ID", err)
+		return 0, false
+	}
+	return id, true
+}
+
+func (h *AdminHandler) respondCreditError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrVACreditNotFound):
+		helpers.NotFoundResponse(c, err.Error())
+	case errors.Is(err, service.ErrVACreditReviewed):
+		helpers.ConflictResponse(c, err.Error(), err)
+	default:
+		helpers.BadRequestResponse(c, err.Error(), err)
+	}
+}
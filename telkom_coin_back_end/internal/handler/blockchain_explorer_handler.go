@@ -131,69 +131,50 @@ func (h *BlockchainExplorerHandler) GetTransactionByHash(c *gin.Context) {
 
 // GetBlockchainStats gets overall blockchain statistics
 func (h *BlockchainExplorerHandler) GetBlockchainStats(c *gin.Context) {
-	// Get ALL transactions to calculate stats (without pagination)
-	allTx, err := h.ExplorerService.GetAllTransactions(nil, nil, 1, 999999)
+	// Stats dihitung dari tabel hasil indexer, bukan scan seluruh chain
+	chainStats, err := h.ExplorerService.GetBlockchainStats()
 	if err != nil {
 		helpers.InternalServerErrorResponse(c, "Failed to get blockchain stats", err)
 		return
 	}
 
-	// Calculate statistics
-	totalTransactions := allTx.TotalCount
-	transferCount := 0
-	topupCount := 0
-	withdrawCount := 0
-	mintCount := 0
-	burnCount := 0
-	
-	// Variables untuk total minted & burned (gunakan big.Int untuk akurasi)
-	totalMinted := big.NewInt(0)
-	totalBurned := big.NewInt(0)
-
-		for _, tx := range allTx.Transactions {
-		// Count by type
-		switch tx.Type {
-		case "transfer":
-			transferCount++
-		case "topup": // 👈 UBAH DARI "mint" JADI "topup"
-			topupCount++
-			// Tambahkan amount ke total minted
-			amount := new(big.Int)
-			amount.SetString(tx.Amount, 10)
-			totalMinted.Add(totalMinted, amount)
-		case "withdraw": // 👈 UBAH DARI "burn" JADI "withdraw"
-			withdrawCount++
-			// Tambahkan amount ke total burned
-			amount := new(big.Int)
-			amount.SetString(tx.Amount, 10)
-			totalBurned.Add(totalBurned, amount)
-		}
+	typeCounts, err := h.ExplorerService.GetTransactionTypeCounts()
+	if err != nil {
+		helpers.InternalServerErrorResponse(c, "Failed to get blockchain stats", err)
+		return
 	}
 
-	// Calculate circulating supply (minted - burned)
-	circulatingSupply := new(big.Int).Sub(totalMinted, totalBurned)
-
+	network := h.ExplorerService.GetNetwork()
 	stats := map[string]interface{}{
-		"total_transactions": totalTransactions,
-		"transaction_types": map[string]int{
-			"transfers":   transferCount,
-			"topups":      topupCount,
-			"withdrawals": withdrawCount,
-			"mints":       mintCount,
-			"burns":       burnCount,
+		"total_transactions": chainStats.TotalTransactions,
+		"total_addresses":    chainStats.TotalAddresses,
+		"transaction_types": map[string]int64{
+			"transfers":   typeCounts["transfer"],
+			"topups":      typeCounts["topup"],
+			"withdrawals": typeCounts["withdraw"],
+			"mints":       typeCounts["mint"],
+			"burns":       typeCounts["burn"],
 		},
 		"coin_statistics": map[string]string{
-			"total_minted":       totalMinted.String(),
-			"total_burned":       totalBurned.String(),
-			"circulating_supply": circulatingSupply.String(),
-			"max_supply":         "Unlimited",
+			"total_minted":       chainStats.TotalMinted,
+			"total_burned":       chainStats.TotalBurned,
+			"circulating_supply": chainStats.CirculatingSupply,
+			"max_supply":         chainStats.MaxSupply,
 		},
+		"indexer": map[string]interface{}{
+			"latest_block":       chainStats.LatestBlock,
+			"last_indexed_block": chainStats.LastIndexedBlock,
+		},
+		"connectivity": h.ExplorerService.GetChainStatus(),
 		"network": map[string]interface{}{
-			"name":         "Ganache Local",
-			"chain_id":     "1337",
-			"token_name":   "Telkom Token",
-			"token_symbol": "TELKOM",
-			"decimals":     18,
+			"key":              network.Key,
+			"name":             network.Name,
+			"chain_id":         strconv.FormatInt(network.ChainID, 10),
+			"contract_address": h.ExplorerService.GetContractAddress(),
+			"confirmations":    network.Confirmations,
+			"token_name":       "Telkom Token",
+			"token_symbol":     "TELKOM",
+			"decimals":         18,
 		},
 		"features": map[string]bool{
 			"real_time_tracking": true,
@@ -205,6 +186,7 @@ func (h *BlockchainExplorerHandler) GetBlockchainStats(c *gin.Context) {
 
 	helpers.SuccessResponse(c, "Blockchain statistics retrieved", stats)
 }
+
 // SearchTransactions searches transactions by various criteria with pagination
 func (h *BlockchainExplorerHandler) SearchTransactions(c *gin.Context) {
 	query := c.Query("q")
@@ -366,39 +348,18 @@ func (h *BlockchainExplorerHandler) GetTransactionsByType(c *gin.Context) {
 		limit = num
 	}
 
-	// Get all transactions first
-	allTx, err := h.ExplorerService.GetAllTransactions(nil, nil, 1, 999999)
+	// Get transactions of this type
+	transactions, err := h.ExplorerService.GetTransactionsByType(txType, page, limit)
 	if err != nil {
 		helpers.InternalServerErrorResponse(c, "Failed to get transactions", err)
 		return
 	}
 
-	// Filter by type
-	var filtered []interface{}
-	for _, tx := range allTx.Transactions {
-		if tx.Type == txType {
-			filtered = append(filtered, tx)
-		}
-	}
-
-	// Manual pagination for filtered results
-	totalCount := len(filtered)
-	totalPages := (totalCount + limit - 1) / limit
-	startIdx := (page - 1) * limit
-	endIdx := startIdx + limit
-
-	if startIdx >= totalCount {
-		filtered = []interface{}{}
-	} else {
-		if endIdx > totalCount {
-			endIdx = totalCount
-		}
-		filtered = filtered[startIdx:endIdx]
-	}
+	totalPages := (transactions.TotalCount + limit - 1) / limit
 
 	helpers.SuccessResponse(c, "Transactions by type retrieved", map[string]interface{}{
-		"transactions": filtered,
-		"total_count":  totalCount,
+		"transactions": transactions.Transactions,
+		"total_count":  transactions.TotalCount,
 		"total_pages":  totalPages,
 		"page":         page,
 		"limit":        limit,
@@ -416,33 +377,19 @@ func (h *BlockchainExplorerHandler) GetAddressBalance(c *gin.Context) {
 		return
 	}
 
-	// Get all transactions for this address
-	transactions, err := h.ExplorerService.GetTransactionsByAddress(address, 1, 999999)
+	// Sum indexed Transfer events instead of loading every transaction
+	received, sent, err := h.ExplorerService.GetAddressFlow(address)
 	if err != nil {
 		helpers.InternalServerErrorResponse(c, "Failed to calculate balance", err)
 		return
 	}
+	balance := new(big.Int).Sub(received, sent)
 
-	// Calculate balance from transactions
-	balance := big.NewInt(0)
-	received := big.NewInt(0)
-	sent := big.NewInt(0)
-
-	for _, tx := range transactions.Transactions {
-		amount := new(big.Int)
-		amount.SetString(tx.AmountWei, 10)
-
-		// Received
-		if tx.ToAddress == address {
-			balance.Add(balance, amount)
-			received.Add(received, amount)
-		}
-		
-		// Sent
-		if tx.FromAddress == address {
-			balance.Sub(balance, amount)
-			sent.Add(sent, amount)
-		}
+	// Only the count is needed here
+	transactions, err := h.ExplorerService.GetTransactionsByAddress(address, 1, 1)
+	if err != nil {
+		helpers.InternalServerErrorResponse(c, "Failed to calculate balance", err)
+		return
 	}
 
 	// Convert to TLC (divide by 10^18)
@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"net/http"
+	service "telkom_coin_back_end/internal/services"
+	"telkom_coin_back_end/internal/web3"
+	"telkom_coin_back_end/pkg/helpers"
+
+	"github.com/gin-gonic/gin"
+)
+
+type HealthHandler struct {
+	HealthService *service.HealthService
+}
+
+func NewHealthHandler(healthService *service.HealthService) *HealthHandler {
+	return &HealthHandler{
+		HealthService: healthService,
+	}
+}
+
+// GetHealth reports database and chain connectivity; 503 when the app is down
+func (h *HealthHandler) GetHealth(c *gin.Context) {
+	health := h.HealthService.Check()
+
+	if health.Status == web3.ChainStatusDown {
+		c.JSON(http.StatusServiceUnavailable, helpers.APIResponse{
+			Success: false,
+			Message: "Service unavailable",
+			Data:    health,
+		})
+		return
+	}
+
+	helpers.SuccessResponse(c, "Service is "+health.Status, health)
+}
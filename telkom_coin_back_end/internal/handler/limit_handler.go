@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	service "telkom_coin_back_end/internal/services"
+	"telkom_coin_back_end/pkg/helpers"
+
+	"github.com/gin-gonic/gin"
+)
+
+type LimitHandler struct {
+	LimitService *service.LimitService
+}
+
+func NewLimitHandler(limitService *service.LimitService) *LimitHandler {
+	return &LimitHandler{
+		LimitService: limitService,
+	}
+}
+
+// GetLimits returns the transfer, withdraw and topup limits of the user's tier
+// with the rolling daily and monthly usage
+func (h *LimitHandler) GetLimits(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+
+	limits, err := h.LimitService.GetLimits(userID)
+	if err != nil {
+		helpers.InternalServerErrorResponse(c, "Failed to get limits", err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Limits retrieved", limits)
+}
+
+// limitExceeded answers 422 with the exceeded limit and the remaining allowance
+// when err is a limit error
+func limitExceeded(c *gin.Context, err error) bool {
+	var limitErr *service.LimitExceededError
+	if !errors.As(err, &limitErr) {
+		return false
+	}
+
+	c.JSON(http.StatusUnprocessableEntity, helpers.APIResponse{
+		Success: false,
+		Message: err.Error(),
+		Data:    limitErr,
+		Error:   err.Error(),
+	})
+	return true
+}
@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"telkom_coin_back_end/internal/dto/request"
+	service "telkom_coin_back_end/internal/services"
+	"telkom_coin_back_end/pkg/helpers"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PaymentRequestHandler struct {
+	PaymentRequestService *service.PaymentRequestService
+}
+
+func NewPaymentRequestHandler(paymentRequestService *service.PaymentRequestService) *PaymentRequestHandler {
+	return &PaymentRequestHandler{
+		PaymentRequestService: paymentRequestService,
+	}
+}
+
+// CreatePaymentRequest asks another user for TLC
+func (h *PaymentRequestHandler) CreatePaymentRequest(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+
+	var req request.CreatePaymentRequestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helpers.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	paymentRequest, err := h.PaymentRequestService.Create(userID, &req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Payment request created successfully", paymentRequest)
+}
+
+// GetPaymentRequests lists incoming (?direction=incoming, default) or outgoing requests
+func (h *PaymentRequestHandler) GetPaymentRequests(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+
+	page, limit, err := helpers.ValidatePagination(c.Query("page"), c.Query("limit"))
+	if err != nil {
+		helpers.BadRequestResponse(c, "Invalid pagination parameters", err)
+		return
+	}
+
+	direction := c.DefaultQuery("direction", service.PaymentRequestIncoming)
+	requests, err := h.PaymentRequestService.List(userID, direction, c.Query("status"), page, limit)
+	if err != nil {
+		helpers.BadRequestResponse(c, err.Error(), err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Payment requests retrieved", requests)
+}
+
+// GetPaymentRequest gets a request the user made or received
+func (h *PaymentRequestHandler) GetPaymentRequest(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	paymentRequest, err := h.PaymentRequestService.Get(userID, id)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Payment request retrieved", paymentRequest)
+}
+
+// PayPaymentRequest pays an incoming request with the user's PIN
+func (h *PaymentRequestHandler) PayPaymentRequest(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	var req request.PayPaymentRequestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helpers.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	paymentRequest, err := h.PaymentRequestService.Pay(userID, id, req.Pin)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Payment request paid, waiting for confirmation", paymentRequest)
+}
+
+// DeclinePaymentRequest declines an incoming request
+func (h *PaymentRequestHandler) DeclinePaymentRequest(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	paymentRequest, err := h.PaymentRequestService.Decline(userID, id)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Payment request declined", paymentRequest)
+}
+
+func (h *PaymentRequestHandler) parseID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		helpers.BadRequestResponse(c, "Invalid payment request ID", err)
+		return 0, false
+	}
+	return id, true
+}
+
+func (h *PaymentRequestHandler) respondError(c *gin.Context, err error) {
+	if limitExceeded(c, err) {
+		return
+	}
+
+	switch {
+	case errors.Is(err, service.ErrPaymentRequestNotFound):
+		helpers.NotFoundResponse(c, err.Error())
+	case errors.Is(err, service.ErrRecipientLookupLimit):
+		helpers.ErrorResponse(c, http.StatusTooManyRequests, err.Error(), err)
+	default:
+		helpers.BadRequestResponse(c, err.Error(), err)
+	}
+}
@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"errors"
+	"telkom_coin_back_end/internal/dto/request"
+	"telkom_coin_back_end/internal/payment"
+	"telkom_coin_back_end/pkg/helpers"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PaymentSimulatorHandler pays mock provider charges during development.
+// Only registered behind the admin key, with PAYMENT_PROVIDER=mock,
+// BLOCKCHAIN_NETWORK=simulated and PAYMENT_SIMULATOR_WEBHOOK_URL set.
+type PaymentSimulatorHandler struct {
+	Simulator *payment.Simulator
+}
+
+func NewPaymentSimulatorHandler(simulator *payment.Simulator) *PaymentSimulatorHandler {
+	return &PaymentSimulatorHandler{Simulator: simulator}
+}
+
+// PayQRIS pays a dynamic QRIS and fires the topup webhook
+func (h *PaymentSimulatorHandler) PayQRIS(c *gin.Context) {
+	var req request.QRISRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helpers.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	result, err := h.Simulator.PayQRIS(req.QRString)
+	if err != nil {
+		helpers.BadRequestResponse(c, err.Error(), err)
+		return
+	}
+	if result.WebhookStatus >= 300 {
+		helpers.ErrorResponse(c, result.WebhookStatus, "Payment webhook was rejected", errors.New(string(result.WebhookResponse)))
+		return
+	}
+
+	helpers.SuccessResponse(c, "QRIS paid", result)
+}
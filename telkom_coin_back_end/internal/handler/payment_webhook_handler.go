@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"telkom_coin_back_end/internal/payment"
+	service "telkom_coin_back_end/internal/services"
+	"telkom_coin_back_end/pkg/helpers"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Webhook bodies are small JSON documents
+const maxWebhookBodySize = 64 << 10
+
+type PaymentWebhookHandler struct {
+	TopupService          *service.TopupService
+	VirtualAccountService *service.VirtualAccountService
+}
+
+func NewPaymentWebhookHandler(topupService *service.TopupService, virtualAccountService *service.VirtualAccountService) *PaymentWebhookHandler {
+	return &PaymentWebhookHandler{
+		TopupService:          topupService,
+		VirtualAccountService: virtualAccountService,
+	}
+}
+
+// HandlePaymentWebhook receives payment notifications from the payment provider.
+// The signature is checked over the raw body, so it is read before any binding.
+func (h *PaymentWebhookHandler) HandlePaymentWebhook(c *gin.Context) {
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxWebhookBodySize))
+	if err != nil {
+		helpers.BadRequestResponse(c, "Invalid webhook body", err)
+		return
+	}
+
+	topup, err := h.TopupService.HandlePaymentWebhook(c.Request.Header, body)
+	switch {
+	case errors.Is(err, payment.ErrInvalidSignature):
+		helpers.UnauthorizedResponse(c, err.Error())
+	case errors.Is(err, service.ErrTopupOrderNotFound):
+		helpers.NotFoundResponse(c, err.Error())
+	case err != nil:
+		helpers.BadRequestResponse(c, err.Error(), err)
+	default:
+		helpers.SuccessResponse(c, "Payment webhook processed", topup)
+	}
+}
+
+// HandleVACreditNotification receives incoming transfers to users' virtual
+// accounts. Unmatched transfers are accepted too; they go to the review queue.
+func (h *PaymentWebhookHandler) HandleVACreditNotification(c *gin.Context) {
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxWebhookBodySize))
+	if err != nil {
+		helpers.BadRequestResponse(c, "Invalid webhook body", err)
+		return
+	}
+
+	credit, err := h.VirtualAccountService.HandleCreditNotification(c.Request.Header, body)
+	switch {
+	case errors.Is(err, payment.ErrInvalidSignature):
+		helpers.UnauthorizedResponse(c, err.Error())
+	case err != nil:
+		helpers.BadRequestResponse(c, err.Error(), err)
+	default:
+		helpers.SuccessResponse(c, "Credit notification processed", credit)
+	}
+}
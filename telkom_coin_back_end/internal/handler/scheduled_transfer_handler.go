@@ -0,0 +1,200 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"telkom_coin_back_end/internal/dto/request"
+	service "telkom_coin_back_end/internal/services"
+	"telkom_coin_back_end/pkg/helpers"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ScheduledTransferHandler struct {
+	ScheduledTransferService *service.ScheduledTransferService
+}
+
+func NewScheduledTransferHandler(scheduledTransferService *service.ScheduledTransferService) *ScheduledTransferHandler {
+	return &ScheduledTransferHandler{
+		ScheduledTransferService: scheduledTransferService,
+	}
+}
+
+// CreateScheduledTransfer sets up a future-dated or recurring transfer
+func (h *ScheduledTransferHandler) CreateScheduledTransfer(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+
+	var req request.CreateScheduledTransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helpers.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	schedule, err := h.ScheduledTransferService.Create(userID, &req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Scheduled transfer created successfully", schedule)
+}
+
+// GetScheduledTransfers lists the user's scheduled transfers
+func (h *ScheduledTransferHandler) GetScheduledTransfers(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+
+	page, limit, err := helpers.ValidatePagination(c.Query("page"), c.Query("limit"))
+	if err != nil {
+		helpers.BadRequestResponse(c, "Invalid pagination parameters", err)
+		return
+	}
+
+	schedules, err := h.ScheduledTransferService.List(userID, page, limit)
+	if err != nil {
+		helpers.InternalServerErrorResponse(c, err.Error(), err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Scheduled transfers retrieved", schedules)
+}
+
+// GetScheduledTransfer gets one scheduled transfer
+func (h *ScheduledTransferHandler) GetScheduledTransfer(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	schedule, err := h.ScheduledTransferService.Get(userID, id)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Scheduled transfer retrieved", schedule)
+}
+
+// UpdateScheduledTransfer edits the amount, memo or end of a scheduled transfer
+func (h *ScheduledTransferHandler) UpdateScheduledTransfer(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	var req request.UpdateScheduledTransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helpers.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	schedule, err := h.ScheduledTransferService.Update(userID, id, &req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Scheduled transfer updated successfully", schedule)
+}
+
+// PauseScheduledTransfer pauses a scheduled transfer
+func (h *ScheduledTransferHandler) PauseScheduledTransfer(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	schedule, err := h.ScheduledTransferService.Pause(userID, id)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Scheduled transfer paused", schedule)
+}
+
+// ResumeScheduledTransfer resumes a paused scheduled transfer
+func (h *ScheduledTransferHandler) ResumeScheduledTransfer(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	schedule, err := h.ScheduledTransferService.Resume(userID, id)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Scheduled transfer resumed", schedule)
+}
+
+// CancelScheduledTransfer cancels a scheduled transfer
+func (h *ScheduledTransferHandler) CancelScheduledTransfer(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	schedule, err := h.ScheduledTransferService.Cancel(userID, id)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Scheduled transfer cancelled", schedule)
+}
+
+// GetScheduledTransferExecutions lists the runs of a scheduled transfer
+func (h *ScheduledTransferHandler) GetScheduledTransferExecutions(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	page, limit, err := helpers.ValidatePagination(c.Query("page"), c.Query("limit"))
+	if err != nil {
+		helpers.BadRequestResponse(c, "Invalid pagination parameters", err)
+		return
+	}
+
+	executions, err := h.ScheduledTransferService.GetExecutions(userID, id, page, limit)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Scheduled transfer history retrieved", executions)
+}
+
+func (h *ScheduledTransferHandler) parseID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		helpers.BadRequestResponse(c, "Invalid scheduled transfer ID", err)
+		return 0, false
+	}
+	return id, true
+}
+
+func (h *ScheduledTransferHandler) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrScheduledTransferNotFound):
+		helpers.NotFoundResponse(c, err.Error())
+	case errors.Is(err, service.ErrRecipientLookupLimit):
+		helpers.ErrorResponse(c, http.StatusTooManyRequests, err.Error(), err)
+	default:
+		helpers.BadRequestResponse(c, err.Error(), err)
+	}
+}
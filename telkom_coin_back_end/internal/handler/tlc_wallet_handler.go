@@ -1,7 +1,10 @@
 package handler
 
 import (
+	"errors"
+	"net/http"
 	"strconv"
+	"time"
 	"telkom_coin_back_end/internal/dto/request"
 	service "telkom_coin_back_end/internal/services"
 	"telkom_coin_back_end/pkg/helpers"
@@ -9,6 +12,15 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+const maxStatusWaitSeconds = 60
+
+// Receive QR code size in pixels
+const (
+	defaultQRCodeSize = 256
+	minQRCodeSize     = 128
+	maxQRCodeSize     = 1024
+)
+
 type TLCWalletHandler struct {
 	TLCWalletService *service.TLCWalletService
 	UserService      *service.UserService
@@ -53,7 +65,37 @@ func (h *TLCWalletHandler) ValidateTransfer(c *gin.Context) {
 	}
 
 	// Call validate service (TIDAK ada PIN, cuma validasi data)
-	validateResult, err := h.TLCWalletService.ValidateTransfer(userID, req.ToAddress, req.Amount)
+	validateResult, err := h.TLCWalletService.ValidateTransfer(userID, req.ToAddress, req.Amount, req.Memo)
+	if limitExceeded(c, err) {
+		return
+	}
+	if errors.Is(err, service.ErrRecipientLookupLimit) {
+		helpers.ErrorResponse(c, http.StatusTooManyRequests, err.Error(), err)
+		return
+	}
+	if err != nil {
+		helpers.BadRequestResponse(c, err.Error(), err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Transfer validation successful", validateResult)
+}
+
+// ValidatePaymentURI validates a transfer from a scanned EIP-681 payment URI,
+// returning the same confirmation (and quote) as ValidateTransfer
+func (h *TLCWalletHandler) ValidatePaymentURI(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+
+	var req request.ValidatePaymentURIRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helpers.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	validateResult, err := h.TLCWalletService.ValidatePaymentURI(userID, req.URI, req.Amount)
+	if limitExceeded(c, err) {
+		return
+	}
 	if err != nil {
 		helpers.BadRequestResponse(c, err.Error(), err)
 		return
@@ -62,6 +104,38 @@ func (h *TLCWalletHandler) ValidateTransfer(c *gin.Context) {
 	helpers.SuccessResponse(c, "Transfer validation successful", validateResult)
 }
 
+// GetReceivePaymentURI returns the EIP-681 URI for receiving TLC (?amount=&memo=)
+func (h *TLCWalletHandler) GetReceivePaymentURI(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+
+	paymentURI, err := h.TLCWalletService.GetReceivePaymentURI(userID, c.Query("amount"), c.Query("memo"))
+	if err != nil {
+		helpers.BadRequestResponse(c, err.Error(), err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Payment URI generated", paymentURI)
+}
+
+// GetReceiveQRCode returns the receive payment URI as a PNG QR code (?amount=&memo=&size=)
+func (h *TLCWalletHandler) GetReceiveQRCode(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+
+	size, err := strconv.Atoi(c.DefaultQuery("size", strconv.Itoa(defaultQRCodeSize)))
+	if err != nil || size < minQRCodeSize || size > maxQRCodeSize {
+		helpers.BadRequestResponse(c, "size must be between 128 and 1024", err)
+		return
+	}
+
+	png, err := h.TLCWalletService.GetReceiveQRCode(userID, c.Query("amount"), c.Query("memo"), size)
+	if err != nil {
+		helpers.BadRequestResponse(c, err.Error(), err)
+		return
+	}
+
+	c.Data(http.StatusOK, "image/png", png)
+}
+
 // ============================================================================
 // HANDLER 2: TransferTLC - Untuk execute transfer (setelah confirm + PIN)
 // ============================================================================
@@ -74,14 +148,53 @@ func (h *TLCWalletHandler) TransferTLC(c *gin.Context) {
 		return
 	}
 
-	// Execute transfer (dengan PIN verification)
-	transferResult, err := h.TLCWalletService.TransferTLC(userID, req.ToAddress, req.Amount, req.Memo, req.Pin)
+	// Execute transfer (dengan PIN verification), returns as soon as the transaction is broadcast
+	transferResult, err := h.TLCWalletService.TransferTLC(userID, req.QuoteToken, req.Pin, req.CallbackURL)
+	if limitExceeded(c, err) {
+		return
+	}
+	if errors.Is(err, service.ErrRecipientLookupLimit) {
+		helpers.ErrorResponse(c, http.StatusTooManyRequests, err.Error(), err)
+		return
+	}
 	if err != nil {
 		helpers.BadRequestResponse(c, err.Error(), err)
 		return
 	}
 
-	helpers.SuccessResponse(c, "TLC transfer completed successfully", transferResult)
+	helpers.SuccessResponse(c, "TLC transfer submitted, waiting for confirmation", transferResult)
+}
+
+// GetTransferStatus returns the status of a transfer; ?wait=30 holds the request
+// until the transfer is confirmed or failed (max 60 seconds)
+func (h *TLCWalletHandler) GetTransferStatus(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	txHash := c.Param("hash")
+
+	wait := 0
+	if waitStr := c.Query("wait"); waitStr != "" {
+		num, err := strconv.Atoi(waitStr)
+		if err != nil || num < 0 {
+			helpers.BadRequestResponse(c, "Invalid wait parameter", err)
+			return
+		}
+		wait = num
+	}
+	if wait > maxStatusWaitSeconds {
+		wait = maxStatusWaitSeconds
+	}
+
+	status, err := h.TLCWalletService.GetTransferStatus(c.Request.Context(), userID, txHash, time.Duration(wait)*time.Second)
+	if err != nil {
+		if err.Error() == "transaction not found" {
+			helpers.NotFoundResponse(c, err.Error())
+			return
+		}
+		helpers.InternalServerErrorResponse(c, "Failed to get transfer status", err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Transfer status retrieved", status)
 }
 
 // GetTLCTransactionHistory gets transaction history from blockchain events (placeholder)
@@ -1,6 +1,9 @@
 package handler
 
 import (
+	"errors"
+	"net/http"
+	"strconv"
 	"telkom_coin_back_end/internal/dto/request"
 	service "telkom_coin_back_end/internal/services"
 	"telkom_coin_back_end/pkg/helpers"
@@ -9,11 +12,15 @@ import (
 )
 
 type TopupHandler struct {
-	TopupService *service.TopupService
+	TopupService          *service.TopupService
+	VirtualAccountService *service.VirtualAccountService
 }
 
-func NewTopupHandler(topupService *service.TopupService) *TopupHandler {
-	return &TopupHandler{TopupService: topupService}
+func NewTopupHandler(topupService *service.TopupService, virtualAccountService *service.VirtualAccountService) *TopupHandler {
+	return &TopupHandler{
+		TopupService:          topupService,
+		VirtualAccountService: virtualAccountService,
+	}
 }
 
 // RequestTopup handles topup request
@@ -33,12 +40,15 @@ func (h *TopupHandler) RequestTopup(c *gin.Context) {
 	}
 
 	topupResponse, err := h.TopupService.RequestTopup(userID, &req)
+	if limitExceeded(c, err) {
+		return
+	}
 	if err != nil {
 		helpers.BadRequestResponse(c, err.Error(), err)
 		return
 	}
 
-	helpers.SuccessResponse(c, "Topup request created successfully", topupResponse)
+	helpers.SuccessResponse(c, "Topup order created, waiting for payment", topupResponse)
 }
 
 // GetTopupHistory gets user's topup history
@@ -62,22 +72,106 @@ func (h *TopupHandler) GetTopupHistory(c *gin.Context) {
 	helpers.SuccessResponse(c, "Topup history retrieved", history)
 }
 
+// GetTopupOrders lists the user's topup orders with their payment status
+func (h *TopupHandler) GetTopupOrders(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+
+	page, limit, err := helpers.ValidatePagination(c.Query("page"), c.Query("limit"))
+	if err != nil {
+		helpers.BadRequestResponse(c, "Invalid pagination parameters", err)
+		return
+	}
+
+	orders, err := h.TopupService.ListTopupOrders(userID, page, limit)
+	if err != nil {
+		helpers.BadRequestResponse(c, err.Error(), err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Topup orders retrieved", orders)
+}
+
 // GetTopupDetail gets specific topup detail
 func (h *TopupHandler) GetTopupDetail(c *gin.Context) {
 	userID := c.GetInt64("user_id")
-	topupID := c.Param("id")
 
-	if topupID == "" {
-		helpers.BadRequestResponse(c, "Topup ID is required", nil)
+	topupID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || topupID <= 0 {
+		helpers.BadRequestResponse(c, "Invalid topup ID", err)
+		return
+	}
+
+	topup, err := h.TopupService.GetTopupOrder(userID, topupID)
+	if errors.Is(err, service.ErrTopupOrderNotFound) {
+		helpers.NotFoundResponse(c, err.Error())
+		return
+	}
+	if err != nil {
+		helpers.BadRequestResponse(c, err.Error(), err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Topup detail retrieved", topup)
+}
+
+// GetTopupQRCode returns the QRIS of an unpaid qris order as a PNG QR code (?size=256)
+func (h *TopupHandler) GetTopupQRCode(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+
+	topupID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || topupID <= 0 {
+		helpers.BadRequestResponse(c, "Invalid topup ID", err)
+		return
+	}
+
+	size, err := strconv.Atoi(c.DefaultQuery("size", strconv.Itoa(defaultQRCodeSize)))
+	if err != nil || size < minQRCodeSize || size > maxQRCodeSize {
+		helpers.BadRequestResponse(c, "size must be between 128 and 1024", err)
+		return
+	}
+
+	png, err := h.TopupService.GetTopupQRCode(userID, topupID, size)
+	if errors.Is(err, service.ErrTopupOrderNotFound) {
+		helpers.NotFoundResponse(c, err.Error())
+		return
+	}
+	if err != nil {
+		helpers.BadRequestResponse(c, err.Error(), err)
+		return
+	}
+
+	c.Data(http.StatusOK, "image/png", png)
+}
+
+// DecodeQRIS decodes a scanned QRIS string (merchant, amount, references)
+func (h *TopupHandler) DecodeQRIS(c *gin.Context) {
+	var req request.QRISRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helpers.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	qris, err := h.TopupService.DecodeQRIS(req.QRString)
+	if err != nil {
+		helpers.BadRequestResponse(c, err.Error(), err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "QRIS decoded", qris)
+}
+
+// GetVirtualAccounts returns the user's own virtual account numbers; transfers
+// to them are topped up without creating an order first
+func (h *TopupHandler) GetVirtualAccounts(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+
+	accounts, err := h.VirtualAccountService.GetVirtualAccounts(userID)
+	if err != nil {
+		helpers.BadRequestResponse(c, err.Error(), err)
 		return
 	}
 
-	// TODO: Implement GetTopupDetail in service
-	// For now, return empty response
-	helpers.SuccessResponse(c, "Topup detail retrieved", map[string]interface{}{
-		"id":      topupID,
-		"user_id": userID,
-	})
+	helpers.SuccessResponse(c, "Virtual accounts retrieved", accounts)
 }
 
 // CancelTopup cancels a pending topup request
@@ -109,7 +203,7 @@ func (h *TopupHandler) GetPaymentMethods(c *gin.Context) {
 		{
 			"method":      "va",
 			"name":        "Virtual Account",
-			"description": "Pay using virtual account",
+			"description": "Transfer to your own virtual account number, no order needed",
 			"min_amount":  "10000",
 			"max_amount":  "50000000",
 			"fee":         "2500",
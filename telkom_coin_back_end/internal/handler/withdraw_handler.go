@@ -50,6 +50,9 @@ func (h *WithdrawHandler) RequestWithdraw(c *gin.Context) {
 
 	// 👇 PANGGIL FUNGSI SERVICE YANG SUDAH DIREVISI 👇
 	withdrawResponse, err := h.WithdrawService.CreateWithdrawal(userID, req.Amount, bankAccountInfo, req.Pin)
+	if limitExceeded(c, err) {
+		return
+	}
 	if err != nil {
 		// Error dari service sudah cukup deskriptif
 		helpers.BadRequestResponse(c, err.Error(), err)
@@ -248,45 +251,15 @@ func (h *WithdrawHandler) CalculateWithdraw(c *gin.Context) {
 	helpers.SuccessResponse(c, "Withdrawal calculation completed", calculation)
 }
 
-// GetWithdrawLimits returns withdrawal limits for user
+// GetWithdrawLimits returns the user's withdrawal limits and remaining allowance
 func (h *WithdrawHandler) GetWithdrawLimits(c *gin.Context) {
 	userID := c.GetInt64("user_id")
 
-	// Get user info to determine limits based on KYC status
-	user, err := h.UserService.GetProfile(userID)
+	limits, err := h.WithdrawService.GetWithdrawLimits(userID)
 	if err != nil {
-		helpers.InternalServerErrorResponse(c, "Failed to get user info", err)
+		helpers.InternalServerErrorResponse(c, "Failed to get withdrawal limits", err)
 		return
 	}
 
-	var limits map[string]interface{}
-
-	switch user.KYCStatus {
-	case "verified":
-		limits = map[string]interface{}{
-			"daily_limit":     "500000000",  // 500M coins
-			"monthly_limit":   "2000000000", // 2B coins
-			"per_transaction": "100000000",  // 100M coins
-			"kyc_status":      "verified",
-			"fiat_withdrawal": true,
-		}
-	case "pending":
-		limits = map[string]interface{}{
-			"daily_limit":     "50000000",  // 50M coins
-			"monthly_limit":   "200000000", // 200M coins
-			"per_transaction": "10000000",  // 10M coins
-			"kyc_status":      "pending",
-			"fiat_withdrawal": false,
-		}
-	default:
-		limits = map[string]interface{}{
-			"daily_limit":     "5000000",  // 5M coins
-			"monthly_limit":   "20000000", // 20M coins
-			"per_transaction": "1000000",  // 1M coins
-			"kyc_status":      "unverified",
-			"fiat_withdrawal": false,
-		}
-	}
-
 	helpers.SuccessResponse(c, "Withdrawal limits retrieved", limits)
 }
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"telkom_coin_back_end/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminMiddleware protects internal endpoints with the shared ADMIN_API_KEY,
+// sent as the X-Admin-Key header. Without ADMIN_API_KEY every request is refused.
+func AdminMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		adminKey := config.AppConfig.AdminAPIKey
+		if adminKey == "" {
+			c.JSON(http.StatusForbidden, gin.H{"status": "error", "message": "Admin API is disabled"})
+			c.Abort()
+			return
+		}
+
+		providedKey := c.GetHeader("X-Admin-Key")
+		if subtle.ConstantTimeCompare([]byte(providedKey), []byte(adminKey)) != 1 {
+			c.JSON(http.StatusUnauthorized, gin.H{"status": "error", "message": "Invalid admin key"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
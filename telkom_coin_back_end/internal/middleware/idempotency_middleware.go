@@ -0,0 +1,109 @@
+package middleware
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"telkom_coin_back_end/internal/models"
+	service "telkom_coin_back_end/internal/services"
+	"telkom_coin_back_end/pkg/helpers"
+
+	"github.com/gin-gonic/gin"
+)
+
+// responseRecorder keeps a copy of the body written by the handler
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// IdempotencyMiddleware makes a route safe to retry with an Idempotency-Key header.
+// The first response for a key is stored and sent again for every replay; the same
+// key with a different body is rejected. Server errors (5xx) and panics are not
+// stored: the key is released so the client can retry with it. Requests without
+// the header run as before.
+// Must run after JWTMiddleware, keys are scoped per user.
+func IdempotencyMiddleware(idempotencyService *service.IdempotencyService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader("Idempotency-Key")
+		if key == "" {
+			c.Next()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			helpers.BadRequestResponse(c, "Failed to read request body", err)
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		// The request path, not the route pattern: a key used for
+		// /payment-requests/1/pay must not replay for /payment-requests/2/pay
+		userID := c.GetInt64("user_id")
+		record, replay, err := idempotencyService.Begin(userID, key, c.Request.Method, c.Request.URL.Path, body)
+		if err != nil {
+			switch {
+			case errors.Is(err, service.ErrIdempotencyKeyInvalid):
+				helpers.BadRequestResponse(c, err.Error(), nil)
+			case errors.Is(err, service.ErrIdempotencyKeyInProgress):
+				helpers.ErrorResponse(c, http.StatusConflict, err.Error(), nil)
+			case errors.Is(err, service.ErrIdempotencyKeyReused):
+				helpers.ErrorResponse(c, http.StatusUnprocessableEntity, err.Error(), nil)
+			default:
+				log.Printf("[ERROR] Idempotency key lookup failed: %v", err)
+				helpers.InternalServerErrorResponse(c, "Failed to check Idempotency-Key", err)
+			}
+			c.Abort()
+			return
+		}
+
+		if replay {
+			c.Header("Idempotent-Replayed", "true")
+			c.Data(record.ResponseCode, "application/json; charset=utf-8", []byte(record.ResponseBody))
+			c.Abort()
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				releaseIdempotencyKey(idempotencyService, record)
+				panic(recovered)
+			}
+		}()
+
+		c.Next()
+
+		// Successes and client errors are kept, a retry gets the same answer back
+		if recorder.Status() >= http.StatusInternalServerError {
+			releaseIdempotencyKey(idempotencyService, record)
+			return
+		}
+		if err := idempotencyService.Complete(record, recorder.Status(), recorder.body.Bytes()); err != nil {
+			log.Printf("[ERROR] Failed to store response for Idempotency-Key %s: %v", key, err)
+		}
+	}
+}
+
+// releaseIdempotencyKey frees the key of a request that ended in a server error or a panic
+func releaseIdempotencyKey(idempotencyService *service.IdempotencyService, record *models.IdempotencyKey) {
+	if err := idempotencyService.Release(record); err != nil {
+		log.Printf("[ERROR] Failed to release Idempotency-Key %s: %v", record.Key, err)
+	}
+}
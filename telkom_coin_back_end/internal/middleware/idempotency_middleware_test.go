@@ -0,0 +1,93 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"telkom_coin_back_end/config"
+	"telkom_coin_back_end/internal/models"
+	"telkom_coin_back_end/internal/repository"
+	service "telkom_coin_back_end/internal/services"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newIdempotencyRouter serves POST /payment-requests/:id/pay behind the
+// middleware for user 1 and counts the requests that reach the handler
+func newIdempotencyRouter(t *testing.T, handled *int) *gin.Engine {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("open test database: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("open test database: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+	if err := db.AutoMigrate(&models.IdempotencyKey{}); err != nil {
+		t.Fatalf("migrate test database: %v", err)
+	}
+
+	ttl := config.AppConfig.IdempotencyKeyTTL
+	config.AppConfig.IdempotencyKeyTTL = time.Hour
+	t.Cleanup(func() { config.AppConfig.IdempotencyKeyTTL = ttl })
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/payment-requests/:id/pay",
+		func(c *gin.Context) { c.Set("user_id", int64(1)) },
+		IdempotencyMiddleware(service.NewIdempotencyService(repository.NewIdempotencyRepository(db))),
+		func(c *gin.Context) {
+			*handled++
+			c.JSON(http.StatusOK, gin.H{"id": c.Param("id")})
+		})
+	return router
+}
+
+func TestIdempotencyMiddlewareKeyIsScopedToPath(t *testing.T) {
+	tests := []struct {
+		name        string
+		second      string
+		wantCode    int
+		wantHandled int
+		wantReplay  bool
+	}{
+		{"same payment request replays", "/payment-requests/1/pay", http.StatusOK, 1, true},
+		{"other payment request is a key reuse", "/payment-requests/2/pay", http.StatusUnprocessableEntity, 1, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handled := 0
+			router := newIdempotencyRouter(t, &handled)
+
+			send := func(path string) *httptest.ResponseRecorder {
+				req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{}`))
+				req.Header.Set("Idempotency-Key", "pay-key")
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				return w
+			}
+
+			if w := send("/payment-requests/1/pay"); w.Code != http.StatusOK {
+				t.Fatalf("first request = %d, want %d", w.Code, http.StatusOK)
+			}
+			w := send(tt.second)
+			if w.Code != tt.wantCode {
+				t.Errorf("second request = %d, want %d", w.Code, tt.wantCode)
+			}
+			if handled != tt.wantHandled {
+				t.Errorf("handler ran %d times, want %d", handled, tt.wantHandled)
+			}
+			if replayed := w.Header().Get("Idempotent-Replayed") == "true"; replayed != tt.wantReplay {
+				t.Errorf("Idempotent-Replayed = %v, want %v", replayed, tt.wantReplay)
+			}
+		})
+	}
+}
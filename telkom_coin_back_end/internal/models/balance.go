@@ -12,6 +12,7 @@ type Balance struct {
 	Balance       string    `gorm:"type:varchar(50);not null;default:'0'" json:"balance"`
 	LockedBalance string    `gorm:"type:varchar(50);default:'0'" json:"locked_balance"`
 	LastSyncBlock *int64    `gorm:"type:bigint" json:"last_sync_block,omitempty"`
+	NeedsReview   bool      `gorm:"not null;default:false;index" json:"needs_review"` // Set by the balance reconciler when a mismatch was not auto-fixed
 	UpdatedAt     time.Time `gorm:"autoUpdateTime" json:"updated_at"`
 }
 
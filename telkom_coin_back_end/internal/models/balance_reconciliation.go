@@ -0,0 +1,54 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	ReconciliationStatusRunning   = "running"
+	ReconciliationStatusCompleted = "completed"
+	ReconciliationStatusFailed    = "failed"
+
+	MismatchActionFixed   = "fixed"
+	MismatchActionFlagged = "flagged"
+)
+
+// BalanceReconciliationReport summarizes one run of the balance reconciler,
+// which compares every balances row with the PaymentToken balance on chain
+type BalanceReconciliationReport struct {
+	ID           int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	Status       string     `gorm:"type:varchar(20);not null;index" json:"status"`
+	BlockNumber  uint64     `gorm:"not null" json:"block_number"` // Chain balances are read at this block
+	UsersChecked int        `gorm:"not null;default:0" json:"users_checked"`
+	UsersSkipped int        `gorm:"not null;default:0" json:"users_skipped"` // Topup/withdraw still in the outbox
+	Mismatches   int        `gorm:"not null;default:0" json:"mismatches"`
+	Fixed        int        `gorm:"not null;default:0" json:"fixed"`
+	Flagged      int        `gorm:"not null;default:0" json:"flagged"`
+	Errors       int        `gorm:"not null;default:0" json:"errors"`
+	LastError    string     `gorm:"type:text" json:"last_error,omitempty"`
+	StartedAt    time.Time  `gorm:"not null;index" json:"started_at"`
+	FinishedAt   *time.Time `json:"finished_at,omitempty"`
+}
+
+func (BalanceReconciliationReport) TableName() string {
+	return "balance_reconciliation_reports"
+}
+
+// BalanceMismatch is one user whose stored balance differed from the chain
+type BalanceMismatch struct {
+	ID            int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	ReportID      int64     `gorm:"not null;index" json:"report_id"`
+	UserID        int64     `gorm:"not null;index" json:"user_id"`
+	WalletAddress string    `gorm:"type:varchar(42);not null" json:"wallet_address"`
+	DBBalance     string    `gorm:"type:varchar(50);not null" json:"db_balance"`
+	ChainBalance  string    `gorm:"type:varchar(80);not null" json:"chain_balance"`
+	Difference    string    `gorm:"type:varchar(80);not null" json:"difference"` // chain - db
+	LockedBalance string    `gorm:"type:varchar(50)" json:"locked_balance"`
+	Action        string    `gorm:"type:varchar(20);not null" json:"action"` // fixed, flagged
+	Reason        string    `gorm:"type:varchar(255)" json:"reason,omitempty"`
+	CreatedAt     time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (BalanceMismatch) TableName() string {
+	return "balance_mismatches"
+}
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	StatementItemMatched         = "matched"          // Credit backing a paid topup order
+	StatementItemUnmatchedCredit = "unmatched_credit" // Money in with no topup order to back
+	StatementItemUnbackedMint    = "unbacked_mint"    // Minted topup order with no credit on the statement
+
+	StatementMatchReference  = "reference"   // Topup reference found in the description
+	StatementMatchAmountTime = "amount_time" // Same amount, paid within BANK_STATEMENT_MATCH_WINDOW
+)
+
+// BankStatementReport is one imported bank statement reconciled against
+// topup orders: which credits back a topup, which credits back nothing and
+// which minted topups have no credit on the statement
+type BankStatementReport struct {
+	ID               int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	Format           string    `gorm:"type:varchar(10);not null" json:"format"` // csv, mt940
+	FileName         string    `gorm:"type:varchar(255)" json:"file_name"`
+	FileSHA256       string    `gorm:"type:varchar(64);uniqueIndex:idx_bank_statement_reports_file" json:"file_sha256"` // A file is imported once
+	AccountNumber    string    `gorm:"type:varchar(50)" json:"account_number,omitempty"`
+	PeriodFrom       time.Time `gorm:"not null" json:"period_from"` // First booking day on the statement
+	PeriodTo         time.Time `gorm:"not null" json:"period_to"`   // Last booking day on the statement
+	Credits          int       `gorm:"not null;default:0" json:"credits"`
+	Debits           int       `gorm:"not null;default:0" json:"debits"` // Not reconciled
+	Matched          int       `gorm:"not null;default:0" json:"matched"`
+	UnmatchedCredits int       `gorm:"not null;default:0" json:"unmatched_credits"`
+	UnbackedMints    int       `gorm:"not null;default:0" json:"unbacked_mints"`
+	CreditAmount     string    `gorm:"type:varchar(50);not null;default:'0'" json:"credit_amount"` // IDR
+	MatchedAmount    string    `gorm:"type:varchar(50);not null;default:'0'" json:"matched_amount"`
+	UnmatchedAmount  string    `gorm:"type:varchar(50);not null;default:'0'" json:"unmatched_amount"`
+	UnbackedAmount   string    `gorm:"type:varchar(50);not null;default:'0'" json:"unbacked_amount"`
+	CreatedAt        time.Time `gorm:"autoCreateTime;index" json:"created_at"`
+}
+
+func (BankStatementReport) TableName() string {
+	return "bank_statement_reports"
+}
+
+// BankStatementItem is one line of a report: a statement credit, a topup
+// order, or both when they matched
+type BankStatementItem struct {
+	ID             int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	ReportID       int64      `gorm:"not null;index" json:"report_id"`
+	Kind           string     `gorm:"type:varchar(20);not null;index" json:"kind"` // matched, unmatched_credit, unbacked_mint
+	Line           int        `json:"line,omitempty"`                              // Line in the statement file
+	EntryDate      *time.Time `json:"entry_date,omitempty"`
+	Amount         string     `gorm:"type:varchar(50)" json:"amount,omitempty"` // IDR on the statement
+	Description    string     `gorm:"type:text" json:"description,omitempty"`
+	BankReference  string     `gorm:"type:varchar(100)" json:"bank_reference,omitempty"`
+	OrderID        *int64     `gorm:"index" json:"order_id,omitempty"`
+	OrderReference string     `gorm:"type:varchar(40)" json:"order_reference,omitempty"`
+	OrderAmount    string     `gorm:"type:varchar(50)" json:"order_amount,omitempty"`
+	PaymentMethod  string     `gorm:"type:varchar(20)" json:"payment_method,omitempty"`
+	PaidAt         *time.Time `json:"paid_at,omitempty"`
+	MatchedBy      string     `gorm:"type:varchar(20)" json:"matched_by,omitempty"` // reference, amount_time
+	Reason         string     `gorm:"type:varchar(255)" json:"reason,omitempty"`
+	CreatedAt      time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (BankStatementItem) TableName() string {
+	return "bank_statement_items"
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	OutboxActionTopup        = "topup"
+	OutboxActionTopupRequest = "topup_request" // Delayed topup, minted later by processTopup
+	OutboxActionWithdraw     = "withdraw"
+
+	OutboxStatusPending   = "pending"   // Intent saved, not signed yet
+	OutboxStatusSigned    = "signed"    // Signed, hash known, not accepted by a node yet
+	OutboxStatusBroadcast = "broadcast" // Accepted by a node, waiting for the receipt
+	OutboxStatusConfirmed = "confirmed"
+	OutboxStatusFailed    = "failed"
+)
+
+// ChainOutbox is the intent of a mint or burn, written before anything is sent to
+// the chain (in the same DB transaction as the balance lock). The outbox relay
+// signs and broadcasts it, the outbox recorder settles it from the receipt.
+type ChainOutbox struct {
+	ID            int64               `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID        int64               `gorm:"not null;index" json:"user_id"`
+	Action        string              `gorm:"type:varchar(20);not null" json:"action"` // topup, topup_request, withdraw
+	WalletAddress string              `gorm:"type:varchar(42);not null" json:"wallet_address"`
+	Amount        string              `gorm:"type:varchar(50);not null" json:"amount"`
+	Payload       TransactionMetadata `gorm:"type:json" json:"payload,omitempty"` // payment_method, payment_proof, bank_account
+	Status        string              `gorm:"type:varchar(20);not null;default:'pending';index" json:"status"`
+	TxHash        string              `gorm:"type:varchar(66);index" json:"tx_hash,omitempty"`
+	RawTx         string              `gorm:"type:text" json:"-"` // Signed transaction (hex), rebroadcast after a restart
+	Attempts      int                 `gorm:"not null;default:0" json:"attempts"`
+	LastError     string              `gorm:"type:text" json:"last_error,omitempty"`
+	NextAttemptAt *time.Time          `gorm:"index" json:"next_attempt_at,omitempty"` // Failed broadcasts back off until then
+	CreatedAt     time.Time           `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt     time.Time           `gorm:"autoUpdateTime" json:"updated_at"`
+	BroadcastAt   *time.Time          `json:"broadcast_at,omitempty"`
+	NotFoundSince *time.Time          `json:"not_found_since,omitempty"` // First receipt check the node did not know the transaction
+	FinalizedAt   *time.Time          `json:"finalized_at,omitempty"`
+}
+
+func (ChainOutbox) TableName() string {
+	return "chain_outbox"
+}
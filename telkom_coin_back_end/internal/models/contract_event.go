@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+)
+
+// ContractEvent is a PaymentToken log persisted by the contract event indexer.
+// Every log is stored; IsPrimary marks the one event per transaction that the
+// explorer shows (custom events win over the plain ERC20 Transfer). Rows belong
+// to one deployment (chain ID and contract address), so switching networks or
+// redeploying never mixes or deletes the events of another one.
+type ContractEvent struct {
+	ID              int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	ChainID         int64     `gorm:"not null;default:0;uniqueIndex:idx_contract_events_deployment_log,priority:1;index:idx_contract_events_deployment,priority:1" json:"chain_id"`
+	ContractAddress string    `gorm:"type:varchar(42);not null;default:'';uniqueIndex:idx_contract_events_deployment_log,priority:2;index:idx_contract_events_deployment,priority:2" json:"contract_address"`
+	TxHash          string    `gorm:"type:varchar(66);not null;uniqueIndex:idx_contract_events_deployment_log,priority:3;index" json:"tx_hash"`
+	LogIndex        uint      `gorm:"not null;uniqueIndex:idx_contract_events_deployment_log,priority:4" json:"log_index"`
+	BlockNumber     uint64    `gorm:"not null;index" json:"block_number"`
+	BlockHash       string    `gorm:"type:varchar(66);not null" json:"block_hash"`
+	EventName       string    `gorm:"type:varchar(32);not null;index" json:"event_name"`
+	TxType          string    `gorm:"type:varchar(20);not null;index" json:"tx_type"` // transfer, topup, withdraw, mint, burn
+	Status          string    `gorm:"type:varchar(20);not null;default:'confirmed'" json:"status"`
+	FromAddress     string    `gorm:"type:varchar(42);not null;index" json:"from_address"`
+	ToAddress       string    `gorm:"type:varchar(42);not null;index" json:"to_address"`
+	Amount          string    `gorm:"type:varchar(50);not null" json:"amount"`
+	AmountWei       string    `gorm:"type:varchar(80);not null" json:"amount_wei"`
+	RequestID       string    `gorm:"type:varchar(66);index" json:"request_id,omitempty"`
+	Memo            string    `gorm:"type:text" json:"memo,omitempty"`
+	IsPrimary       bool      `gorm:"not null;default:false;index" json:"is_primary"`
+	BlockTime       time.Time `gorm:"not null" json:"block_time"`
+	CreatedAt       time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (ContractEvent) TableName() string {
+	return "contract_events"
+}
+
+// IndexerCheckpoint stores the last block an indexer has fully processed
+type IndexerCheckpoint struct {
+	ID               int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	Name             string    `gorm:"type:varchar(100);uniqueIndex;not null" json:"name"`
+	LastIndexedBlock uint64    `gorm:"not null;default:0" json:"last_indexed_block"`
+	LastIndexedHash  string    `gorm:"type:varchar(66)" json:"last_indexed_hash"` // Checked on resume to catch a reorg
+	UpdatedAt        time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (IndexerCheckpoint) TableName() string {
+	return "indexer_checkpoints"
+}
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+)
+
+// LimitReservation holds an amount against the user's limits while the
+// operation that uses it is still in progress. It is deleted once the
+// operation's own row (transaction, outbox message or topup order) is saved;
+// one left behind by a crash stops counting at ExpiresAt.
+type LimitReservation struct {
+	ID        int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID    int64     `gorm:"not null;index:idx_limit_reservations_user_operation" json:"user_id"`
+	Operation string    `gorm:"type:varchar(20);not null;index:idx_limit_reservations_user_operation" json:"operation"`
+	Amount    string    `gorm:"type:varchar(50);not null" json:"amount"` // TLC
+	ExpiresAt time.Time `gorm:"not null;index" json:"expires_at"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (LimitReservation) TableName() string {
+	return "limit_reservations"
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	PaymentRequestStatusOpen     = "open"
+	PaymentRequestStatusPaying   = "paying" // Claimed by the payer, transfer broadcast and waiting for its receipt
+	PaymentRequestStatusPaid     = "paid"   // Transfer confirmed
+	PaymentRequestStatusDeclined = "declined"
+	PaymentRequestStatusExpired  = "expired"
+)
+
+// PaymentRequest is one user asking another for TLC. Paying it runs a normal
+// transfer from the payer to the requester and links the transfer hash; the
+// receipt of that transfer moves it to paid, or back to open when it failed.
+type PaymentRequest struct {
+	ID          int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	RequesterID int64      `gorm:"not null;index" json:"requester_id"`
+	PayerID     int64      `gorm:"not null;index" json:"payer_id"`
+	Amount      string     `gorm:"type:varchar(50);not null" json:"amount"` // TLC
+	Memo        string     `gorm:"type:varchar(100)" json:"memo,omitempty"`
+	Status      string     `gorm:"type:varchar(20);not null;default:'open';index" json:"status"`
+	TxHash      string     `gorm:"type:varchar(66);index" json:"tx_hash,omitempty"`
+	ExpiresAt   time.Time  `gorm:"not null;index" json:"expires_at"`
+	PaidAt      *time.Time `json:"paid_at,omitempty"`
+	DeclinedAt  *time.Time `json:"declined_at,omitempty"`
+	CreatedAt   time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (PaymentRequest) TableName() string {
+	return "payment_requests"
+}
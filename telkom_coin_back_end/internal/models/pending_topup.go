@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	PendingTopupStatusRequesting = "requesting" // requestTopup in chain_outbox, not mined yet
+	PendingTopupStatusRequested  = "requested"  // Request ID known, waiting for the contract's delay
+	PendingTopupStatusProcessing = "processing" // processTopup signed by the keeper, waiting for the receipt
+	PendingTopupStatusProcessed  = "processed"
+	PendingTopupStatusFailed     = "failed"
+)
+
+// PendingTopup is a delayed topup: the user's requestTopup goes through the
+// chain outbox, and the topup keeper calls processTopup once the contract's
+// waiting period is over. Balances are credited from the processTopup receipt.
+type PendingTopup struct {
+	ID            int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID        int64      `gorm:"not null;index" json:"user_id"`
+	OutboxID      int64      `gorm:"not null;uniqueIndex" json:"outbox_id"`   // requestTopup message
+	Reference     string     `gorm:"type:varchar(40);index" json:"reference"` // Topup order reference
+	PaymentMethod string     `gorm:"type:varchar(20)" json:"payment_method"`
+	WalletAddress string     `gorm:"type:varchar(42);not null" json:"wallet_address"`
+	Amount        string     `gorm:"type:varchar(50);not null" json:"amount"`
+	RequestTxHash string     `gorm:"type:varchar(66)" json:"request_tx_hash,omitempty"`
+	RequestID     string     `gorm:"type:varchar(66);index" json:"request_id,omitempty"` // bytes32 from TopupRequested
+	Status        string     `gorm:"type:varchar(20);not null;default:'requesting';index" json:"status"`
+	ProcessableAt *time.Time `gorm:"index" json:"processable_at,omitempty"`
+	ProcessTxHash string     `gorm:"type:varchar(66);index" json:"process_tx_hash,omitempty"`
+	RawTx         string     `gorm:"type:text" json:"-"` // Signed processTopup (hex), resent if the node drops it
+	Attempts      int        `gorm:"not null;default:0" json:"attempts"`
+	LastError     string     `gorm:"type:text" json:"last_error,omitempty"`
+	ProcessedAt   *time.Time `json:"processed_at,omitempty"`
+	CreatedAt     time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt     time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (PendingTopup) TableName() string {
+	return "pending_topups"
+}
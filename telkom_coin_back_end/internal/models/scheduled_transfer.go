@@ -0,0 +1,67 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	ScheduleFrequencyOnce    = "once"
+	ScheduleFrequencyDaily   = "daily"
+	ScheduleFrequencyWeekly  = "weekly"
+	ScheduleFrequencyMonthly = "monthly"
+
+	ScheduleStatusActive    = "active"
+	ScheduleStatusPaused    = "paused"
+	ScheduleStatusCancelled = "cancelled"
+	ScheduleStatusCompleted = "completed" // Last occurrence done (once, or past EndAt)
+
+	ScheduleExecutionSending  = "sending"  // Recorded before the broadcast; resolved from the transactions after a crash
+	ScheduleExecutionSent     = "sent"     // Broadcast, tx hash linked
+	ScheduleExecutionRetrying = "retrying" // Failed, tried again after SCHEDULED_TRANSFER_RETRY_INTERVAL
+	ScheduleExecutionFailed   = "failed"   // Failed after the last retry, occurrence skipped
+)
+
+// ScheduledTransfer is a future-dated or recurring transfer the user authorized
+// with the PIN. Occurrence N is due at StartAt plus N periods; NextRunAt is when
+// the worker tries it next (later than the occurrence while retrying).
+type ScheduledTransfer struct {
+	ID              int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID          int64      `gorm:"not null;index" json:"user_id"`
+	ToAddress       string     `gorm:"type:varchar(42);not null" json:"to_address"`
+	Amount          string     `gorm:"type:varchar(50);not null" json:"amount"` // TLC
+	Memo            string     `gorm:"type:varchar(100)" json:"memo,omitempty"`
+	Frequency       string     `gorm:"type:varchar(20);not null" json:"frequency"`
+	StartAt         time.Time  `gorm:"not null" json:"start_at"`
+	EndAt           *time.Time `json:"end_at,omitempty"`
+	Status          string     `gorm:"type:varchar(20);not null;default:'active';index" json:"status"`
+	OccurrenceIndex int        `gorm:"not null;default:0" json:"occurrence_index"`
+	NextRunAt       time.Time  `gorm:"not null;index" json:"next_run_at"`
+	RetryCount      int        `gorm:"not null;default:0" json:"retry_count"`
+	LastRunAt       *time.Time `json:"last_run_at,omitempty"`
+	LastError       string     `gorm:"type:text" json:"last_error,omitempty"`
+	CreatedAt       time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt       time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (ScheduledTransfer) TableName() string {
+	return "scheduled_transfers"
+}
+
+// ScheduledTransferExecution is the run of one occurrence of a scheduled
+// transfer; retries update the same row, so an occurrence is sent at most once
+type ScheduledTransferExecution struct {
+	ID           int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	ScheduleID   int64     `gorm:"not null;uniqueIndex:idx_schedule_occurrence" json:"schedule_id"`
+	Occurrence   int       `gorm:"not null;uniqueIndex:idx_schedule_occurrence" json:"occurrence"`
+	ScheduledFor time.Time `gorm:"not null" json:"scheduled_for"`
+	Attempt      int       `gorm:"not null" json:"attempt"`
+	Status       string    `gorm:"type:varchar(20);not null;index" json:"status"`
+	TxHash       string    `gorm:"type:varchar(66)" json:"tx_hash,omitempty"`
+	Error        string    `gorm:"type:text" json:"error,omitempty"`
+	CreatedAt    time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt    time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (ScheduledTransferExecution) TableName() string {
+	return "scheduled_transfer_executions"
+}
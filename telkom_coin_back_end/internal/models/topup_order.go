@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	TopupOrderStatusCreated         = "created"          // Saved, charge not created at the provider yet
+	TopupOrderStatusAwaitingPayment = "awaiting_payment" // Charge created, waiting for the payment webhook
+	TopupOrderStatusPaid            = "paid"             // Payment confirmed by a signed webhook, not minted yet
+	TopupOrderStatusMinted          = "minted"           // Mint intent in chain_outbox
+	TopupOrderStatusExpired         = "expired"          // Not paid in time, or the charge could not be created
+	TopupOrderStatusMintFailed      = "mint_failed"      // Paid, but every mint attempt failed; needs review
+)
+
+// TopupOrder is a fiat topup. TLC is only minted after the payment provider
+// confirms the IDR payment with a signed webhook.
+type TopupOrder struct {
+	ID                int64               `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID            int64               `gorm:"not null;index" json:"user_id"`
+	Reference         string              `gorm:"type:varchar(40);uniqueIndex;not null" json:"reference"`
+	Amount            string              `gorm:"type:varchar(50);not null" json:"amount"` // IDR, minted 1:1 as TLC
+	PaymentMethod     string              `gorm:"type:varchar(20);not null" json:"payment_method"`
+	Provider          string              `gorm:"type:varchar(30);not null" json:"provider"`
+	ProviderReference string              `gorm:"type:varchar(100);index" json:"provider_reference,omitempty"`
+	PaymentDetails    TransactionMetadata `gorm:"type:json" json:"payment_details,omitempty"` // Instructions from the provider
+	Status            string              `gorm:"type:varchar(20);not null;default:'created';index" json:"status"`
+	OutboxID          *int64              `json:"outbox_id,omitempty"`
+	TxHash            string              `gorm:"type:varchar(66)" json:"tx_hash,omitempty"`
+	LastError         string              `gorm:"type:text" json:"last_error,omitempty"`
+	MintAttempts      int                 `gorm:"not null;default:0" json:"mint_attempts"` // Mints the outbox gave up on
+	ExpiresAt         time.Time           `gorm:"not null;index" json:"expires_at"`
+	PaidAt            *time.Time          `json:"paid_at,omitempty"`
+	MintedAt          *time.Time          `json:"minted_at,omitempty"`
+	CreatedAt         time.Time           `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt         time.Time           `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (TopupOrder) TableName() string {
+	return "topup_orders"
+}
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+)
+
+// TransferQuoteClaim records that a transfer quote was executed. The quote ID is
+// the primary key, so a quote is claimed once even across instances and
+// restarts; rows are removed once the quote itself has expired.
+type TransferQuoteClaim struct {
+	QuoteID   string    `gorm:"type:varchar(64);primaryKey" json:"quote_id"`
+	UserID    int64     `gorm:"not null;index" json:"user_id"`
+	ExpiresAt time.Time `gorm:"not null;index" json:"expires_at"` // Quote expiry
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (TransferQuoteClaim) TableName() string {
+	return "transfer_quote_claims"
+}
@@ -8,7 +8,8 @@ type User struct {
 	ID                  int64     `gorm:"primaryKey;autoIncrement" json:"id"`
 	Username            string    `gorm:"type:varchar(50);uniqueIndex;not null" json:"username"`
 	Email               string    `gorm:"type:varchar(100);uniqueIndex;not null" json:"email"`
-	Phone               string    `gorm:"type:varchar(20)" json:"phone,omitempty"`
+	Phone               string    `gorm:"type:varchar(20);index" json:"phone,omitempty"`
+	PhoneDiscoverable   bool      `gorm:"not null;default:true" json:"phone_discoverable"` // Others can send to this user by phone number
 	PasswordHash        string    `gorm:"type:varchar(255);not null" json:"-"`
 	WalletAddress       string    `gorm:"type:varchar(42);uniqueIndex;not null" json:"wallet_address"`
 	PrivateKeyEncrypted string    `gorm:"type:text;not null" json:"-"`
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	VACreditStatusMatched     = "matched"      // Matched to a user, topup order created
+	VACreditStatusNeedsReview = "needs_review" // No matching user, waiting in the manual review queue
+	VACreditStatusResolved    = "resolved"     // Assigned to a user by an admin
+	VACreditStatusRejected    = "rejected"     // Not credited (e.g. refunded to the sender)
+)
+
+// VirtualAccount is a user's stable virtual account number at one bank
+type VirtualAccount struct {
+	ID        int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID    int64     `gorm:"not null;uniqueIndex:idx_virtual_account_user_bank" json:"user_id"`
+	Bank      string    `gorm:"type:varchar(20);not null;uniqueIndex:idx_virtual_account_user_bank" json:"bank"`
+	Number    string    `gorm:"type:varchar(20);not null;uniqueIndex" json:"number"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (VirtualAccount) TableName() string {
+	return "virtual_accounts"
+}
+
+// VirtualAccountCredit is an incoming transfer to a virtual account. Matched
+// credits become paid topup orders; the others wait for manual review.
+type VirtualAccountCredit struct {
+	ID            int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	Provider      string     `gorm:"type:varchar(30);not null;uniqueIndex:idx_va_credit_transaction" json:"provider"`
+	TransactionID string     `gorm:"type:varchar(100);not null;uniqueIndex:idx_va_credit_transaction" json:"transaction_id"`
+	Bank          string     `gorm:"type:varchar(20)" json:"bank"`
+	VANumber      string     `gorm:"type:varchar(30);index" json:"va_number"`
+	Amount        string     `gorm:"type:varchar(50);not null" json:"amount"` // IDR
+	PaidAt        time.Time  `json:"paid_at"`
+	Status        string     `gorm:"type:varchar(20);not null;index" json:"status"`
+	Reason        string     `gorm:"type:varchar(255)" json:"reason,omitempty"` // Why it needs review
+	UserID        *int64     `gorm:"index" json:"user_id,omitempty"`
+	OrderID       *int64     `json:"order_id,omitempty"`
+	Note          string     `gorm:"type:text" json:"note,omitempty"` // Admin note on resolve/reject
+	ReviewedAt    *time.Time `json:"reviewed_at,omitempty"`
+	CreatedAt     time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt     time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (VirtualAccountCredit) TableName() string {
+	return "virtual_account_credits"
+}
@@ -0,0 +1,185 @@
+package payment
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+)
+
+const (
+	ProviderMock = "mock"
+
+	// MockSignatureHeader carries sha256=<hex HMAC of the body> with PAYMENT_WEBHOOK_SECRET
+	MockSignatureHeader = "X-Payment-Signature"
+
+	mockBankName    = "BNI"
+	mockAccountNo   = "0987654321"
+	mockAccountName = "PT Telkom Coin Indonesia"
+
+	// One-off VA numbers of VA charges. Not the prefix of any bank in
+	// VirtualAccountBanks, so a charge number never collides with a user's fixed
+	// virtual account (ValidateVANumber rejects it)
+	mockVAPrefix = "9888"
+
+	// Acquirer of the mock provider's QRIS payloads, see Simulator
+	MockQRISAcquirer = "ID.CO.MOCKPAY.WWW"
+	mockQRISCriteria = "UBE"
+	mockQRISTerminal = "TLCAPP"
+)
+
+// MockProvider is a local payment provider for development. Nothing is
+// collected; a payment is confirmed by posting a webhook signed with the
+// shared secret (see Sign).
+type MockProvider struct {
+	secret   []byte
+	merchant QRISMerchant
+}
+
+func NewMockProvider(webhookSecret string, merchant QRISMerchant) *MockProvider {
+	merchant.AcquirerDomain = MockQRISAcquirer
+	if merchant.Criteria == "" {
+		merchant.Criteria = mockQRISCriteria
+	}
+	if merchant.MerchantID == "" {
+		merchant.MerchantID = merchant.NMID
+	}
+	return &MockProvider{secret: []byte(webhookSecret), merchant: merchant}
+}
+
+func (p *MockProvider) Name() string {
+	return ProviderMock
+}
+
+func (p *MockProvider) SupportsMethod(method string) bool {
+	return method == MethodBankTransfer || method == MethodVA || method == MethodQRIS
+}
+
+// CreateCharge returns payment instructions for the method
+func (p *MockProvider) CreateCharge(req ChargeRequest) (*Charge, error) {
+	if !p.SupportsMethod(req.Method) {
+		return nil, fmt.Errorf("payment method %q is not supported", req.Method)
+	}
+
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	charge := &Charge{
+		ProviderReference: "MOCK-" + strings.ToUpper(hex.EncodeToString(id)),
+		ExpiresAt:         req.ExpiresAt,
+	}
+
+	switch req.Method {
+	case MethodBankTransfer:
+		charge.Instructions = map[string]interface{}{
+			"bank":           mockBankName,
+			"account_number": mockAccountNo,
+			"account_name":   mockAccountName,
+			"transfer_note":  req.Reference,
+			"amount":         req.Amount,
+		}
+	case MethodVA:
+		number, err := randomDigits(12)
+		if err != nil {
+			return nil, err
+		}
+		charge.Instructions = map[string]interface{}{
+			"bank":      mockBankName,
+			"va_number": mockVAPrefix + number,
+			"amount":    req.Amount,
+		}
+	case MethodQRIS:
+		// Dynamic QRIS: the amount is fixed and the bill number is the order reference
+		qrString, err := EncodeQRIS(&QRIS{
+			Dynamic:        true,
+			Merchant:       p.merchant,
+			Amount:         req.Amount,
+			BillNumber:     req.Reference,
+			ReferenceLabel: charge.ProviderReference,
+			TerminalLabel:  mockQRISTerminal,
+		})
+		if err != nil {
+			return nil, err
+		}
+		charge.Instructions = map[string]interface{}{
+			"qr_string":     qrString,
+			"merchant_name": p.merchant.Name,
+			"nmid":          p.merchant.NMID,
+			"amount":        req.Amount,
+		}
+	}
+	return charge, nil
+}
+
+// ParseWebhook verifies the X-Payment-Signature header and decodes the body
+func (p *MockProvider) ParseWebhook(header http.Header, body []byte) (*WebhookEvent, error) {
+	if err := p.verify(header, body); err != nil {
+		return nil, err
+	}
+
+	var event WebhookEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, errors.New("invalid webhook body")
+	}
+	if event.Reference == "" {
+		return nil, errors.New("webhook has no reference")
+	}
+	if event.Status != StatusPaid && event.Status != StatusExpired {
+		return nil, fmt.Errorf("unknown webhook status %q", event.Status)
+	}
+	return &event, nil
+}
+
+// ParseCreditNotification verifies the X-Payment-Signature header and decodes
+// an incoming transfer to a virtual account
+func (p *MockProvider) ParseCreditNotification(header http.Header, body []byte) (*CreditNotification, error) {
+	if err := p.verify(header, body); err != nil {
+		return nil, err
+	}
+
+	var credit CreditNotification
+	if err := json.Unmarshal(body, &credit); err != nil {
+		return nil, errors.New("invalid credit notification body")
+	}
+	if credit.TransactionID == "" || credit.VANumber == "" || credit.Amount == "" {
+		return nil, errors.New("credit notification needs transaction_id, va_number and amount")
+	}
+	credit.Bank = strings.ToUpper(credit.Bank)
+	return &credit, nil
+}
+
+func (p *MockProvider) verify(header http.Header, body []byte) error {
+	if len(p.secret) == 0 {
+		return errors.New("PAYMENT_WEBHOOK_SECRET is not set")
+	}
+	if !hmac.Equal([]byte(header.Get(MockSignatureHeader)), []byte(p.Sign(body))) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// Sign returns the signature header value for a webhook body
+func (p *MockProvider) Sign(body []byte) string {
+	mac := hmac.New(sha256.New, p.secret)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func randomDigits(n int) (string, error) {
+	var b strings.Builder
+	for i := 0; i < n; i++ {
+		digit, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(digit.String())
+	}
+	return b.String(), nil
+}
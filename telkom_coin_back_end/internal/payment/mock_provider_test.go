@@ -0,0 +1,84 @@
+package payment
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+const testWebhookSecret = "whsec_test"
+
+func TestMockProviderSign(t *testing.T) {
+	p := NewMockProvider(testWebhookSecret, QRISMerchant{})
+	body := []byte(`{"reference":"TOP-1","status":"paid"}`)
+	want := "sha256=45ce57f9d1b28fa0d4a71be364f163ad3dab98dd2d1bd23051afab8f30634121"
+	if got := p.Sign(body); got != want {
+		t.Errorf("Sign = %q, want %q", got, want)
+	}
+}
+
+func TestMockProviderParseWebhook(t *testing.T) {
+	p := NewMockProvider(testWebhookSecret, QRISMerchant{})
+	paid := []byte(`{"reference":"TOP-1","provider_reference":"MOCK-1","status":"paid","amount":"150000"}`)
+
+	tests := []struct {
+		name      string
+		provider  *MockProvider
+		body      []byte
+		signature string
+		wantErr   bool
+		// The error is ErrInvalidSignature
+		badSignature bool
+	}{
+		{name: "valid", provider: p, body: paid, signature: p.Sign(paid)},
+		{name: "missing signature", provider: p, body: paid, wantErr: true, badSignature: true},
+		{name: "signature without prefix", provider: p, body: paid, signature: p.Sign(paid)[len("sha256="):], wantErr: true, badSignature: true},
+		{name: "signed with another secret", provider: p, body: paid, signature: NewMockProvider("other", QRISMerchant{}).Sign(paid), wantErr: true, badSignature: true},
+		{name: "body changed after signing", provider: p, body: []byte(`{"reference":"TOP-1","provider_reference":"MOCK-1","status":"paid","amount":"950000"}`), signature: p.Sign(paid), wantErr: true, badSignature: true},
+		{name: "no secret configured", provider: NewMockProvider("", QRISMerchant{}), body: paid, signature: NewMockProvider("", QRISMerchant{}).Sign(paid), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.signature != "" {
+				header.Set(MockSignatureHeader, tt.signature)
+			}
+			event, err := tt.provider.ParseWebhook(header, tt.body)
+			switch {
+			case tt.wantErr:
+				if err == nil || errors.Is(err, ErrInvalidSignature) != tt.badSignature {
+					t.Fatalf("ParseWebhook error = %v, want error (invalid signature: %v)", err, tt.badSignature)
+				}
+			case err != nil:
+				t.Fatalf("ParseWebhook error = %v", err)
+			case event.Reference != "TOP-1" || event.Status != StatusPaid || event.Amount != "150000":
+				t.Errorf("ParseWebhook = %+v", event)
+			}
+		})
+	}
+}
+
+func TestMockProviderParseWebhookBody(t *testing.T) {
+	p := NewMockProvider(testWebhookSecret, QRISMerchant{})
+	tests := []struct {
+		name    string
+		body    string
+		wantErr bool
+	}{
+		{name: "paid", body: `{"reference":"TOP-1","status":"paid"}`},
+		{name: "expired", body: `{"reference":"TOP-1","status":"expired"}`},
+		{name: "unknown status", body: `{"reference":"TOP-1","status":"refunded"}`, wantErr: true},
+		{name: "no reference", body: `{"status":"paid"}`, wantErr: true},
+		{name: "not JSON", body: `reference=TOP-1`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			header.Set(MockSignatureHeader, p.Sign([]byte(tt.body)))
+			_, err := p.ParseWebhook(header, []byte(tt.body))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseWebhook(%s) error = %v, wantErr %v", tt.body, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package payment
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Payment methods a topup order can be paid with
+const (
+	MethodBankTransfer = "bank_transfer"
+	MethodVA           = "va"
+	MethodQRIS         = "qris"
+)
+
+// Statuses a provider reports in a webhook
+const (
+	StatusPaid    = "paid"
+	StatusExpired = "expired"
+)
+
+var ErrInvalidSignature = errors.New("invalid webhook signature")
+
+// ChargeRequest asks the provider to collect Amount IDR for a topup order
+type ChargeRequest struct {
+	Reference string // Topup order reference, echoed back in the webhook
+	Method    string
+	Amount    string // IDR
+	ExpiresAt time.Time
+}
+
+// Charge is what the user needs to pay a topup order
+type Charge struct {
+	ProviderReference string
+	Instructions      map[string]interface{} // account or VA number, QR string, ...
+	ExpiresAt         time.Time
+}
+
+// WebhookEvent is a verified payment notification from the provider
+type WebhookEvent struct {
+	Reference         string    `json:"reference"`
+	ProviderReference string    `json:"provider_reference"`
+	Status            string    `json:"status"` // paid or expired
+	Amount            string    `json:"amount"` // IDR
+	PaidAt            time.Time `json:"paid_at"`
+}
+
+// PaymentProvider collects IDR for topup orders and confirms payments with
+// signed webhooks
+type PaymentProvider interface {
+	Name() string
+	SupportsMethod(method string) bool
+	CreateCharge(req ChargeRequest) (*Charge, error)
+	// ParseWebhook verifies the signature of a webhook request and decodes it
+	ParseWebhook(header http.Header, body []byte) (*WebhookEvent, error)
+	// ParseCreditNotification verifies and decodes an incoming transfer to a virtual account
+	ParseCreditNotification(header http.Header, body []byte) (*CreditNotification, error)
+}
+
+// NewProvider returns the provider selected with PAYMENT_PROVIDER; merchant is
+// who QRIS payloads pay
+func NewProvider(name, webhookSecret string, merchant QRISMerchant) (PaymentProvider, error) {
+	switch name {
+	case "", ProviderMock:
+		return NewMockProvider(webhookSecret, merchant), nil
+	default:
+		return nil, fmt.Errorf("unknown payment provider %q", name)
+	}
+}
@@ -0,0 +1,353 @@
+package payment
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// QRIS payloads follow the EMVCo Merchant-Presented Mode layout: a list of
+// <ID (2 digits)><length (2 digits)><value> fields, ending with the CRC16
+// (CCITT-FALSE) of everything before it, including "6304".
+const (
+	qrisPayloadFormat     = "00"
+	qrisInitiation        = "01"
+	qrisMerchantFirst     = "26" // Merchant account information templates 26-45
+	qrisMerchantLast      = "45"
+	qrisNational          = "51" // QRIS national merchant ID template
+	qrisCategoryCode      = "52"
+	qrisCurrency          = "53"
+	qrisAmount            = "54"
+	qrisCountryCode       = "58"
+	qrisMerchantName      = "59"
+	qrisMerchantCity      = "60"
+	qrisPostalCode        = "61"
+	qrisAdditionalData    = "62"
+	qrisCRC               = "63"
+	qrisInitiationStatic  = "11"
+	qrisInitiationDynamic = "12"
+
+	// Sub-fields of the merchant account and national templates
+	qrisSubDomain     = "00"
+	qrisSubPAN        = "01"
+	qrisSubMerchantID = "02"
+	qrisSubCriteria   = "03"
+
+	// Sub-fields of the additional data template
+	qrisSubBillNumber     = "01"
+	qrisSubReferenceLabel = "05"
+	qrisSubTerminalLabel  = "07"
+
+	QRISDomain       = "ID.CO.QRIS.WWW"
+	QRISCurrencyIDR  = "360"
+	QRISCountryCode  = "ID"
+	qrisCRCTagLength = "6304"
+)
+
+var (
+	ErrInvalidQRIS  = errors.New("invalid QRIS payload")
+	ErrQRISChecksum = errors.New("QRIS checksum does not match")
+)
+
+// QRISMerchant is the merchant a QRIS payload pays
+type QRISMerchant struct {
+	Name           string `json:"name"` // Max 25 characters
+	City           string `json:"city"` // Max 15 characters
+	PostalCode     string `json:"postal_code,omitempty"`
+	CategoryCode   string `json:"category_code"`             // ISO 18245 MCC
+	NMID           string `json:"nmid"`                      // National merchant ID (template 51)
+	Criteria       string `json:"criteria,omitempty"`        // UMI, UKE, UME, UBE or URE
+	AcquirerDomain string `json:"acquirer_domain,omitempty"` // Reverse domain of the acquirer (template 26)
+	PAN            string `json:"pan,omitempty"`             // Merchant PAN at the acquirer
+	MerchantID     string `json:"merchant_id,omitempty"`     // Merchant ID at the acquirer
+}
+
+// QRIS is a decoded Merchant-Presented QR payload. Dynamic payloads carry the
+// amount and are valid for one payment; static ones leave the amount to the payer.
+type QRIS struct {
+	Dynamic        bool         `json:"dynamic"`
+	Merchant       QRISMerchant `json:"merchant"`
+	Currency       string       `json:"currency"`
+	CountryCode    string       `json:"country_code"`
+	Amount         string       `json:"amount,omitempty"`          // IDR
+	BillNumber     string       `json:"bill_number,omitempty"`     // Topup order reference for our payloads
+	ReferenceLabel string       `json:"reference_label,omitempty"` // Provider reference for our payloads
+	TerminalLabel  string       `json:"terminal_label,omitempty"`
+	CRC            string       `json:"crc,omitempty"`
+}
+
+// EncodeQRIS builds the QR string of a payload, checksum included
+func EncodeQRIS(q *QRIS) (string, error) {
+	if err := q.validate(); err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	initiation := qrisInitiationStatic
+	if q.Dynamic {
+		initiation = qrisInitiationDynamic
+	}
+	writeTLV(&b, qrisPayloadFormat, "01")
+	writeTLV(&b, qrisInitiation, initiation)
+
+	m := q.Merchant
+	if m.AcquirerDomain != "" {
+		writeTLV(&b, qrisMerchantFirst, tlvString(
+			qrisSubDomain, m.AcquirerDomain,
+			qrisSubPAN, m.PAN,
+			qrisSubMerchantID, m.MerchantID,
+			qrisSubCriteria, m.Criteria,
+		))
+	}
+	writeTLV(&b, qrisNational, tlvString(
+		qrisSubDomain, QRISDomain,
+		qrisSubMerchantID, m.NMID,
+		qrisSubCriteria, m.Criteria,
+	))
+	writeTLV(&b, qrisCategoryCode, m.CategoryCode)
+	writeTLV(&b, qrisCurrency, QRISCurrencyIDR)
+	writeTLV(&b, qrisAmount, q.Amount)
+	writeTLV(&b, qrisCountryCode, QRISCountryCode)
+	writeTLV(&b, qrisMerchantName, m.Name)
+	writeTLV(&b, qrisMerchantCity, m.City)
+	writeTLV(&b, qrisPostalCode, m.PostalCode)
+	writeTLV(&b, qrisAdditionalData, tlvString(
+		qrisSubBillNumber, q.BillNumber,
+		qrisSubReferenceLabel, q.ReferenceLabel,
+		qrisSubTerminalLabel, q.TerminalLabel,
+	))
+
+	b.WriteString(qrisCRCTagLength)
+	payload := b.String()
+	return payload + fmt.Sprintf("%04X", crc16CCITT(payload)), nil
+}
+
+// ParseQRIS decodes a scanned QR string and checks its checksum and layout
+func ParseQRIS(payload string) (*QRIS, error) {
+	payload = strings.TrimSpace(payload)
+	if len(payload) < len(qrisCRCTagLength)+4 || payload[len(payload)-8:len(payload)-4] != qrisCRCTagLength {
+		return nil, fmt.Errorf("%w: no CRC field at the end", ErrInvalidQRIS)
+	}
+	crc := strings.ToUpper(payload[len(payload)-4:])
+	if crc != fmt.Sprintf("%04X", crc16CCITT(payload[:len(payload)-4])) {
+		return nil, ErrQRISChecksum
+	}
+
+	fields, err := parseTLV(payload)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(fields[qrisCRC], crc) {
+		return nil, fmt.Errorf("%w: CRC must be the last field", ErrInvalidQRIS)
+	}
+	if fields[qrisPayloadFormat] != "01" {
+		return nil, fmt.Errorf("%w: unknown payload format", ErrInvalidQRIS)
+	}
+
+	q := &QRIS{
+		Currency:    fields[qrisCurrency],
+		CountryCode: fields[qrisCountryCode],
+		Amount:      fields[qrisAmount],
+		CRC:         crc,
+		Merchant: QRISMerchant{
+			Name:         fields[qrisMerchantName],
+			City:         fields[qrisMerchantCity],
+			PostalCode:   fields[qrisPostalCode],
+			CategoryCode: fields[qrisCategoryCode],
+		},
+	}
+
+	switch fields[qrisInitiation] {
+	case "", qrisInitiationStatic:
+	case qrisInitiationDynamic:
+		q.Dynamic = true
+	default:
+		return nil, fmt.Errorf("%w: unknown point of initiation", ErrInvalidQRIS)
+	}
+
+	if national, ok := fields[qrisNational]; ok {
+		sub, err := parseTLV(national)
+		if err != nil {
+			return nil, err
+		}
+		q.Merchant.NMID = sub[qrisSubMerchantID]
+		q.Merchant.Criteria = sub[qrisSubCriteria]
+	}
+	// The first merchant account template is the acquirer the payment goes through
+	for id := qrisMerchantFirst; id <= qrisMerchantLast; id = nextTag(id) {
+		account, ok := fields[id]
+		if !ok {
+			continue
+		}
+		sub, err := parseTLV(account)
+		if err != nil {
+			return nil, err
+		}
+		q.Merchant.AcquirerDomain = sub[qrisSubDomain]
+		q.Merchant.PAN = sub[qrisSubPAN]
+		q.Merchant.MerchantID = sub[qrisSubMerchantID]
+		if q.Merchant.Criteria == "" {
+			q.Merchant.Criteria = sub[qrisSubCriteria]
+		}
+		break
+	}
+	if data, ok := fields[qrisAdditionalData]; ok {
+		sub, err := parseTLV(data)
+		if err != nil {
+			return nil, err
+		}
+		q.BillNumber = sub[qrisSubBillNumber]
+		q.ReferenceLabel = sub[qrisSubReferenceLabel]
+		q.TerminalLabel = sub[qrisSubTerminalLabel]
+	}
+
+	if q.Currency != QRISCurrencyIDR {
+		return nil, fmt.Errorf("%w: currency must be IDR (360)", ErrInvalidQRIS)
+	}
+	if q.Merchant.Name == "" || q.Merchant.City == "" || q.Merchant.CategoryCode == "" {
+		return nil, fmt.Errorf("%w: merchant name, city and category are required", ErrInvalidQRIS)
+	}
+	if q.Amount != "" && !validQRISAmount(q.Amount) {
+		return nil, fmt.Errorf("%w: invalid amount", ErrInvalidQRIS)
+	}
+	return q, nil
+}
+
+// AmountIDR returns the amount of a payload as whole rupiah
+func (q *QRIS) AmountIDR() (*big.Int, error) {
+	if q.Amount == "" {
+		return nil, errors.New("QRIS has no amount")
+	}
+	whole, fraction, _ := strings.Cut(q.Amount, ".")
+	if strings.Trim(fraction, "0") != "" {
+		return nil, errors.New("QRIS amount has a fraction of a rupiah")
+	}
+	amount, ok := new(big.Int).SetString(whole, 10)
+	if !ok {
+		return nil, errors.New("invalid QRIS amount")
+	}
+	return amount, nil
+}
+
+func (q *QRIS) validate() error {
+	m := q.Merchant
+	switch {
+	case m.Name == "" || len(m.Name) > 25:
+		return fmt.Errorf("%w: merchant name must be 1-25 characters", ErrInvalidQRIS)
+	case m.City == "" || len(m.City) > 15:
+		return fmt.Errorf("%w: merchant city must be 1-15 characters", ErrInvalidQRIS)
+	case len(m.PostalCode) > 10:
+		return fmt.Errorf("%w: postal code is longer than 10 characters", ErrInvalidQRIS)
+	case len(m.CategoryCode) != 4 || !allDigits(m.CategoryCode):
+		return fmt.Errorf("%w: merchant category code must be 4 digits", ErrInvalidQRIS)
+	case m.NMID == "":
+		return fmt.Errorf("%w: NMID is required", ErrInvalidQRIS)
+	case q.Dynamic && q.Amount == "":
+		return fmt.Errorf("%w: dynamic QRIS needs an amount", ErrInvalidQRIS)
+	case !q.Dynamic && q.Amount != "":
+		return fmt.Errorf("%w: static QRIS cannot carry an amount", ErrInvalidQRIS)
+	case q.Amount != "" && !validQRISAmount(q.Amount):
+		return fmt.Errorf("%w: invalid amount", ErrInvalidQRIS)
+	case len(q.BillNumber) > 25 || len(q.ReferenceLabel) > 25 || len(q.TerminalLabel) > 25:
+		return fmt.Errorf("%w: additional data fields are limited to 25 characters", ErrInvalidQRIS)
+	}
+
+	for _, value := range []string{m.Name, m.City, m.PostalCode, m.NMID, m.Criteria, m.AcquirerDomain, m.PAN, m.MerchantID, q.BillNumber, q.ReferenceLabel, q.TerminalLabel} {
+		if !printableASCII(value) {
+			return fmt.Errorf("%w: fields must be printable ASCII", ErrInvalidQRIS)
+		}
+	}
+	return nil
+}
+
+// parseTLV splits a payload or template into its fields. IDs must be unique.
+func parseTLV(data string) (map[string]string, error) {
+	fields := make(map[string]string)
+	for i := 0; i < len(data); {
+		if i+4 > len(data) {
+			return nil, fmt.Errorf("%w: truncated field at %d", ErrInvalidQRIS, i)
+		}
+		id := data[i : i+2]
+		length, err := strconv.Atoi(data[i+2 : i+4])
+		if err != nil || !allDigits(id) || length <= 0 || i+4+length > len(data) {
+			return nil, fmt.Errorf("%w: malformed field at %d", ErrInvalidQRIS, i)
+		}
+		if _, dup := fields[id]; dup {
+			return nil, fmt.Errorf("%w: field %s appears twice", ErrInvalidQRIS, id)
+		}
+		fields[id] = data[i+4 : i+4+length]
+		i += 4 + length
+	}
+	return fields, nil
+}
+
+// writeTLV appends one field; empty values are left out
+func writeTLV(b *strings.Builder, id, value string) {
+	if value == "" {
+		return
+	}
+	fmt.Fprintf(b, "%s%02d%s", id, len(value), value)
+}
+
+// tlvString encodes id/value pairs of a template
+func tlvString(pairs ...string) string {
+	var b strings.Builder
+	for i := 0; i+1 < len(pairs); i += 2 {
+		writeTLV(&b, pairs[i], pairs[i+1])
+	}
+	return b.String()
+}
+
+// crc16CCITT is CRC-16/CCITT-FALSE (poly 0x1021, init 0xFFFF), as EMVCo requires
+func crc16CCITT(data string) uint16 {
+	crc := uint16(0xFFFF)
+	for i := 0; i < len(data); i++ {
+		crc ^= uint16(data[i]) << 8
+		for bit := 0; bit < 8; bit++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
+// validQRISAmount accepts up to 13 characters of digits with an optional
+// decimal point and at most two decimals
+func validQRISAmount(amount string) bool {
+	if len(amount) > 13 {
+		return false
+	}
+	whole, fraction, hasPoint := strings.Cut(amount, ".")
+	if whole == "" || !allDigits(whole) || (hasPoint && (fraction == "" || len(fraction) > 2 || !allDigits(fraction))) {
+		return false
+	}
+	return strings.Trim(amount, "0.") != ""
+}
+
+func nextTag(id string) string {
+	n, _ := strconv.Atoi(id)
+	return fmt.Sprintf("%02d", n+1)
+}
+
+func allDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func printableASCII(s string) bool {
+	for _, r := range s {
+		if r < 0x20 || r > 0x7e {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,182 @@
+package payment
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCRC16CCITT(t *testing.T) {
+	tests := []struct {
+		data string
+		want uint16
+	}{
+		{"", 0xFFFF},
+		{"123456789", 0x29B1}, // Check value of CRC-16/CCITT-FALSE
+		{"A", 0xB915},
+	}
+	for _, tt := range tests {
+		if got := crc16CCITT(tt.data); got != tt.want {
+			t.Errorf("crc16CCITT(%q) = %04X, want %04X", tt.data, got, tt.want)
+		}
+	}
+}
+
+func TestParseTLV(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    map[string]string
+		wantErr bool
+	}{
+		{name: "empty", data: "", want: map[string]string{}},
+		{name: "single field", data: "000201", want: map[string]string{"00": "01"}},
+		{name: "several fields", data: "00020101021253033605802ID", want: map[string]string{"00": "01", "01": "12", "53": "360", "58": "ID"}},
+		{name: "truncated header", data: "00020", wantErr: true},
+		{name: "value shorter than length", data: "0005abc", wantErr: true},
+		{name: "non-numeric length", data: "00xxab", wantErr: true},
+		{name: "non-numeric id", data: "a002ab", wantErr: true},
+		{name: "zero length", data: "0000", wantErr: true},
+		{name: "duplicate id", data: "000201000202", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseTLV(tt.data)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidQRIS) {
+					t.Fatalf("parseTLV(%q) error = %v, want ErrInvalidQRIS", tt.data, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseTLV(%q) error = %v", tt.data, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseTLV(%q) = %v, want %v", tt.data, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteTLVSkipsEmptyValues(t *testing.T) {
+	if got := tlvString("00", "ID.CO.QRIS.WWW", "01", "", "02", "ID123"); got != "0014ID.CO.QRIS.WWW0205ID123" {
+		t.Errorf("tlvString = %q", got)
+	}
+}
+
+func testQRIS() *QRIS {
+	return &QRIS{
+		Dynamic: true,
+		Merchant: QRISMerchant{
+			Name:           "TELKOM COIN",
+			City:           "JAKARTA",
+			PostalCode:     "12345",
+			CategoryCode:   "6012",
+			NMID:           "ID1234567890123",
+			Criteria:       "UMI",
+			AcquirerDomain: "ID.CO.EXAMPLE",
+			PAN:            "9360000000000000001",
+			MerchantID:     "M0001",
+		},
+		Amount:         "150000",
+		BillNumber:     "TOPUP-1",
+		ReferenceLabel: "REF-1",
+	}
+}
+
+func TestQRISRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(q *QRIS)
+	}{
+		{name: "dynamic", modify: func(q *QRIS) {}},
+		{name: "static", modify: func(q *QRIS) { q.Dynamic = false; q.Amount = "" }},
+		{name: "without acquirer", modify: func(q *QRIS) {
+			q.Merchant.AcquirerDomain, q.Merchant.PAN, q.Merchant.MerchantID = "", "", ""
+		}},
+		{name: "amount with decimals", modify: func(q *QRIS) { q.Amount = "150000.50" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := testQRIS()
+			tt.modify(q)
+
+			payload, err := EncodeQRIS(q)
+			if err != nil {
+				t.Fatalf("EncodeQRIS error = %v", err)
+			}
+			got, err := ParseQRIS(payload)
+			if err != nil {
+				t.Fatalf("ParseQRIS(%q) error = %v", payload, err)
+			}
+
+			want := *q
+			want.Currency = QRISCurrencyIDR
+			want.CountryCode = QRISCountryCode
+			want.CRC = payload[len(payload)-4:]
+			if !reflect.DeepEqual(*got, want) {
+				t.Errorf("ParseQRIS = %+v, want %+v", *got, want)
+			}
+		})
+	}
+}
+
+func TestParseQRISRejects(t *testing.T) {
+	payload, err := EncodeQRIS(testQRIS())
+	if err != nil {
+		t.Fatalf("EncodeQRIS error = %v", err)
+	}
+	body := payload[:len(payload)-4]
+
+	tests := []struct {
+		name    string
+		payload string
+		wantErr error
+	}{
+		{name: "empty", payload: "", wantErr: ErrInvalidQRIS},
+		{name: "no CRC field", payload: body[:len(body)-4], wantErr: ErrInvalidQRIS},
+		{name: "wrong checksum", payload: body + "0000", wantErr: ErrQRISChecksum},
+		{name: "changed amount", payload: strings.Replace(payload, "150000", "950000", 1), wantErr: ErrQRISChecksum},
+		{name: "lowercase checksum", payload: body + strings.ToLower(payload[len(payload)-4:])},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseQRIS(tt.payload)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("ParseQRIS error = %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ParseQRIS error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEncodeQRISValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(q *QRIS)
+	}{
+		{name: "dynamic without amount", modify: func(q *QRIS) { q.Amount = "" }},
+		{name: "static with amount", modify: func(q *QRIS) { q.Dynamic = false }},
+		{name: "long merchant name", modify: func(q *QRIS) { q.Merchant.Name = strings.Repeat("A", 26) }},
+		{name: "category code not digits", modify: func(q *QRIS) { q.Merchant.CategoryCode = "60A2" }},
+		{name: "missing NMID", modify: func(q *QRIS) { q.Merchant.NMID = "" }},
+		{name: "three decimals", modify: func(q *QRIS) { q.Amount = "1.005" }},
+		{name: "zero amount", modify: func(q *QRIS) { q.Amount = "0.00" }},
+		{name: "non-ASCII bill number", modify: func(q *QRIS) { q.BillNumber = "TOPUP-é" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := testQRIS()
+			tt.modify(q)
+			if _, err := EncodeQRIS(q); !errors.Is(err, ErrInvalidQRIS) {
+				t.Errorf("EncodeQRIS error = %v, want ErrInvalidQRIS", err)
+			}
+		})
+	}
+}
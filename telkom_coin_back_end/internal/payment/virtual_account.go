@@ -0,0 +1,104 @@
+package payment
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Virtual account numbers are <bank prefix (4)><user ID (11)><check digit (1)>.
+// The check digit is the Luhn digit of the first 15 digits, so a mistyped
+// number is caught before it is matched to a user.
+const (
+	vaPrefixLength  = 4
+	vaAccountLength = 11
+	VANumberLength  = vaPrefixLength + vaAccountLength + 1
+)
+
+// VirtualAccountBanks maps each bank that issues our virtual accounts to its prefix
+var VirtualAccountBanks = map[string]string{
+	"BCA":     "3901",
+	"BNI":     "8808",
+	"BRI":     "2626",
+	"MANDIRI": "8960",
+}
+
+var ErrInvalidVANumber = errors.New("invalid virtual account number")
+
+// CreditNotification is a verified incoming transfer to a virtual account
+type CreditNotification struct {
+	TransactionID string    `json:"transaction_id"` // Bank transfer ID, unique per provider
+	Bank          string    `json:"bank"`
+	VANumber      string    `json:"va_number"`
+	Amount        string    `json:"amount"` // IDR
+	PaidAt        time.Time `json:"paid_at"`
+}
+
+// VirtualAccountBankNames returns the issuing banks in a stable order
+func VirtualAccountBankNames() []string {
+	banks := make([]string, 0, len(VirtualAccountBanks))
+	for bank := range VirtualAccountBanks {
+		banks = append(banks, bank)
+	}
+	sort.Strings(banks)
+	return banks
+}
+
+// NewVANumber returns the virtual account number of a user at a bank. The
+// same user and bank always give the same number.
+func NewVANumber(bank string, userID int64) (string, error) {
+	prefix, ok := VirtualAccountBanks[strings.ToUpper(bank)]
+	if !ok {
+		return "", fmt.Errorf("bank %s does not issue virtual accounts", bank)
+	}
+	if userID <= 0 || len(strconv.FormatInt(userID, 10)) > vaAccountLength {
+		return "", fmt.Errorf("user ID %d does not fit a virtual account number", userID)
+	}
+
+	body := fmt.Sprintf("%s%0*d", prefix, vaAccountLength, userID)
+	return body + string(luhnCheckDigit(body)), nil
+}
+
+// ValidateVANumber checks the length, check digit and bank prefix of a
+// number and returns the bank it belongs to
+func ValidateVANumber(number string) (string, error) {
+	if len(number) != VANumberLength {
+		return "", ErrInvalidVANumber
+	}
+	for _, r := range number {
+		if r < '0' || r > '9' {
+			return "", ErrInvalidVANumber
+		}
+	}
+	if luhnCheckDigit(number[:VANumberLength-1]) != number[VANumberLength-1] {
+		return "", ErrInvalidVANumber
+	}
+
+	for bank, prefix := range VirtualAccountBanks {
+		if number[:vaPrefixLength] == prefix {
+			return bank, nil
+		}
+	}
+	return "", ErrInvalidVANumber
+}
+
+// luhnCheckDigit returns the digit that makes digits+check pass the Luhn check
+func luhnCheckDigit(digits string) byte {
+	sum := 0
+	double := true // The check digit is appended on the right
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return byte('0' + (10-sum%10)%10)
+}
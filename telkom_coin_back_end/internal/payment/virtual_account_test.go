@@ -0,0 +1,102 @@
+package payment
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestLuhnCheckDigit(t *testing.T) {
+	tests := []struct {
+		digits string
+		want   byte
+	}{
+		{"7992739871", '3'}, // Standard Luhn example
+		{"0", '0'},
+		{"1", '8'},
+		{"", '0'},
+		{"390100000000001", '2'},
+	}
+	for _, tt := range tests {
+		if got := luhnCheckDigit(tt.digits); got != tt.want {
+			t.Errorf("luhnCheckDigit(%q) = %c, want %c", tt.digits, got, tt.want)
+		}
+	}
+}
+
+func TestNewVANumber(t *testing.T) {
+	tests := []struct {
+		name    string
+		bank    string
+		userID  int64
+		want    string
+		wantErr bool
+	}{
+		{name: "BCA", bank: "BCA", userID: 1, want: "3901000000000012"},
+		{name: "lowercase bank", bank: "bca", userID: 1, want: "3901000000000012"},
+		{name: "largest user ID", bank: "BNI", userID: 99999999999},
+		{name: "unknown bank", bank: "XYZ", userID: 1, wantErr: true},
+		{name: "zero user ID", bank: "BRI", userID: 0, wantErr: true},
+		{name: "user ID too long", bank: "BRI", userID: 100000000000, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewVANumber(tt.bank, tt.userID)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("NewVANumber(%q, %d) = %q, want error", tt.bank, tt.userID, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewVANumber(%q, %d) error = %v", tt.bank, tt.userID, err)
+			}
+			if tt.want != "" && got != tt.want {
+				t.Errorf("NewVANumber(%q, %d) = %q, want %q", tt.bank, tt.userID, got, tt.want)
+			}
+			bank, err := ValidateVANumber(got)
+			if err != nil || bank != strings.ToUpper(tt.bank) {
+				t.Errorf("ValidateVANumber(%q) = %q, %v, want %q", got, bank, err, strings.ToUpper(tt.bank))
+			}
+		})
+	}
+}
+
+func TestValidateVANumber(t *testing.T) {
+	tests := []struct {
+		name     string
+		number   string
+		wantBank string
+	}{
+		{name: "valid", number: "3901000000000012", wantBank: "BCA"},
+		{name: "wrong check digit", number: "3901000000000013"},
+		{name: "swapped digits", number: "3901000000000102"},
+		{name: "too short", number: "390100000000012"},
+		{name: "too long", number: "39010000000000120"},
+		{name: "not digits", number: "39010000000000A2"},
+		{name: "unknown prefix with valid check digit", number: "1234000000000014"},
+		{name: "mock charge prefix", number: mockVAPrefix + "00000000001" + string(luhnCheckDigit(mockVAPrefix+"00000000001"))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bank, err := ValidateVANumber(tt.number)
+			if tt.wantBank == "" {
+				if !errors.Is(err, ErrInvalidVANumber) {
+					t.Errorf("ValidateVANumber(%q) = %q, %v, want ErrInvalidVANumber", tt.number, bank, err)
+				}
+				return
+			}
+			if err != nil || bank != tt.wantBank {
+				t.Errorf("ValidateVANumber(%q) = %q, %v, want %q", tt.number, bank, err, tt.wantBank)
+			}
+		})
+	}
+}
+
+func TestMockVAPrefixIsNotABankPrefix(t *testing.T) {
+	for bank, prefix := range VirtualAccountBanks {
+		if prefix == mockVAPrefix {
+			t.Errorf("mock VA prefix %s is also the prefix of %s", mockVAPrefix, bank)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"telkom_coin_back_end/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// BalanceReconciliationRepositoryInterface defines the contract for balance reconciliation repository
+type BalanceReconciliationRepositoryInterface interface {
+	CreateReport(report *models.BalanceReconciliationReport) error
+	UpdateReport(report *models.BalanceReconciliationReport) error
+	GetLatestReport() (*models.BalanceReconciliationReport, error)
+	AddMismatch(mismatch *models.BalanceMismatch) error
+	GetMismatches(reportID int64) ([]models.BalanceMismatch, error)
+}
+
+type BalanceReconciliationRepository struct {
+	db *gorm.DB
+}
+
+func NewBalanceReconciliationRepository(db *gorm.DB) *BalanceReconciliationRepository {
+	return &BalanceReconciliationRepository{db: db}
+}
+
+// Create new report
+func (r *BalanceReconciliationRepository) CreateReport(report *models.BalanceReconciliationReport) error {
+	return r.db.Create(report).Error
+}
+
+// Update report
+func (r *BalanceReconciliationRepository) UpdateReport(report *models.BalanceReconciliationReport) error {
+	return r.db.Save(report).Error
+}
+
+// Get the most recent finished report
+func (r *BalanceReconciliationRepository) GetLatestReport() (*models.BalanceReconciliationReport, error) {
+	var report models.BalanceReconciliationReport
+	err := r.db.Where("status <> ?", models.ReconciliationStatusRunning).
+		Order("started_at DESC").
+		First(&report).Error
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// Add mismatch to a report
+func (r *BalanceReconciliationRepository) AddMismatch(mismatch *models.BalanceMismatch) error {
+	return r.db.Create(mismatch).Error
+}
+
+// Get mismatches of a report
+func (r *BalanceReconciliationRepository) GetMismatches(reportID int64) ([]models.BalanceMismatch, error) {
+	var mismatches []models.BalanceMismatch
+	err := r.db.Where("report_id = ?", reportID).
+		Order("id ASC").
+		Find(&mismatches).Error
+	return mismatches, err
+}
@@ -6,6 +6,7 @@ import (
 	"telkom_coin_back_end/internal/models"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // BalanceRepositoryInterface defines the contract for balance repository
@@ -22,6 +23,12 @@ type BalanceRepositoryInterface interface {
 	DeductLockedBalance(userID int64, amount string) error
 	GetAvailableBalance(userID int64) (string, error)
 	UpdateWithTx(tx *gorm.DB, balance *models.Balance) error
+	LockBalanceWithin(userID int64, amount, spendable string) error
+	WithTx(tx *gorm.DB) *BalanceRepository
+	GetAll(limit, offset int) ([]models.Balance, error)
+	SetReconciledBalance(userID int64, expected, newBalance string, block int64) (bool, error)
+	FlagForReview(userID int64, block int64) error
+	MarkSynced(userID int64, block int64) error
 }
 
 type BalanceRepository struct {
@@ -32,6 +39,11 @@ func NewBalanceRepository(db *gorm.DB) *BalanceRepository {
 	return &BalanceRepository{db: db}
 }
 
+// WithTx returns a repository that runs inside the given DB transaction
+func (r *BalanceRepository) WithTx(tx *gorm.DB) *BalanceRepository {
+	return &BalanceRepository{db: tx}
+}
+
 // Create new balance
 func (r *BalanceRepository) Create(balance *models.Balance) error {
 	return r.db.Create(balance).Error
@@ -149,6 +161,36 @@ func (r *BalanceRepository) LockBalance(userID int64, amount string) error {
 		Update("locked_balance", newLockedBalance.String()).Error
 }
 
+// Lock balance against an outside spendable amount (e.g. the on-chain balance),
+// so the sum of all locks never exceeds it. Row is locked for the DB transaction.
+func (r *BalanceRepository) LockBalanceWithin(userID int64, amount, spendable string) error {
+	var balance models.Balance
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("user_id = ?", userID).
+		First(&balance).Error
+	if err != nil {
+		return err
+	}
+
+	lockedBalance := new(big.Int)
+	lockedBalance.SetString(balance.LockedBalance, 10)
+
+	lockAmount := new(big.Int)
+	lockAmount.SetString(amount, 10)
+
+	spendableAmount := new(big.Int)
+	spendableAmount.SetString(spendable, 10)
+
+	newLockedBalance := new(big.Int).Add(lockedBalance, lockAmount)
+	if newLockedBalance.Cmp(spendableAmount) > 0 {
+		return errors.New("insufficient available balance")
+	}
+
+	return r.db.Model(&models.Balance{}).
+		Where("user_id = ?", userID).
+		Update("locked_balance", newLockedBalance.String()).Error
+}
+
 // Unlock balance (transaction confirmed/failed)
 func (r *BalanceRepository) UnlockBalance(userID int64, amount string) error {
 	balance, err := r.GetByUserID(userID)
@@ -204,6 +246,49 @@ func (r *BalanceRepository) GetAvailableBalance(userID int64) (string, error) {
 	return available.String(), nil
 }
 
+// Get all balances, ordered by user
+func (r *BalanceRepository) GetAll(limit, offset int) ([]models.Balance, error) {
+	var balances []models.Balance
+	err := r.db.Order("user_id ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&balances).Error
+	return balances, err
+}
+
+// Overwrite the balance with the on-chain value, only if it still holds the value
+// that was compared (false means it changed in the meantime)
+func (r *BalanceRepository) SetReconciledBalance(userID int64, expected, newBalance string, block int64) (bool, error) {
+	result := r.db.Model(&models.Balance{}).
+		Where("user_id = ? AND balance = ?", userID, expected).
+		Updates(map[string]interface{}{
+			"balance":         newBalance,
+			"last_sync_block": block,
+			"needs_review":    false,
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+// Flag a balance for manual review
+func (r *BalanceRepository) FlagForReview(userID int64, block int64) error {
+	return r.db.Model(&models.Balance{}).
+		Where("user_id = ?", userID).
+		Updates(map[string]interface{}{
+			"needs_review":    true,
+			"last_sync_block": block,
+		}).Error
+}
+
+// Mark a balance as matching the chain at block
+func (r *BalanceRepository) MarkSynced(userID int64, block int64) error {
+	return r.db.Model(&models.Balance{}).
+		Where("user_id = ?", userID).
+		Updates(map[string]interface{}{
+			"last_sync_block": block,
+			"needs_review":    false,
+		}).Error
+}
+
 func (r *BalanceRepository) UpdateWithTx(tx *gorm.DB, balance *models.Balance) error {
 	return tx.Save(balance).Error
 }
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"telkom_coin_back_end/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// BankStatementRepositoryInterface defines the contract for bank statement repository
+type BankStatementRepositoryInterface interface {
+	CreateReport(report *models.BankStatementReport, items []models.BankStatementItem) error
+	GetReportByID(id int64) (*models.BankStatementReport, error)
+	GetReportBySHA256(fileSHA256 string) (*models.BankStatementReport, error)
+	GetReports(page, limit int) ([]models.BankStatementReport, int64, error)
+	GetItems(reportID int64) ([]models.BankStatementItem, error)
+}
+
+type BankStatementRepository struct {
+	db *gorm.DB
+}
+
+func NewBankStatementRepository(db *gorm.DB) *BankStatementRepository {
+	return &BankStatementRepository{db: db}
+}
+
+// Create report with its items in one transaction
+func (r *BankStatementRepository) CreateReport(report *models.BankStatementReport, items []models.BankStatementItem) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(report).Error; err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			return nil
+		}
+		for i := range items {
+			items[i].ReportID = report.ID
+		}
+		return tx.CreateInBatches(items, 200).Error
+	})
+}
+
+// Get report by the SHA-256 of its file
+func (r *BankStatementRepository) GetReportBySHA256(fileSHA256 string) (*models.BankStatementReport, error) {
+	var report models.BankStatementReport
+	err := r.db.Where("file_sha256 = ?", fileSHA256).First(&report).Error
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// Get report by ID
+func (r *BankStatementRepository) GetReportByID(id int64) (*models.BankStatementReport, error) {
+	var report models.BankStatementReport
+	err := r.db.First(&report, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// Get reports, newest first
+func (r *BankStatementRepository) GetReports(page, limit int) ([]models.BankStatementReport, int64, error) {
+	query := r.db.Model(&models.BankStatementReport{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var reports []models.BankStatementReport
+	err := query.Order("created_at DESC, id DESC").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&reports).Error
+	return reports, total, err
+}
+
+// Get items of a report in statement order
+func (r *BankStatementRepository) GetItems(reportID int64) ([]models.BankStatementItem, error) {
+	var items []models.BankStatementItem
+	err := r.db.Where("report_id = ?", reportID).
+		Order("id ASC").
+		Find(&items).Error
+	return items, err
+}
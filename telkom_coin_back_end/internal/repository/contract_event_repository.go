@@ -0,0 +1,269 @@
+package repository
+
+import (
+	"errors"
+	"math/big"
+	"telkom_coin_back_end/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ContractEventFilter narrows down indexed events for explorer queries
+type ContractEventFilter struct {
+	FromBlock *uint64
+	ToBlock   *uint64
+	Address   string
+	TxType    string
+}
+
+// ContractEventRepositoryInterface defines the contract for contract event repository
+type ContractEventRepositoryInterface interface {
+	ForContract(chainID int64, contractAddress string) *ContractEventRepository
+	SaveBatch(events []models.ContractEvent, checkpointName string, lastBlock uint64, lastHash string) error
+	GetCheckpoint(name string) (*models.IndexerCheckpoint, error)
+	GetBlockHashes(maxBlock uint64, limit int) ([]IndexedBlock, error)
+	Rewind(checkpointName string, lastBlock uint64, lastHash string) error
+	FindPrimary(filter ContractEventFilter, limit, offset int) ([]models.ContractEvent, int64, error)
+	GetPrimaryByHash(hash string) (*models.ContractEvent, error)
+	GetByHash(hash string) ([]models.ContractEvent, error)
+	CountPrimaryByType() (map[string]int64, error)
+	SumAmountsByEvent(eventName string) (*big.Int, error)
+	SumTransferAmounts(address string, incoming bool) (*big.Int, error)
+	CountUniqueAddresses() (int64, error)
+}
+
+// IndexedBlock is a block that has indexed events, with the hash it had when indexed
+type IndexedBlock struct {
+	BlockNumber uint64
+	BlockHash   string
+}
+
+// ContractEventRepository reads and writes the events of one deployment, see
+// ForContract; the repository from NewContractEventRepository has none and finds nothing
+type ContractEventRepository struct {
+	db              *gorm.DB
+	chainID         int64
+	contractAddress string
+}
+
+func NewContractEventRepository(db *gorm.DB) *ContractEventRepository {
+	return &ContractEventRepository{db: db}
+}
+
+// ForContract returns a repository for the events of the contract at
+// contractAddress on chain chainID
+func (r *ContractEventRepository) ForContract(chainID int64, contractAddress string) *ContractEventRepository {
+	return &ContractEventRepository{db: r.db, chainID: chainID, contractAddress: contractAddress}
+}
+
+// events starts a query on the events of the repository's deployment
+func (r *ContractEventRepository) events(db *gorm.DB) *gorm.DB {
+	return db.Model(&models.ContractEvent{}).
+		Where("chain_id = ? AND contract_address = ?", r.chainID, r.contractAddress)
+}
+
+// SaveBatch stores a block range worth of events and moves the checkpoint in one DB transaction
+func (r *ContractEventRepository) SaveBatch(events []models.ContractEvent, checkpointName string, lastBlock uint64, lastHash string) error {
+	for i := range events {
+		events[i].ChainID = r.chainID
+		events[i].ContractAddress = r.contractAddress
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if len(events) > 0 {
+			// Re-indexing a range must not duplicate rows
+			err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+				CreateInBatches(events, 100).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return saveCheckpoint(tx, checkpointName, lastBlock, lastHash)
+	})
+}
+
+// Rewind drops the deployment's events after lastBlock (orphaned by a reorg)
+// and moves the checkpoint back to lastBlock in one DB transaction
+func (r *ContractEventRepository) Rewind(checkpointName string, lastBlock uint64, lastHash string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("chain_id = ? AND contract_address = ? AND block_number > ?", r.chainID, r.contractAddress, lastBlock).
+			Delete(&models.ContractEvent{}).Error
+		if err != nil {
+			return err
+		}
+		return saveCheckpoint(tx, checkpointName, lastBlock, lastHash)
+	})
+}
+
+func saveCheckpoint(tx *gorm.DB, name string, lastBlock uint64, lastHash string) error {
+	checkpoint := models.IndexerCheckpoint{Name: name, LastIndexedBlock: lastBlock, LastIndexedHash: lastHash}
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "name"}},
+		DoUpdates: clause.AssignmentColumns([]string{"last_indexed_block", "last_indexed_hash", "updated_at"}),
+	}).Create(&checkpoint).Error
+}
+
+// Get the blocks up to maxBlock that have events, newest first
+func (r *ContractEventRepository) GetBlockHashes(maxBlock uint64, limit int) ([]IndexedBlock, error) {
+	var blocks []IndexedBlock
+	err := r.events(r.db).
+		Select("DISTINCT block_number, block_hash").
+		Where("block_number <= ?", maxBlock).
+		Order("block_number DESC").
+		Limit(limit).
+		Scan(&blocks).Error
+	return blocks, err
+}
+
+// Get checkpoint by name, nil if the indexer has never run
+func (r *ContractEventRepository) GetCheckpoint(name string) (*models.IndexerCheckpoint, error) {
+	var checkpoint models.IndexerCheckpoint
+	err := r.db.Where("name = ?", name).First(&checkpoint).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &checkpoint, nil
+}
+
+// Find primary events (one per transaction), newest first
+func (r *ContractEventRepository) FindPrimary(filter ContractEventFilter, limit, offset int) ([]models.ContractEvent, int64, error) {
+	var events []models.ContractEvent
+	var totalCount int64
+
+	query := r.events(r.db).Where("is_primary = ?", true)
+
+	if filter.FromBlock != nil {
+		query = query.Where("block_number >= ?", *filter.FromBlock)
+	}
+	if filter.ToBlock != nil {
+		query = query.Where("block_number <= ?", *filter.ToBlock)
+	}
+	if filter.Address != "" {
+		query = query.Where("from_address = ? OR to_address = ?", filter.Address, filter.Address)
+	}
+	if filter.TxType != "" {
+		query = query.Where("tx_type = ?", filter.TxType)
+	}
+
+	err := query.Count(&totalCount).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = query.Order("block_number DESC").
+		Order("log_index DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&events).Error
+
+	return events, totalCount, err
+}
+
+// Get primary event of a transaction
+func (r *ContractEventRepository) GetPrimaryByHash(hash string) (*models.ContractEvent, error) {
+	var event models.ContractEvent
+	err := r.events(r.db).Where("tx_hash = ? AND is_primary = ?", hash, true).First(&event).Error
+	if err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// Get all events of a transaction in log order
+func (r *ContractEventRepository) GetByHash(hash string) ([]models.ContractEvent, error) {
+	var events []models.ContractEvent
+	err := r.events(r.db).Where("tx_hash = ?", hash).
+		Order("log_index ASC").
+		Find(&events).Error
+	return events, err
+}
+
+// Count primary events grouped by transaction type
+func (r *ContractEventRepository) CountPrimaryByType() (map[string]int64, error) {
+	var rows []struct {
+		TxType string
+		Total  int64
+	}
+
+	err := r.events(r.db).
+		Select("tx_type, COUNT(*) AS total").
+		Where("is_primary = ?", true).
+		Group("tx_type").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64)
+	for _, row := range rows {
+		counts[row.TxType] = row.Total
+	}
+	return counts, nil
+}
+
+// Sum the wei amounts of every event with the given name
+func (r *ContractEventRepository) SumAmountsByEvent(eventName string) (*big.Int, error) {
+	return r.sumAmountWei(r.events(r.db).
+		Where("event_name = ?", eventName))
+}
+
+// Sum the wei amounts of ERC20 Transfer events received by (incoming) or sent from an address
+func (r *ContractEventRepository) SumTransferAmounts(address string, incoming bool) (*big.Int, error) {
+	column := "from_address"
+	if incoming {
+		column = "to_address"
+	}
+
+	return r.sumAmountWei(r.events(r.db).
+		Where("event_name = ?", "Transfer").
+		Where(column+" = ?", address))
+}
+
+// sumAmountWei sums amount_wei over a query. MySQL sums it in SQL as an exact
+// DECIMAL; SQLite has no exact type for 256-bit amounts, so there the rows are
+// added up here.
+func (r *ContractEventRepository) sumAmountWei(query *gorm.DB) (*big.Int, error) {
+	if r.db.Dialector.Name() == "mysql" {
+		var total string
+		err := query.Select("CAST(COALESCE(SUM(CAST(amount_wei AS DECIMAL(65,0))), 0) AS CHAR)").
+			Scan(&total).Error
+		if err != nil {
+			return nil, err
+		}
+		sum, ok := new(big.Int).SetString(total, 10)
+		if !ok {
+			return nil, errors.New("invalid amount sum " + total)
+		}
+		return sum, nil
+	}
+
+	var amounts []string
+	if err := query.Pluck("amount_wei", &amounts).Error; err != nil {
+		return nil, err
+	}
+	total := big.NewInt(0)
+	for _, amount := range amounts {
+		if value, ok := new(big.Int).SetString(amount, 10); ok {
+			total.Add(total, value)
+		}
+	}
+	return total, nil
+}
+
+// Count distinct non-zero addresses that appear in any event
+func (r *ContractEventRepository) CountUniqueAddresses() (int64, error) {
+	var count int64
+	zeroAddress := "0x0000000000000000000000000000000000000000"
+
+	err := r.db.Raw(`SELECT COUNT(*) FROM (
+		SELECT from_address AS address FROM contract_events WHERE chain_id = ? AND contract_address = ?
+		UNION
+		SELECT to_address AS address FROM contract_events WHERE chain_id = ? AND contract_address = ?
+	) addresses WHERE address <> ?`, r.chainID, r.contractAddress, r.chainID, r.contractAddress, zeroAddress).Scan(&count).Error
+	return count, err
+}
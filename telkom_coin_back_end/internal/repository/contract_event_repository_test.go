@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"telkom_coin_back_end/internal/models"
+	"testing"
+	"time"
+)
+
+const (
+	testTokenA = "0x00000000000000000000000000000000000000aA"
+	testTokenB = "0x00000000000000000000000000000000000000bB"
+)
+
+func mintedEvent(txHash string, block uint64, to, amountWei string) models.ContractEvent {
+	return models.ContractEvent{
+		TxHash:      txHash,
+		BlockNumber: block,
+		BlockHash:   "0xblock",
+		EventName:   "TokensMinted",
+		TxType:      "mint",
+		FromAddress: "0x0000000000000000000000000000000000000000",
+		ToAddress:   to,
+		Amount:      amountWei,
+		AmountWei:   amountWei,
+		IsPrimary:   true,
+		BlockTime:   time.Now(),
+	}
+}
+
+func TestContractEventsScopedToDeployment(t *testing.T) {
+	base := NewContractEventRepository(newTestDB(t, &models.ContractEvent{}, &models.IndexerCheckpoint{}))
+	deployments := []struct {
+		name   string
+		repo   *ContractEventRepository
+		holder string
+	}{
+		{"token A", base.ForContract(1337, testTokenA), "0x2"},
+		{"token B", base.ForContract(1337, testTokenB), "0x3"},
+		{"token A on another chain", base.ForContract(11155111, testTokenA), "0x4"},
+	}
+
+	// Every deployment has the same log at block 10 and its own at block 20
+	for _, deployment := range deployments {
+		events := []models.ContractEvent{
+			mintedEvent("0xshared", 10, "0x1", "100"),
+			mintedEvent("0xown"+deployment.name, 20, deployment.holder, "5"),
+		}
+		if err := deployment.repo.SaveBatch(events, deployment.name, 20, "0xblock"); err != nil {
+			t.Fatalf("SaveBatch %s: %v", deployment.name, err)
+		}
+	}
+
+	// A reorg on token A drops only its own events after block 10
+	if err := deployments[0].repo.Rewind(deployments[0].name, 10, "0xblock"); err != nil {
+		t.Fatalf("Rewind: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		repo      *ContractEventRepository
+		minted    int64
+		primary   int64
+		addresses int64
+	}{
+		{"token A", deployments[0].repo, 100, 1, 1},
+		{"token B", deployments[1].repo, 105, 2, 2},
+		{"token A on another chain", deployments[2].repo, 105, 2, 2},
+		{"no deployment", base, 0, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			minted, err := tt.repo.SumAmountsByEvent("TokensMinted")
+			if err != nil {
+				t.Fatalf("SumAmountsByEvent error = %v", err)
+			}
+			if minted.Int64() != tt.minted {
+				t.Errorf("SumAmountsByEvent = %s, want %d", minted, tt.minted)
+			}
+
+			counts, err := tt.repo.CountPrimaryByType()
+			if err != nil {
+				t.Fatalf("CountPrimaryByType error = %v", err)
+			}
+			if counts["mint"] != tt.primary {
+				t.Errorf("CountPrimaryByType[mint] = %d, want %d", counts["mint"], tt.primary)
+			}
+
+			events, total, err := tt.repo.FindPrimary(ContractEventFilter{}, 10, 0)
+			if err != nil {
+				t.Fatalf("FindPrimary error = %v", err)
+			}
+			if total != tt.primary || int64(len(events)) != tt.primary {
+				t.Errorf("FindPrimary = %d events of %d, want %d", len(events), total, tt.primary)
+			}
+
+			addresses, err := tt.repo.CountUniqueAddresses()
+			if err != nil {
+				t.Fatalf("CountUniqueAddresses error = %v", err)
+			}
+			if addresses != tt.addresses {
+				t.Errorf("CountUniqueAddresses = %d, want %d", addresses, tt.addresses)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"telkom_coin_back_end/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IdempotencyRepositoryInterface defines the contract for idempotency key repository
+type IdempotencyRepositoryInterface interface {
+	Reserve(record *models.IdempotencyKey) (bool, error)
+	GetByUserAndKey(userID int64, key string) (*models.IdempotencyKey, error)
+	Complete(id int64, responseCode int, responseBody string) error
+	Delete(id int64) error
+	DeleteExpired(now time.Time) (int64, error)
+}
+
+type IdempotencyRepository struct {
+	db *gorm.DB
+}
+
+func NewIdempotencyRepository(db *gorm.DB) *IdempotencyRepository {
+	return &IdempotencyRepository{db: db}
+}
+
+// Reserve inserts the key; false means the user already used it
+func (r *IdempotencyRepository) Reserve(record *models.IdempotencyKey) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(record)
+	return result.RowsAffected > 0, result.Error
+}
+
+// Get idempotency key by user and key
+func (r *IdempotencyRepository) GetByUserAndKey(userID int64, key string) (*models.IdempotencyKey, error) {
+	var record models.IdempotencyKey
+	err := r.db.Where(&models.IdempotencyKey{UserID: userID, Key: key}).First(&record).Error
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// Store the response of a finished request
+func (r *IdempotencyRepository) Complete(id int64, responseCode int, responseBody string) error {
+	return r.db.Model(&models.IdempotencyKey{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":        models.IdempotencyStatusCompleted,
+		"response_code": responseCode,
+		"response_body": responseBody,
+	}).Error
+}
+
+// Delete idempotency key
+func (r *IdempotencyRepository) Delete(id int64) error {
+	return r.db.Delete(&models.IdempotencyKey{}, id).Error
+}
+
+// Delete keys past their expiry
+func (r *IdempotencyRepository) DeleteExpired(now time.Time) (int64, error) {
+	result := r.db.Where("expires_at < ?", now).Delete(&models.IdempotencyKey{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"telkom_coin_back_end/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// LimitReservationRepositoryInterface defines the contract for limit reservation repository
+type LimitReservationRepositoryInterface interface {
+	Create(reservation *models.LimitReservation) error
+	Delete(id int64) error
+	DeleteExpired(now time.Time) (int64, error)
+	GetActive(userID int64, operation string, now time.Time) ([]models.LimitReservation, error)
+	LockUser(userID int64) error
+	Transaction(fn func(tx *gorm.DB) error) error
+	WithTx(tx *gorm.DB) *LimitReservationRepository
+}
+
+type LimitReservationRepository struct {
+	db *gorm.DB
+}
+
+func NewLimitReservationRepository(db *gorm.DB) *LimitReservationRepository {
+	return &LimitReservationRepository{db: db}
+}
+
+// WithTx returns a repository that runs inside the given DB transaction
+func (r *LimitReservationRepository) WithTx(tx *gorm.DB) *LimitReservationRepository {
+	return &LimitReservationRepository{db: tx}
+}
+
+// Transaction runs fn in one DB transaction, for writes spanning several repositories
+func (r *LimitReservationRepository) Transaction(fn func(tx *gorm.DB) error) error {
+	return r.db.Transaction(fn)
+}
+
+// Create new reservation
+func (r *LimitReservationRepository) Create(reservation *models.LimitReservation) error {
+	return r.db.Create(reservation).Error
+}
+
+// Delete reservation
+func (r *LimitReservationRepository) Delete(id int64) error {
+	return r.db.Delete(&models.LimitReservation{}, id).Error
+}
+
+// Delete reservations past their expiry
+func (r *LimitReservationRepository) DeleteExpired(now time.Time) (int64, error) {
+	result := r.db.Where("expires_at < ?", now).Delete(&models.LimitReservation{})
+	return result.RowsAffected, result.Error
+}
+
+// Get the user's reservations for an operation that did not expire yet
+func (r *LimitReservationRepository) GetActive(userID int64, operation string, now time.Time) ([]models.LimitReservation, error) {
+	var reservations []models.LimitReservation
+	err := r.db.Select("amount", "created_at").
+		Where("user_id = ? AND operation = ? AND expires_at >= ?", userID, operation, now).
+		Find(&reservations).Error
+	return reservations, err
+}
+
+// Lock the user's row for the DB transaction, so limit checks of one user run
+// one at a time across instances
+func (r *LimitReservationRepository) LockUser(userID int64) error {
+	var user models.User
+	return r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		Where("id = ?", userID).
+		First(&user).Error
+}
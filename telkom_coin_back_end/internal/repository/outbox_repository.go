@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"telkom_coin_back_end/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// OutboxRepositoryInterface defines the contract for chain outbox repository
+type OutboxRepositoryInterface interface {
+	Create(msg *models.ChainOutbox) error
+	GetByID(id int64) (*models.ChainOutbox, error)
+	GetByStatus(status string, limit int) ([]models.ChainOutbox, error)
+	GetDue(status string, now time.Time, limit int) ([]models.ChainOutbox, error)
+	MarkSigned(id int64, txHash, rawTx string) (bool, error)
+	RecordAttempt(id int64, lastError string, nextAttemptAt time.Time) error
+	Transition(id int64, from, to string, fields map[string]interface{}) (bool, error)
+	Transaction(fn func(tx *gorm.DB) error) error
+	WithTx(tx *gorm.DB) *OutboxRepository
+	HasInFlight(userID int64) (bool, error)
+	GetUnbroadcastSince(userID int64, action string, since time.Time) ([]models.ChainOutbox, error)
+	GetHeldRawTxs() ([]string, error)
+}
+
+type OutboxRepository struct {
+	db *gorm.DB
+}
+
+func NewOutboxRepository(db *gorm.DB) *OutboxRepository {
+	return &OutboxRepository{db: db}
+}
+
+// WithTx returns a repository that runs inside the given DB transaction
+func (r *OutboxRepository) WithTx(tx *gorm.DB) *OutboxRepository {
+	return &OutboxRepository{db: tx}
+}
+
+// Transaction runs fn in one DB transaction, for writes spanning several repositories
+func (r *OutboxRepository) Transaction(fn func(tx *gorm.DB) error) error {
+	return r.db.Transaction(fn)
+}
+
+// Create new outbox message
+func (r *OutboxRepository) Create(msg *models.ChainOutbox) error {
+	return r.db.Create(msg).Error
+}
+
+// Get outbox message by ID
+func (r *OutboxRepository) GetByID(id int64) (*models.ChainOutbox, error) {
+	var msg models.ChainOutbox
+	err := r.db.First(&msg, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &msg, nil
+}
+
+// Get outbox messages by status, oldest first
+func (r *OutboxRepository) GetByStatus(status string, limit int) ([]models.ChainOutbox, error) {
+	var messages []models.ChainOutbox
+	err := r.db.Where("status = ?", status).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&messages).Error
+	return messages, err
+}
+
+// Get outbox messages in a status whose next attempt is due, oldest first
+func (r *OutboxRepository) GetDue(status string, now time.Time, limit int) ([]models.ChainOutbox, error) {
+	var messages []models.ChainOutbox
+	err := r.db.Where("status = ?", status).
+		Where("next_attempt_at IS NULL OR next_attempt_at <= ?", now).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&messages).Error
+	return messages, err
+}
+
+// Store the hash and raw transaction of a pending message
+func (r *OutboxRepository) MarkSigned(id int64, txHash, rawTx string) (bool, error) {
+	return r.Transition(id, models.OutboxStatusPending, models.OutboxStatusSigned, map[string]interface{}{
+		"tx_hash": txHash,
+		"raw_tx":  rawTx,
+	})
+}
+
+// Count a failed broadcast attempt and hold the message back until nextAttemptAt
+func (r *OutboxRepository) RecordAttempt(id int64, lastError string, nextAttemptAt time.Time) error {
+	return r.db.Model(&models.ChainOutbox{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"attempts":        gorm.Expr("attempts + 1"),
+		"last_error":      lastError,
+		"next_attempt_at": nextAttemptAt,
+	}).Error
+}
+
+// Transition moves a message from one status to another; false means another
+// worker already moved it
+func (r *OutboxRepository) Transition(id int64, from, to string, fields map[string]interface{}) (bool, error) {
+	return transition(r.db, &models.ChainOutbox{}, id, from, to, fields)
+}
+
+// Check if the user has a message that is not final yet
+func (r *OutboxRepository) HasInFlight(userID int64) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.ChainOutbox{}).
+		Where("user_id = ? AND status IN ?", userID, []string{models.OutboxStatusPending, models.OutboxStatusSigned, models.OutboxStatusBroadcast}).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// Get amounts of the user's messages for an action that are not broadcast yet;
+// a broadcast message has its transactions row
+func (r *OutboxRepository) GetUnbroadcastSince(userID int64, action string, since time.Time) ([]models.ChainOutbox, error) {
+	var messages []models.ChainOutbox
+	err := r.db.Select("amount", "created_at").
+		Where("user_id = ? AND action = ? AND status IN ? AND created_at >= ?", userID, action, []string{models.OutboxStatusPending, models.OutboxStatusSigned}, since).
+		Find(&messages).Error
+	return messages, err
+}
+
+// Get the raw transactions of messages that are signed but not final; the relay
+// or recorder may still broadcast them, so their nonces are taken
+func (r *OutboxRepository) GetHeldRawTxs() ([]string, error) {
+	var rawTxs []string
+	err := r.db.Model(&models.ChainOutbox{}).
+		Where("status IN ? AND raw_tx <> ''", []string{models.OutboxStatusSigned, models.OutboxStatusBroadcast}).
+		Pluck("raw_tx", &rawTxs).Error
+	return rawTxs, err
+}
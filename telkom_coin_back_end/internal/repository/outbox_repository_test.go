@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"telkom_coin_back_end/internal/models"
+	"testing"
+	"time"
+)
+
+func TestOutboxGetDue(t *testing.T) {
+	repo := NewOutboxRepository(newTestDB(t, &models.ChainOutbox{}))
+	now := time.Now()
+	past, future := now.Add(-time.Minute), now.Add(time.Minute)
+
+	tests := []struct {
+		wallet        string
+		status        string
+		nextAttemptAt *time.Time
+		wantDue       bool
+	}{
+		{wallet: "never tried", status: models.OutboxStatusSigned, wantDue: true},
+		{wallet: "backoff over", status: models.OutboxStatusSigned, nextAttemptAt: &past, wantDue: true},
+		{wallet: "backing off", status: models.OutboxStatusSigned, nextAttemptAt: &future},
+		{wallet: "other status", status: models.OutboxStatusPending},
+	}
+	for _, tt := range tests {
+		msg := &models.ChainOutbox{UserID: 1, Action: models.OutboxActionTopup, WalletAddress: tt.wallet, Amount: "1", Status: tt.status, NextAttemptAt: tt.nextAttemptAt}
+		if err := repo.Create(msg); err != nil {
+			t.Fatalf("create: %v", err)
+		}
+	}
+
+	due, err := repo.GetDue(models.OutboxStatusSigned, now, 10)
+	if err != nil {
+		t.Fatalf("GetDue error = %v", err)
+	}
+	got := map[string]bool{}
+	for _, msg := range due {
+		got[msg.WalletAddress] = true
+	}
+	for _, tt := range tests {
+		if got[tt.wallet] != tt.wantDue {
+			t.Errorf("%s: due = %v, want %v", tt.wallet, got[tt.wallet], tt.wantDue)
+		}
+	}
+}
+
+func TestOutboxRecordAttempt(t *testing.T) {
+	repo := NewOutboxRepository(newTestDB(t, &models.ChainOutbox{}))
+	msg := &models.ChainOutbox{UserID: 1, Action: models.OutboxActionTopup, WalletAddress: "0x1", Amount: "1", Status: models.OutboxStatusSigned}
+	if err := repo.Create(msg); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	now := time.Now()
+	if err := repo.RecordAttempt(msg.ID, "connection refused", now.Add(time.Minute)); err != nil {
+		t.Fatalf("RecordAttempt error = %v", err)
+	}
+	got, err := repo.GetByID(msg.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if got.Attempts != 1 || got.LastError != "connection refused" || got.NextAttemptAt == nil {
+		t.Fatalf("after RecordAttempt: %+v", got)
+	}
+	if due, _ := repo.GetDue(models.OutboxStatusSigned, now, 10); len(due) != 0 {
+		t.Errorf("message is due during its backoff")
+	}
+	if due, _ := repo.GetDue(models.OutboxStatusSigned, now.Add(2*time.Minute), 10); len(due) != 1 {
+		t.Errorf("message is not due after its backoff")
+	}
+}
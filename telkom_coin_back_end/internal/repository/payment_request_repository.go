@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"telkom_coin_back_end/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PaymentRequestRepositoryInterface defines the contract for payment request repository
+type PaymentRequestRepositoryInterface interface {
+	Create(req *models.PaymentRequest) error
+	GetByID(id int64) (*models.PaymentRequest, error)
+	GetIncoming(payerID int64, status string, page, limit int) ([]models.PaymentRequest, int64, error)
+	GetOutgoing(requesterID int64, status string, page, limit int) ([]models.PaymentRequest, int64, error)
+	Transition(id int64, from, to string, fields map[string]interface{}) (bool, error)
+	GetByTxHash(txHash string) (*models.PaymentRequest, error)
+	GetStalePaying(before time.Time, limit int) ([]models.PaymentRequest, error)
+	ExpireOverdue(now time.Time) (int64, error)
+}
+
+type PaymentRequestRepository struct {
+	db *gorm.DB
+}
+
+func NewPaymentRequestRepository(db *gorm.DB) *PaymentRequestRepository {
+	return &PaymentRequestRepository{db: db}
+}
+
+// Create new payment request
+func (r *PaymentRequestRepository) Create(req *models.PaymentRequest) error {
+	return r.db.Create(req).Error
+}
+
+// Get payment request by ID
+func (r *PaymentRequestRepository) GetByID(id int64) (*models.PaymentRequest, error) {
+	var req models.PaymentRequest
+	err := r.db.First(&req, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// Get requests sent to the payer, newest first
+func (r *PaymentRequestRepository) GetIncoming(payerID int64, status string, page, limit int) ([]models.PaymentRequest, int64, error) {
+	return r.list("payer_id", payerID, status, page, limit)
+}
+
+// Get requests made by the requester, newest first
+func (r *PaymentRequestRepository) GetOutgoing(requesterID int64, status string, page, limit int) ([]models.PaymentRequest, int64, error) {
+	return r.list("requester_id", requesterID, status, page, limit)
+}
+
+func (r *PaymentRequestRepository) list(column string, userID int64, status string, page, limit int) ([]models.PaymentRequest, int64, error) {
+	query := r.db.Model(&models.PaymentRequest{}).Where(column+" = ?", userID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var requests []models.PaymentRequest
+	err := query.Order("created_at DESC").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&requests).Error
+	return requests, total, err
+}
+
+// Move a request from one status to another; false when it was no longer in from
+func (r *PaymentRequestRepository) Transition(id int64, from, to string, fields map[string]interface{}) (bool, error) {
+	return transition(r.db, &models.PaymentRequest{}, id, from, to, fields)
+}
+
+// Get request by the hash of the transfer paying it
+func (r *PaymentRequestRepository) GetByTxHash(txHash string) (*models.PaymentRequest, error) {
+	var req models.PaymentRequest
+	err := r.db.Where("tx_hash = ?", txHash).First(&req).Error
+	if err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// Get requests still paying that were last changed before the given time
+func (r *PaymentRequestRepository) GetStalePaying(before time.Time, limit int) ([]models.PaymentRequest, error) {
+	var requests []models.PaymentRequest
+	err := r.db.Where("status = ? AND updated_at < ?", models.PaymentRequestStatusPaying, before).
+		Order("updated_at ASC").
+		Limit(limit).
+		Find(&requests).Error
+	return requests, err
+}
+
+// Mark open requests past their expiry as expired
+func (r *PaymentRequestRepository) ExpireOverdue(now time.Time) (int64, error) {
+	result := r.db.Model(&models.PaymentRequest{}).
+		Where("status = ? AND expires_at <= ?", models.PaymentRequestStatusOpen, now).
+		Update("status", models.PaymentRequestStatusExpired)
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"telkom_coin_back_end/internal/models"
+	"testing"
+	"time"
+)
+
+func TestPaymentRequestExpireOverdue(t *testing.T) {
+	repo := NewPaymentRequestRepository(newTestDB(t, &models.PaymentRequest{}))
+	now := time.Now()
+
+	tests := []struct {
+		status     string
+		expiresAt  time.Time
+		wantStatus string
+	}{
+		{status: models.PaymentRequestStatusOpen, expiresAt: now.Add(-time.Minute), wantStatus: models.PaymentRequestStatusExpired},
+		{status: models.PaymentRequestStatusOpen, expiresAt: now.Add(time.Minute), wantStatus: models.PaymentRequestStatusOpen},
+		// A payment in flight is settled by its receipt, not expired
+		{status: models.PaymentRequestStatusPaying, expiresAt: now.Add(-time.Minute), wantStatus: models.PaymentRequestStatusPaying},
+	}
+	ids := make([]int64, len(tests))
+	for i, tt := range tests {
+		req := &models.PaymentRequest{RequesterID: 1, PayerID: 2, Amount: "10", Status: tt.status, ExpiresAt: tt.expiresAt}
+		if err := repo.Create(req); err != nil {
+			t.Fatalf("create: %v", err)
+		}
+		ids[i] = req.ID
+	}
+
+	expired, err := repo.ExpireOverdue(now)
+	if err != nil || expired != 1 {
+		t.Fatalf("ExpireOverdue = %d, %v, want 1", expired, err)
+	}
+	for i, tt := range tests {
+		got, err := repo.GetByID(ids[i])
+		if err != nil {
+			t.Fatalf("GetByID: %v", err)
+		}
+		if got.Status != tt.wantStatus {
+			t.Errorf("request %d is %s, want %s", i, got.Status, tt.wantStatus)
+		}
+	}
+}
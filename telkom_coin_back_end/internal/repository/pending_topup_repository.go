@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"telkom_coin_back_end/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PendingTopupRepositoryInterface defines the contract for pending topup repository
+type PendingTopupRepositoryInterface interface {
+	Create(topup *models.PendingTopup) error
+	GetByOutboxID(outboxID int64) (*models.PendingTopup, error)
+	GetWaitingByUserID(userID int64) ([]models.PendingTopup, error)
+	GetDue(now time.Time, limit int) ([]models.PendingTopup, error)
+	GetByStatus(status string, limit int) ([]models.PendingTopup, error)
+	HasUnsettled(userID int64) (bool, error)
+	GetHeldRawTxs() ([]string, error)
+	Transition(id int64, from, to string, fields map[string]interface{}) (bool, error)
+	UpdateFields(id int64, fields map[string]interface{}) error
+	Transaction(fn func(tx *gorm.DB) error) error
+	WithTx(tx *gorm.DB) *PendingTopupRepository
+}
+
+type PendingTopupRepository struct {
+	db *gorm.DB
+}
+
+func NewPendingTopupRepository(db *gorm.DB) *PendingTopupRepository {
+	return &PendingTopupRepository{db: db}
+}
+
+// WithTx returns a repository that runs inside the given DB transaction
+func (r *PendingTopupRepository) WithTx(tx *gorm.DB) *PendingTopupRepository {
+	return &PendingTopupRepository{db: tx}
+}
+
+// Transaction runs fn in one DB transaction
+func (r *PendingTopupRepository) Transaction(fn func(tx *gorm.DB) error) error {
+	return r.db.Transaction(fn)
+}
+
+// Create new pending topup
+func (r *PendingTopupRepository) Create(topup *models.PendingTopup) error {
+	return r.db.Create(topup).Error
+}
+
+// Get pending topup by its requestTopup outbox message
+func (r *PendingTopupRepository) GetByOutboxID(outboxID int64) (*models.PendingTopup, error) {
+	var topup models.PendingTopup
+	err := r.db.Where("outbox_id = ?", outboxID).First(&topup).Error
+	if err != nil {
+		return nil, err
+	}
+	return &topup, nil
+}
+
+// Get the user's topups still waiting for processTopup, newest first
+func (r *PendingTopupRepository) GetWaitingByUserID(userID int64) ([]models.PendingTopup, error) {
+	waiting := []string{models.PendingTopupStatusRequesting, models.PendingTopupStatusRequested}
+
+	var topups []models.PendingTopup
+	err := r.db.Where("user_id = ? AND status IN ?", userID, waiting).
+		Order("created_at DESC, id DESC").
+		Find(&topups).Error
+	return topups, err
+}
+
+// Get requested topups whose waiting period is over, oldest first
+func (r *PendingTopupRepository) GetDue(now time.Time, limit int) ([]models.PendingTopup, error) {
+	var topups []models.PendingTopup
+	err := r.db.Where("status = ? AND processable_at <= ?", models.PendingTopupStatusRequested, now).
+		Order("processable_at ASC, id ASC").
+		Limit(limit).
+		Find(&topups).Error
+	return topups, err
+}
+
+// Get pending topups in a status, oldest first
+func (r *PendingTopupRepository) GetByStatus(status string, limit int) ([]models.PendingTopup, error) {
+	var topups []models.PendingTopup
+	err := r.db.Where("status = ?", status).
+		Order("id ASC").
+		Limit(limit).
+		Find(&topups).Error
+	return topups, err
+}
+
+// Check whether the user has a requested topup not yet minted and credited
+func (r *PendingTopupRepository) HasUnsettled(userID int64) (bool, error) {
+	unsettled := []string{models.PendingTopupStatusRequested, models.PendingTopupStatusProcessing}
+
+	var count int64
+	err := r.db.Model(&models.PendingTopup{}).
+		Where("user_id = ? AND status IN ?", userID, unsettled).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// Get the signed processTopup transactions the keeper may still resend
+func (r *PendingTopupRepository) GetHeldRawTxs() ([]string, error) {
+	var rawTxs []string
+	err := r.db.Model(&models.PendingTopup{}).
+		Where("status = ? AND raw_tx <> ''", models.PendingTopupStatusProcessing).
+		Pluck("raw_tx", &rawTxs).Error
+	return rawTxs, err
+}
+
+// Move a pending topup from one status to another; false when it was no longer in from
+func (r *PendingTopupRepository) Transition(id int64, from, to string, fields map[string]interface{}) (bool, error) {
+	return transition(r.db, &models.PendingTopup{}, id, from, to, fields)
+}
+
+// Update specific fields
+func (r *PendingTopupRepository) UpdateFields(id int64, fields map[string]interface{}) error {
+	return r.db.Model(&models.PendingTopup{}).Where("id = ?", id).Updates(fields).Error
+}
@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"telkom_coin_back_end/internal/models"
+	"testing"
+)
+
+func TestPendingTopupHasUnsettled(t *testing.T) {
+	tests := []struct {
+		status string
+		want   bool
+	}{
+		// Requesting is still covered by its chain_outbox message
+		{models.PendingTopupStatusRequesting, false},
+		{models.PendingTopupStatusRequested, true},
+		{models.PendingTopupStatusProcessing, true},
+		{models.PendingTopupStatusProcessed, false},
+		{models.PendingTopupStatusFailed, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			repo := NewPendingTopupRepository(newTestDB(t, &models.PendingTopup{}))
+			if err := repo.Create(&models.PendingTopup{UserID: 1, OutboxID: 1, WalletAddress: "0x1", Amount: "1", Status: tt.status}); err != nil {
+				t.Fatalf("create: %v", err)
+			}
+
+			got, err := repo.HasUnsettled(1)
+			if err != nil {
+				t.Fatalf("HasUnsettled error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("HasUnsettled = %v, want %v", got, tt.want)
+			}
+			if other, _ := repo.HasUnsettled(2); other {
+				t.Error("HasUnsettled is true for another user")
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"telkom_coin_back_end/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ScheduledTransferRepositoryInterface defines the contract for scheduled transfer repository
+type ScheduledTransferRepositoryInterface interface {
+	Create(schedule *models.ScheduledTransfer) error
+	GetByID(id int64) (*models.ScheduledTransfer, error)
+	GetByUserID(userID int64, page, limit int) ([]models.ScheduledTransfer, int64, error)
+	GetDue(now time.Time, limit int) ([]models.ScheduledTransfer, error)
+	Claim(id int64, now, leaseUntil time.Time) (bool, error)
+	UpdateFields(id int64, fields map[string]interface{}) error
+	Transition(id int64, from, to string, fields map[string]interface{}) (bool, error)
+	CreateExecution(execution *models.ScheduledTransferExecution) error
+	GetExecution(scheduleID int64, occurrence int) (*models.ScheduledTransferExecution, error)
+	TransitionExecution(id int64, from, to string, fields map[string]interface{}) (bool, error)
+	GetStaleSending(before time.Time, limit int) ([]models.ScheduledTransferExecution, error)
+	GetExecutions(scheduleID int64, page, limit int) ([]models.ScheduledTransferExecution, int64, error)
+	Transaction(fn func(tx *gorm.DB) error) error
+	WithTx(tx *gorm.DB) *ScheduledTransferRepository
+}
+
+type ScheduledTransferRepository struct {
+	db *gorm.DB
+}
+
+func NewScheduledTransferRepository(db *gorm.DB) *ScheduledTransferRepository {
+	return &ScheduledTransferRepository{db: db}
+}
+
+// WithTx returns a repository that runs inside the given DB transaction
+func (r *ScheduledTransferRepository) WithTx(tx *gorm.DB) *ScheduledTransferRepository {
+	return &ScheduledTransferRepository{db: tx}
+}
+
+// Transaction runs fn in one DB transaction
+func (r *ScheduledTransferRepository) Transaction(fn func(tx *gorm.DB) error) error {
+	return r.db.Transaction(fn)
+}
+
+// Create new scheduled transfer
+func (r *ScheduledTransferRepository) Create(schedule *models.ScheduledTransfer) error {
+	return r.db.Create(schedule).Error
+}
+
+// Get scheduled transfer by ID
+func (r *ScheduledTransferRepository) GetByID(id int64) (*models.ScheduledTransfer, error) {
+	var schedule models.ScheduledTransfer
+	err := r.db.First(&schedule, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+// Get scheduled transfers of a user, newest first
+func (r *ScheduledTransferRepository) GetByUserID(userID int64, page, limit int) ([]models.ScheduledTransfer, int64, error) {
+	query := r.db.Model(&models.ScheduledTransfer{}).Where("user_id = ?", userID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var schedules []models.ScheduledTransfer
+	err := query.Order("created_at DESC").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&schedules).Error
+	return schedules, total, err
+}
+
+// Get active schedules whose next run is due, oldest first
+func (r *ScheduledTransferRepository) GetDue(now time.Time, limit int) ([]models.ScheduledTransfer, error) {
+	var schedules []models.ScheduledTransfer
+	err := r.db.Where("status = ? AND next_run_at <= ?", models.ScheduleStatusActive, now).
+		Order("next_run_at ASC").
+		Limit(limit).
+		Find(&schedules).Error
+	return schedules, err
+}
+
+// Claim a due schedule by moving its next run to leaseUntil, so no other worker
+// runs it at the same time; false when it is no longer due or active
+func (r *ScheduledTransferRepository) Claim(id int64, now, leaseUntil time.Time) (bool, error) {
+	result := r.db.Model(&models.ScheduledTransfer{}).
+		Where("id = ? AND status = ? AND next_run_at <= ?", id, models.ScheduleStatusActive, now).
+		Update("next_run_at", leaseUntil)
+	return result.RowsAffected > 0, result.Error
+}
+
+// Update specific fields
+func (r *ScheduledTransferRepository) UpdateFields(id int64, fields map[string]interface{}) error {
+	return r.db.Model(&models.ScheduledTransfer{}).Where("id = ?", id).Updates(fields).Error
+}
+
+// Move a schedule from one status to another; false when it was no longer in from
+func (r *ScheduledTransferRepository) Transition(id int64, from, to string, fields map[string]interface{}) (bool, error) {
+	return transition(r.db, &models.ScheduledTransfer{}, id, from, to, fields)
+}
+
+// Record one run of a schedule
+func (r *ScheduledTransferRepository) CreateExecution(execution *models.ScheduledTransferExecution) error {
+	return r.db.Create(execution).Error
+}
+
+// Get the run of one occurrence of a schedule
+func (r *ScheduledTransferRepository) GetExecution(scheduleID int64, occurrence int) (*models.ScheduledTransferExecution, error) {
+	var execution models.ScheduledTransferExecution
+	err := r.db.Where("schedule_id = ? AND occurrence = ?", scheduleID, occurrence).First(&execution).Error
+	if err != nil {
+		return nil, err
+	}
+	return &execution, nil
+}
+
+// Move a run from one status to another; false when it was no longer in from
+func (r *ScheduledTransferRepository) TransitionExecution(id int64, from, to string, fields map[string]interface{}) (bool, error) {
+	return transition(r.db, &models.ScheduledTransferExecution{}, id, from, to, fields)
+}
+
+// Get runs still marked sending that were last touched before the given time
+func (r *ScheduledTransferRepository) GetStaleSending(before time.Time, limit int) ([]models.ScheduledTransferExecution, error) {
+	var executions []models.ScheduledTransferExecution
+	err := r.db.Where("status = ? AND updated_at < ?", models.ScheduleExecutionSending, before).
+		Order("updated_at ASC").
+		Limit(limit).
+		Find(&executions).Error
+	return executions, err
+}
+
+// Get the runs of a schedule, newest first
+func (r *ScheduledTransferRepository) GetExecutions(scheduleID int64, page, limit int) ([]models.ScheduledTransferExecution, int64, error) {
+	query := r.db.Model(&models.ScheduledTransferExecution{}).Where("schedule_id = ?", scheduleID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var executions []models.ScheduledTransferExecution
+	err := query.Order("created_at DESC, id DESC").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&executions).Error
+	return executions, total, err
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"telkom_coin_back_end/internal/models"
+	"testing"
+	"time"
+)
+
+func TestScheduledTransferExecutionUniquePerOccurrence(t *testing.T) {
+	repo := NewScheduledTransferRepository(newTestDB(t, &models.ScheduledTransferExecution{}))
+	for attempt := 1; attempt <= 2; attempt++ {
+		err := repo.CreateExecution(&models.ScheduledTransferExecution{ScheduleID: 1, Occurrence: 3, ScheduledFor: time.Now(), Attempt: attempt, Status: models.ScheduleExecutionSending})
+		if attempt == 2 && err == nil {
+			t.Fatal("a second run of the same occurrence was recorded")
+		}
+		if attempt == 1 && err != nil {
+			t.Fatalf("create: %v", err)
+		}
+	}
+}
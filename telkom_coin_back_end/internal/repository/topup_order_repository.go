@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"telkom_coin_back_end/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TopupOrderRepositoryInterface defines the contract for topup order repository
+type TopupOrderRepositoryInterface interface {
+	Create(order *models.TopupOrder) error
+	GetByID(id int64) (*models.TopupOrder, error)
+	GetByReference(reference string) (*models.TopupOrder, error)
+	GetByUserID(userID int64, page, limit int) ([]models.TopupOrder, int64, error)
+	GetByStatus(status string, limit int) ([]models.TopupOrder, error)
+	Transition(id int64, from, to string, fields map[string]interface{}) (bool, error)
+	UpdateFields(id int64, fields map[string]interface{}) error
+	GetMintedWithoutTxHash(limit int) ([]models.TopupOrder, error)
+	ExpireOverdue(now time.Time) (int64, error)
+	GetPaidBetween(from, to time.Time) ([]models.TopupOrder, error)
+	GetUsageSince(userID int64, since time.Time) ([]models.TopupOrder, error)
+	Transaction(fn func(tx *gorm.DB) error) error
+	WithTx(tx *gorm.DB) *TopupOrderRepository
+}
+
+type TopupOrderRepository struct {
+	db *gorm.DB
+}
+
+func NewTopupOrderRepository(db *gorm.DB) *TopupOrderRepository {
+	return &TopupOrderRepository{db: db}
+}
+
+// WithTx returns a repository that runs inside the given DB transaction
+func (r *TopupOrderRepository) WithTx(tx *gorm.DB) *TopupOrderRepository {
+	return &TopupOrderRepository{db: tx}
+}
+
+// Transaction runs fn in one DB transaction
+func (r *TopupOrderRepository) Transaction(fn func(tx *gorm.DB) error) error {
+	return r.db.Transaction(fn)
+}
+
+// Create new topup order
+func (r *TopupOrderRepository) Create(order *models.TopupOrder) error {
+	return r.db.Create(order).Error
+}
+
+// Get topup order by ID
+func (r *TopupOrderRepository) GetByID(id int64) (*models.TopupOrder, error) {
+	var order models.TopupOrder
+	err := r.db.First(&order, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// Get topup order by its reference
+func (r *TopupOrderRepository) GetByReference(reference string) (*models.TopupOrder, error) {
+	var order models.TopupOrder
+	err := r.db.Where("reference = ?", reference).First(&order).Error
+	if err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// Get topup orders of a user, newest first
+func (r *TopupOrderRepository) GetByUserID(userID int64, page, limit int) ([]models.TopupOrder, int64, error) {
+	query := r.db.Model(&models.TopupOrder{}).Where("user_id = ?", userID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var orders []models.TopupOrder
+	err := query.Order("created_at DESC, id DESC").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&orders).Error
+	return orders, total, err
+}
+
+// Get topup orders in a status, oldest first
+func (r *TopupOrderRepository) GetByStatus(status string, limit int) ([]models.TopupOrder, error) {
+	var orders []models.TopupOrder
+	err := r.db.Where("status = ?", status).
+		Order("id ASC").
+		Limit(limit).
+		Find(&orders).Error
+	return orders, err
+}
+
+// Move an order from one status to another; false when it was no longer in from
+func (r *TopupOrderRepository) Transition(id int64, from, to string, fields map[string]interface{}) (bool, error) {
+	return transition(r.db, &models.TopupOrder{}, id, from, to, fields)
+}
+
+// Update specific fields
+func (r *TopupOrderRepository) UpdateFields(id int64, fields map[string]interface{}) error {
+	return r.db.Model(&models.TopupOrder{}).Where("id = ?", id).Updates(fields).Error
+}
+
+// Get minted orders whose mint has no tx hash yet, oldest first
+func (r *TopupOrderRepository) GetMintedWithoutTxHash(limit int) ([]models.TopupOrder, error) {
+	var orders []models.TopupOrder
+	err := r.db.Where("status = ? AND (tx_hash = '' OR tx_hash IS NULL)", models.TopupOrderStatusMinted).
+		Order("id ASC").
+		Limit(limit).
+		Find(&orders).Error
+	return orders, err
+}
+
+// Expire orders still waiting for payment after their expiry
+func (r *TopupOrderRepository) ExpireOverdue(now time.Time) (int64, error) {
+	unpaid := []string{models.TopupOrderStatusCreated, models.TopupOrderStatusAwaitingPayment}
+	result := r.db.Model(&models.TopupOrder{}).
+		Where("status IN ? AND expires_at <= ?", unpaid, now).
+		Update("status", models.TopupOrderStatusExpired)
+	return result.RowsAffected, result.Error
+}
+
+// Get paid, minted or mint_failed orders paid in [from, to), oldest payment first
+func (r *TopupOrderRepository) GetPaidBetween(from, to time.Time) ([]models.TopupOrder, error) {
+	paid := []string{models.TopupOrderStatusPaid, models.TopupOrderStatusMinted, models.TopupOrderStatusMintFailed}
+	var orders []models.TopupOrder
+	err := r.db.Where("status IN ? AND paid_at >= ? AND paid_at < ?", paid, from, to).
+		Order("paid_at ASC, id ASC").
+		Find(&orders).Error
+	return orders, err
+}
+
+// Get amounts of the user's orders created since the given time that were not
+// expired, from the request until the mint
+func (r *TopupOrderRepository) GetUsageSince(userID int64, since time.Time) ([]models.TopupOrder, error) {
+	var orders []models.TopupOrder
+	err := r.db.Select("amount", "created_at").
+		Where("user_id = ? AND status <> ? AND created_at >= ?", userID, models.TopupOrderStatusExpired, since).
+		Find(&orders).Error
+	return orders, err
+}
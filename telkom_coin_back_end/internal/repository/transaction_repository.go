@@ -2,6 +2,7 @@ package repository
 
 import (
 	"telkom_coin_back_end/internal/models"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -19,9 +20,15 @@ type TransactionRepositoryInterface interface {
 	Update(tx *models.Transaction) error
 	UpdateStatus(hash, status string) error
 	UpdateBlockInfo(hash string, blockNumber, gasUsed, gasPrice int64) error
+	UpdateFinalStatus(hash, status string, blockNumber, gasUsed, gasPrice int64) (bool, error)
+	MarkNotFound(hash string) (bool, error)
 	HashExists(hash string) (bool, error)
+	HasPendingForAddress(address string) (bool, error)
 	GetTopupHistory(address string, limit, offset int) ([]models.Transaction, int64, error)
+	GetUsageSince(address, txType string, since time.Time) ([]models.Transaction, error)
+	FindTransferSince(from, to, amount string, since time.Time) (*models.Transaction, error)
 	CreateWithTx(tx *gorm.DB, txModel *models.Transaction) error
+	WithTx(tx *gorm.DB) *TransactionRepository
 }
 
 type TransactionRepository struct {
@@ -32,6 +39,11 @@ func NewTransactionRepository(db *gorm.DB) *TransactionRepository {
 	return &TransactionRepository{db: db}
 }
 
+// WithTx returns a repository that runs inside the given DB transaction
+func (r *TransactionRepository) WithTx(tx *gorm.DB) *TransactionRepository {
+	return &TransactionRepository{db: tx}
+}
+
 // Create new transaction
 func (r *TransactionRepository) Create(tx *models.Transaction) error {
 	return r.db.Create(tx).Error
@@ -117,10 +129,10 @@ func (r *TransactionRepository) GetByStatus(status string, limit, offset int) ([
 	return transactions, err
 }
 
-// Get pending transactions
+// Get transactions that are not final yet (pending or processing), oldest first
 func (r *TransactionRepository) GetPending(limit int) ([]models.Transaction, error) {
 	var transactions []models.Transaction
-	err := r.db.Where("status = ?", "pending").
+	err := r.db.Where("status IN ?", []string{"pending", "processing"}).
 		Order("created_at ASC").
 		Limit(limit).
 		Find(&transactions).Error
@@ -141,14 +153,32 @@ func (r *TransactionRepository) UpdateStatus(hash, status string) error {
 
 // Update transaction with block info
 func (r *TransactionRepository) UpdateBlockInfo(hash string, blockNumber, gasUsed, gasPrice int64) error {
-	return r.db.Model(&models.Transaction{}).
-		Where("tx_hash = ?", hash).
+	_, err := r.UpdateFinalStatus(hash, "confirmed", blockNumber, gasUsed, gasPrice)
+	return err
+}
+
+// Move a pending or processing transaction to its final status (confirmed or failed)
+// with the receipt data. Returns false when the row was already final, so callers
+// that race on the same hash act on the transition only once.
+func (r *TransactionRepository) UpdateFinalStatus(hash, status string, blockNumber, gasUsed, gasPrice int64) (bool, error) {
+	result := r.db.Model(&models.Transaction{}).
+		Where("tx_hash = ? AND status IN ?", hash, []string{"pending", "processing"}).
 		Updates(map[string]interface{}{
 			"block_number": blockNumber,
 			"gas_used":     gasUsed,
 			"gas_price":    gasPrice,
-			"status":       "confirmed",
-		}).Error
+			"status":       status,
+			"confirmed_at": time.Now(),
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+// Flag a pending or processing transaction whose hash the chain does not know
+func (r *TransactionRepository) MarkNotFound(hash string) (bool, error) {
+	result := r.db.Model(&models.Transaction{}).
+		Where("tx_hash = ? AND status IN ?", hash, []string{"pending", "processing"}).
+		Update("status", "not_found")
+	return result.RowsAffected > 0, result.Error
 }
 
 // Check if transaction hash exists
@@ -158,6 +188,15 @@ func (r *TransactionRepository) HashExists(hash string) (bool, error) {
 	return count > 0, err
 }
 
+// Check if a transaction from or to the address is not final yet
+func (r *TransactionRepository) HasPendingForAddress(address string) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.Transaction{}).
+		Where("(from_address = ? OR to_address = ?) AND status IN ?", address, address, []string{"pending", "processing"}).
+		Count(&count).Error
+	return count > 0, err
+}
+
 // Get topup history for user
 func (r *TransactionRepository) GetTopupHistory(address string, limit, offset int) ([]models.Transaction, int64, error) {
 	var transactions []models.Transaction
@@ -181,6 +220,34 @@ func (r *TransactionRepository) GetTopupHistory(address string, limit, offset in
 	return transactions, totalCount, err
 }
 
+// Get the amount and time of a user's transactions of one type since a time, for
+// limits. Topups credit the wallet (to_address), transfers and withdrawals debit it
+// (from_address). Failed transactions don't count.
+func (r *TransactionRepository) GetUsageSince(address, txType string, since time.Time) ([]models.Transaction, error) {
+	column := "from_address"
+	if txType == "topup" {
+		column = "to_address"
+	}
+
+	var transactions []models.Transaction
+	err := r.db.Select("amount", "created_at").
+		Where(column+" = ? AND tx_type = ? AND status <> ? AND created_at >= ?", address, txType, "failed", since).
+		Find(&transactions).Error
+	return transactions, err
+}
+
+// Find the oldest transfer with these parties and amount saved since the given time
+func (r *TransactionRepository) FindTransferSince(from, to, amount string, since time.Time) (*models.Transaction, error) {
+	var tx models.Transaction
+	err := r.db.Where("from_address = ? AND to_address = ? AND amount = ? AND tx_type = ? AND created_at >= ?", from, to, amount, "transfer", since).
+		Order("created_at ASC").
+		First(&tx).Error
+	if err != nil {
+		return nil, err
+	}
+	return &tx, nil
+}
+
 func (r *TransactionRepository) CreateWithTx(tx *gorm.DB, txModel *models.Transaction) error {
 	return tx.Create(txModel).Error
 }
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"telkom_coin_back_end/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TransferQuoteClaimRepositoryInterface defines the contract for transfer quote claim repository
+type TransferQuoteClaimRepositoryInterface interface {
+	Claim(claim *models.TransferQuoteClaim) (bool, error)
+	Delete(quoteID string) error
+	DeleteExpired(now time.Time) (int64, error)
+}
+
+type TransferQuoteClaimRepository struct {
+	db *gorm.DB
+}
+
+func NewTransferQuoteClaimRepository(db *gorm.DB) *TransferQuoteClaimRepository {
+	return &TransferQuoteClaimRepository{db: db}
+}
+
+// Claim inserts the claim; false means the quote was already claimed
+func (r *TransferQuoteClaimRepository) Claim(claim *models.TransferQuoteClaim) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(claim)
+	return result.RowsAffected > 0, result.Error
+}
+
+// Delete claim of a quote
+func (r *TransferQuoteClaimRepository) Delete(quoteID string) error {
+	return r.db.Where("quote_id = ?", quoteID).Delete(&models.TransferQuoteClaim{}).Error
+}
+
+// Delete claims of quotes past their expiry
+func (r *TransferQuoteClaimRepository) DeleteExpired(now time.Time) (int64, error) {
+	result := r.db.Where("expires_at < ?", now).Delete(&models.TransferQuoteClaim{})
+	return result.RowsAffected, result.Error
+}
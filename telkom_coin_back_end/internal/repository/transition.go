@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"gorm.io/gorm"
+)
+
+// transition moves a row of model from one status to another and sets fields
+// with it; false when it was no longer in from, e.g. another worker moved it
+func transition(db *gorm.DB, model interface{}, id int64, from, to string, fields map[string]interface{}) (bool, error) {
+	updates := map[string]interface{}{"status": to}
+	for key, value := range fields {
+		updates[key] = value
+	}
+
+	result := db.Model(model).
+		Where("id = ? AND status = ?", id, from).
+		Updates(updates)
+	return result.RowsAffected > 0, result.Error
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"telkom_coin_back_end/internal/models"
+	"testing"
+	"time"
+)
+
+func TestTransition(t *testing.T) {
+	tests := []struct {
+		name      string
+		id        int64
+		from      string
+		wantMoved bool
+		want      string
+		wantHash  string
+	}{
+		{"moves from the current status", 1, models.TopupOrderStatusPaid, true, models.TopupOrderStatusMinted, "0xabc"},
+		{"keeps a row moved by someone else", 1, models.TopupOrderStatusAwaitingPayment, false, models.TopupOrderStatusPaid, ""},
+		{"unknown row", 2, models.TopupOrderStatusPaid, false, models.TopupOrderStatusPaid, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newTestDB(t, &models.TopupOrder{})
+			order := &models.TopupOrder{UserID: 1, Reference: "TOP-1", Amount: "1", PaymentMethod: "qris", Provider: "mock",
+				Status: models.TopupOrderStatusPaid, ExpiresAt: time.Now()}
+			if err := db.Create(order).Error; err != nil {
+				t.Fatalf("create: %v", err)
+			}
+
+			moved, err := transition(db, &models.TopupOrder{}, tt.id, tt.from, models.TopupOrderStatusMinted, map[string]interface{}{"tx_hash": "0xabc"})
+			if err != nil {
+				t.Fatalf("transition error = %v", err)
+			}
+			if moved != tt.wantMoved {
+				t.Errorf("moved = %v, want %v", moved, tt.wantMoved)
+			}
+
+			var got models.TopupOrder
+			if err := db.First(&got, order.ID).Error; err != nil {
+				t.Fatalf("load: %v", err)
+			}
+			if got.Status != tt.want {
+				t.Errorf("status = %s, want %s", got.Status, tt.want)
+			}
+			if got.TxHash != tt.wantHash {
+				t.Errorf("tx_hash = %q, want %q", got.TxHash, tt.wantHash)
+			}
+		})
+	}
+}
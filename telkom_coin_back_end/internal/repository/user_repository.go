@@ -10,9 +10,11 @@ import (
 type UserRepositoryInterface interface {
 	Create(user *models.User) error
 	GetByID(id int64) (*models.User, error)
+	GetByIDs(ids []int64) (map[int64]models.User, error)
 	GetByEmail(email string) (*models.User, error)
 	GetByUsername(username string) (*models.User, error)
 	GetByWalletAddress(address string) (*models.User, error)
+	GetByPhones(phones []string) ([]models.User, error)
 	Update(user *models.User) error
 	UpdateFields(id int64, fields map[string]interface{}) error
 	Delete(id int64) error
@@ -43,6 +45,20 @@ func (r *UserRepository) GetByID(id int64) (*models.User, error) {
 	return &user, nil
 }
 
+// Get users by IDs, keyed by ID
+func (r *UserRepository) GetByIDs(ids []int64) (map[int64]models.User, error) {
+	var users []models.User
+	if err := r.db.Where("id IN ?", ids).Find(&users).Error; err != nil {
+		return nil, err
+	}
+
+	byID := make(map[int64]models.User, len(users))
+	for _, user := range users {
+		byID[user.ID] = user
+	}
+	return byID, nil
+}
+
 // Get user by email
 func (r *UserRepository) GetByEmail(email string) (*models.User, error) {
 	var user models.User
@@ -73,6 +89,13 @@ func (r *UserRepository) GetByWalletAddress(address string) (*models.User, error
 	return &user, nil
 }
 
+// Get users whose phone matches any of the given formats
+func (r *UserRepository) GetByPhones(phones []string) ([]models.User, error) {
+	var users []models.User
+	err := r.db.Where("phone IN ?", phones).Limit(2).Find(&users).Error
+	return users, err
+}
+
 // Update user
 func (r *UserRepository) Update(user *models.User) error {
 	return r.db.Save(user).Error
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"telkom_coin_back_end/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// VirtualAccountRepositoryInterface defines the contract for virtual account repository
+type VirtualAccountRepositoryInterface interface {
+	Create(account *models.VirtualAccount) error
+	GetByUserID(userID int64) ([]models.VirtualAccount, error)
+	GetByNumber(number string) (*models.VirtualAccount, error)
+	CreateCredit(credit *models.VirtualAccountCredit) error
+	GetCreditByID(id int64) (*models.VirtualAccountCredit, error)
+	GetCreditByTransactionID(provider, transactionID string) (*models.VirtualAccountCredit, error)
+	GetCreditsByStatus(status string, page, limit int) ([]models.VirtualAccountCredit, int64, error)
+	TransitionCredit(id int64, from, to string, fields map[string]interface{}) (bool, error)
+	Transaction(fn func(tx *gorm.DB) error) error
+	WithTx(tx *gorm.DB) *VirtualAccountRepository
+}
+
+type VirtualAccountRepository struct {
+	db *gorm.DB
+}
+
+func NewVirtualAccountRepository(db *gorm.DB) *VirtualAccountRepository {
+	return &VirtualAccountRepository{db: db}
+}
+
+// WithTx returns a repository that runs inside the given DB transaction
+func (r *VirtualAccountRepository) WithTx(tx *gorm.DB) *VirtualAccountRepository {
+	return &VirtualAccountRepository{db: tx}
+}
+
+// Transaction runs fn in one DB transaction
+func (r *VirtualAccountRepository) Transaction(fn func(tx *gorm.DB) error) error {
+	return r.db.Transaction(fn)
+}
+
+// Create new virtual account
+func (r *VirtualAccountRepository) Create(account *models.VirtualAccount) error {
+	return r.db.Create(account).Error
+}
+
+// Get the virtual accounts of a user
+func (r *VirtualAccountRepository) GetByUserID(userID int64) ([]models.VirtualAccount, error) {
+	var accounts []models.VirtualAccount
+	err := r.db.Where("user_id = ?", userID).Order("bank ASC").Find(&accounts).Error
+	return accounts, err
+}
+
+// Get virtual account by its number
+func (r *VirtualAccountRepository) GetByNumber(number string) (*models.VirtualAccount, error) {
+	var account models.VirtualAccount
+	err := r.db.Where("number = ?", number).First(&account).Error
+	if err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// Create new incoming credit
+func (r *VirtualAccountRepository) CreateCredit(credit *models.VirtualAccountCredit) error {
+	return r.db.Create(credit).Error
+}
+
+// Get incoming credit by ID
+func (r *VirtualAccountRepository) GetCreditByID(id int64) (*models.VirtualAccountCredit, error) {
+	var credit models.VirtualAccountCredit
+	err := r.db.First(&credit, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &credit, nil
+}
+
+// Get incoming credit by the provider's transaction ID
+func (r *VirtualAccountRepository) GetCreditByTransactionID(provider, transactionID string) (*models.VirtualAccountCredit, error) {
+	var credit models.VirtualAccountCredit
+	err := r.db.Where("provider = ? AND transaction_id = ?", provider, transactionID).First(&credit).Error
+	if err != nil {
+		return nil, err
+	}
+	return &credit, nil
+}
+
+// Get incoming credits in a status, oldest first (all statuses when empty)
+func (r *VirtualAccountRepository) GetCreditsByStatus(status string, page, limit int) ([]models.VirtualAccountCredit, int64, error) {
+	query := r.db.Model(&models.VirtualAccountCredit{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var credits []models.VirtualAccountCredit
+	err := query.Order("id ASC").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&credits).Error
+	return credits, total, err
+}
+
+// Move an incoming credit from one status to another; false when it was no longer in from
+func (r *VirtualAccountRepository) TransitionCredit(id int64, from, to string, fields map[string]interface{}) (bool, error) {
+	return transition(r.db, &models.VirtualAccountCredit{}, id, from, to, fields)
+}
@@ -0,0 +1,312 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"sync"
+	"telkom_coin_back_end/config"
+	"telkom_coin_back_end/internal/blockchain"
+	"telkom_coin_back_end/internal/dto/response"
+	"telkom_coin_back_end/internal/models"
+	"telkom_coin_back_end/internal/repository"
+	"telkom_coin_back_end/internal/web3"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"gorm.io/gorm"
+)
+
+const balanceReconcileBatchSize = 200
+
+// BalanceReconciler compares every balances row with the PaymentToken balance on
+// chain. Mismatches up to BALANCE_RECONCILE_FIX_LIMIT TLC are fixed with the chain
+// value, larger ones (or fractional chain balances) flag the user for review.
+// Users with a topup or withdraw still in the outbox, a delayed topup not yet
+// credited, or a transaction not final yet, are skipped for that run. Each user is read at the head of its own turn;
+// the report's block is the head when the run started.
+type BalanceReconciler struct {
+	balanceRepo        *repository.BalanceRepository
+	outboxRepo         *repository.OutboxRepository
+	txRepo             *repository.TransactionRepository
+	pendingTopupRepo   *repository.PendingTopupRepository
+	reconciliationRepo *repository.BalanceReconciliationRepository
+	blockchainService  *blockchain.BlockchainService
+	contractService    *web3.ContractService
+	interval           time.Duration
+	fixLimit           *big.Int // wei
+
+	runMu    sync.Mutex
+	stopOnce sync.Once
+	stop     chan struct{}
+}
+
+func NewBalanceReconciler(
+	balanceRepo *repository.BalanceRepository,
+	outboxRepo *repository.OutboxRepository,
+	txRepo *repository.TransactionRepository,
+	pendingTopupRepo *repository.PendingTopupRepository,
+	reconciliationRepo *repository.BalanceReconciliationRepository,
+	blockchainService *blockchain.BlockchainService,
+) *BalanceReconciler {
+	var contractService *web3.ContractService
+	if blockchainService.IsWeb3Enabled() {
+		web3Client, err := web3.NewWeb3Client()
+		if err == nil {
+			contractService, _ = web3.NewContractService(web3Client)
+		}
+	}
+
+	fixLimit, err := blockchain.ParseTokenAmount(config.AppConfig.BalanceReconcileFixLimit)
+	if err != nil {
+		log.Printf("Warning: invalid BALANCE_RECONCILE_FIX_LIMIT %q, every mismatch will be flagged", config.AppConfig.BalanceReconcileFixLimit)
+		fixLimit = big.NewInt(0)
+	}
+
+	return &BalanceReconciler{
+		balanceRepo:        balanceRepo,
+		outboxRepo:         outboxRepo,
+		txRepo:             txRepo,
+		pendingTopupRepo:   pendingTopupRepo,
+		reconciliationRepo: reconciliationRepo,
+		blockchainService:  blockchainService,
+		contractService:    contractService,
+		interval:           config.AppConfig.BalanceReconcileInterval,
+		fixLimit:           fixLimit,
+		stop:               make(chan struct{}),
+	}
+}
+
+// Start runs a reconciliation every BALANCE_RECONCILE_INTERVAL until Stop is called
+func (r *BalanceReconciler) Start() {
+	if r.contractService == nil {
+		log.Println("⚠️  Balance reconciler not started, blockchain not available")
+		return
+	}
+
+	go func() {
+		log.Printf("⚖️  Balance reconciler started (every %s)", r.interval)
+
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-r.stop:
+				log.Println("Balance reconciler stopped")
+				return
+			case <-ticker.C:
+				if _, err := r.RunOnce(); err != nil {
+					log.Printf("[ERROR] Balance reconciliation failed: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+// Stop signals the reconciler loop to exit
+func (r *BalanceReconciler) Stop() {
+	r.stopOnce.Do(func() { close(r.stop) })
+}
+
+// RunOnce reconciles every balance and stores the report
+func (r *BalanceReconciler) RunOnce() (*models.BalanceReconciliationReport, error) {
+	if r.contractService == nil {
+		return nil, errors.New("blockchain not available")
+	}
+	if !r.runMu.TryLock() {
+		return nil, errors.New("balance reconciliation already running")
+	}
+	defer r.runMu.Unlock()
+
+	head, err := r.blockchainService.GetBlockNumber()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest block: %v", err)
+	}
+
+	report := &models.BalanceReconciliationReport{
+		Status:      models.ReconciliationStatusRunning,
+		BlockNumber: head,
+		StartedAt:   time.Now(),
+	}
+	if err := r.reconciliationRepo.CreateReport(report); err != nil {
+		return nil, err
+	}
+
+	runErr := r.reconcileAll(report)
+
+	finishedAt := time.Now()
+	report.FinishedAt = &finishedAt
+	report.Status = models.ReconciliationStatusCompleted
+	if runErr != nil {
+		report.Status = models.ReconciliationStatusFailed
+		report.LastError = runErr.Error()
+	}
+	if err := r.reconciliationRepo.UpdateReport(report); err != nil {
+		return nil, err
+	}
+
+	log.Printf("⚖️  Balance reconciliation #%d at block %d: %d checked, %d mismatches (%d fixed, %d flagged), %d skipped, %d errors",
+		report.ID, report.BlockNumber, report.UsersChecked, report.Mismatches, report.Fixed, report.Flagged, report.UsersSkipped, report.Errors)
+	return report, runErr
+}
+
+func (r *BalanceReconciler) reconcileAll(report *models.BalanceReconciliationReport) error {
+	for offset := 0; ; offset += balanceReconcileBatchSize {
+		balances, err := r.balanceRepo.GetAll(balanceReconcileBatchSize, offset)
+		if err != nil {
+			return fmt.Errorf("failed to load balances: %v", err)
+		}
+
+		for i := range balances {
+			if err := r.reconcileUser(report, balances[i].UserID); err != nil {
+				log.Printf("[WARN] Balance reconciliation of user %d failed: %v", balances[i].UserID, err)
+				report.Errors++
+				report.LastError = err.Error()
+			}
+		}
+
+		if len(balances) < balanceReconcileBatchSize {
+			return nil
+		}
+	}
+}
+
+// reconcileUser re-reads the balances row, skips the user while a topup, withdraw
+// or transfer is still settling, and compares the row with the chain at the
+// current head. A run takes a while, so nothing read at its start is reused here.
+func (r *BalanceReconciler) reconcileUser(report *models.BalanceReconciliationReport, userID int64) error {
+	balance, err := r.balanceRepo.GetByUserID(userID)
+	if err != nil {
+		return fmt.Errorf("failed to load balance: %v", err)
+	}
+
+	inFlight, err := r.outboxRepo.HasInFlight(userID)
+	if err != nil {
+		return fmt.Errorf("failed to check outbox: %v", err)
+	}
+	if !inFlight {
+		inFlight, err = r.txRepo.HasPendingForAddress(balance.WalletAddress)
+		if err != nil {
+			return fmt.Errorf("failed to check transactions: %v", err)
+		}
+	}
+	if !inFlight {
+		// The keeper credits delayed topups itself, also when minted by someone else
+		inFlight, err = r.pendingTopupRepo.HasUnsettled(userID)
+		if err != nil {
+			return fmt.Errorf("failed to check pending topups: %v", err)
+		}
+	}
+	if inFlight {
+		report.UsersSkipped++
+		return nil
+	}
+
+	head, err := r.blockchainService.GetBlockNumber()
+	if err != nil {
+		return fmt.Errorf("failed to get latest block: %v", err)
+	}
+
+	report.UsersChecked++
+	return r.reconcileBalance(report, balance, head)
+}
+
+// reconcileBalance compares one balances row with the chain at block and fixes or
+// flags it. The fix only applies while the row still holds the compared value.
+func (r *BalanceReconciler) reconcileBalance(report *models.BalanceReconciliationReport, balance *models.Balance, head uint64) error {
+	chainWei, err := r.contractService.GetBalanceAt(common.HexToAddress(balance.WalletAddress), new(big.Int).SetUint64(head))
+	if err != nil {
+		return fmt.Errorf("failed to get on-chain balance: %v", err)
+	}
+
+	dbWei, err := blockchain.ParseTokenAmount(balance.Balance)
+	if err != nil {
+		dbWei = nil // Nilai di DB rusak, tetap dicatat sebagai mismatch
+	}
+
+	blockNumber := int64(head)
+	if dbWei != nil && dbWei.Cmp(chainWei) == 0 {
+		return r.balanceRepo.MarkSynced(balance.UserID, blockNumber)
+	}
+
+	difference := new(big.Int).Set(chainWei)
+	if dbWei != nil {
+		difference.Sub(chainWei, dbWei)
+	}
+
+	mismatch := &models.BalanceMismatch{
+		ReportID:      report.ID,
+		UserID:        balance.UserID,
+		WalletAddress: balance.WalletAddress,
+		DBBalance:     balance.Balance,
+		ChainBalance:  blockchain.FormatTokenAmount(chainWei),
+		Difference:    formatSignedTokenAmount(difference),
+		LockedBalance: balance.LockedBalance,
+	}
+	report.Mismatches++
+
+	// Saldo di DB disimpan dalam TLC bulat
+	chainWhole := new(big.Int).Mod(chainWei, tokenUnit()).Sign() == 0
+	switch {
+	case dbWei == nil:
+		mismatch.Reason = "stored balance is not a valid amount"
+	case !chainWhole:
+		mismatch.Reason = "on-chain balance has a fractional part"
+	case new(big.Int).Abs(difference).Cmp(r.fixLimit) > 0:
+		mismatch.Reason = "difference above BALANCE_RECONCILE_FIX_LIMIT"
+	}
+
+	if mismatch.Reason == "" {
+		fixed, err := r.balanceRepo.SetReconciledBalance(balance.UserID, balance.Balance, mismatch.ChainBalance, blockNumber)
+		if err != nil {
+			return err
+		}
+		if fixed {
+			mismatch.Action = models.MismatchActionFixed
+			report.Fixed++
+		} else {
+			mismatch.Reason = "balance changed during reconciliation"
+		}
+	}
+
+	if mismatch.Action == "" {
+		if err := r.balanceRepo.FlagForReview(balance.UserID, blockNumber); err != nil {
+			return err
+		}
+		mismatch.Action = models.MismatchActionFlagged
+		report.Flagged++
+	}
+
+	return r.reconciliationRepo.AddMismatch(mismatch)
+}
+
+// LatestReport returns the last finished report with its mismatches
+func (r *BalanceReconciler) LatestReport() (*response.BalanceReconciliationResponse, error) {
+	report, err := r.reconciliationRepo.GetLatestReport()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("no reconciliation report yet")
+		}
+		return nil, err
+	}
+
+	mismatches, err := r.reconciliationRepo.GetMismatches(report.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.BalanceReconciliationResponse{
+		Report:     report,
+		Mismatches: mismatches,
+	}, nil
+}
+
+// formatSignedTokenAmount formats a wei difference as TLC with its sign
+func formatSignedTokenAmount(amountWei *big.Int) string {
+	if amountWei.Sign() < 0 {
+		return "-" + blockchain.FormatTokenAmount(new(big.Int).Neg(amountWei))
+	}
+	return blockchain.FormatTokenAmount(amountWei)
+}
@@ -56,7 +56,7 @@ func (s *BalanceService) GetBalance(userID int64) (*response.BalanceResponse, er
 	}
 
 	// Create blockchain service
-	blockchainService, err := blockchain.NewBlockchainService("ganache-local")
+	blockchainService, err := blockchain.NewActiveBlockchainService()
 	if err != nil {
 		return nil, errors.New("blockchain service unavailable")
 	}
@@ -0,0 +1,414 @@
+package service
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+	"telkom_coin_back_end/config"
+	"telkom_coin_back_end/internal/bankstatement"
+	"telkom_coin_back_end/internal/dto/response"
+	"telkom_coin_back_end/internal/models"
+	"telkom_coin_back_end/internal/repository"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrStatementReportNotFound  = errors.New("bank statement report not found")
+	ErrStatementAlreadyImported = errors.New("bank statement file was already imported")
+)
+
+// Topup references as banks print them: TOP-20260116-9F2C41AB, often without
+// the dashes or with spaces when the sender typed the transfer note
+var topupReferencePattern = regexp.MustCompile(`TOP[- ]?(\d{8})[- ]?([0-9A-F]{8})`)
+
+// BankStatementService reconciles the IDR that reached our bank account with
+// topup orders. Imports are read-only: nothing is minted or changed on the orders.
+type BankStatementService struct {
+	statementRepo *repository.BankStatementRepository
+	orderRepo     *repository.TopupOrderRepository
+}
+
+func NewBankStatementService(statementRepo *repository.BankStatementRepository, orderRepo *repository.TopupOrderRepository) *BankStatementService {
+	return &BankStatementService{
+		statementRepo: statementRepo,
+		orderRepo:     orderRepo,
+	}
+}
+
+// statementMatch is a statement credit and the order it backs, if any
+type statementMatch struct {
+	entry     bankstatement.Entry
+	order     *models.TopupOrder
+	matchedBy string
+	reason    string
+}
+
+// Import parses a statement file, matches its credits to topup orders and
+// saves the report. format is csv or mt940; empty guesses it from the file name.
+// A file already imported (same SHA-256) is rejected with its report ID.
+func (s *BankStatementService) Import(fileName, format string, data []byte) (*response.BankStatementReportResponse, error) {
+	sum := sha256.Sum256(data)
+	fileSHA256 := hex.EncodeToString(sum[:])
+	if err := s.checkNotImported(fileSHA256); err != nil {
+		return nil, err
+	}
+
+	if format == "" {
+		format = bankstatement.DetectFormat(fileName)
+	}
+	statement, err := bankstatement.Parse(format, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	window := config.AppConfig.BankStatementMatchWindow
+	periodEnd := statement.To.AddDate(0, 0, 1)
+	orders, err := s.orderRepo.GetPaidBetween(statement.From.Add(-window), periodEnd.Add(window))
+	if err != nil {
+		log.Printf("[ERROR] Failed to load paid topup orders: %v", err)
+		return nil, errors.New("failed to load topup orders")
+	}
+
+	var credits []bankstatement.Entry
+	debits := 0
+	for _, entry := range statement.Entries {
+		if entry.Credit {
+			credits = append(credits, entry)
+		} else {
+			debits++
+		}
+	}
+	matches := s.match(credits, orders, window)
+
+	report := &models.BankStatementReport{
+		Format:        strings.ToLower(format),
+		FileName:      fileName,
+		FileSHA256:    fileSHA256,
+		AccountNumber: statement.AccountNumber,
+		PeriodFrom:    statement.From,
+		PeriodTo:      statement.To,
+		Credits:       len(credits),
+		Debits:        debits,
+	}
+	creditTotal, matchedTotal, unmatchedTotal, unbackedTotal := new(big.Rat), new(big.Rat), new(big.Rat), new(big.Rat)
+
+	items := make([]models.BankStatementItem, 0, len(matches))
+	backed := make(map[int64]bool)
+	for _, m := range matches {
+		amount := ratAmount(m.entry.Amount)
+		creditTotal.Add(creditTotal, amount)
+
+		entryDate := m.entry.Date
+		item := models.BankStatementItem{
+			Line:          m.entry.Line,
+			EntryDate:     &entryDate,
+			Amount:        m.entry.Amount,
+			Description:   m.entry.Description,
+			BankReference: m.entry.BankReference,
+			MatchedBy:     m.matchedBy,
+			Reason:        m.reason,
+		}
+		if m.order != nil && m.matchedBy != "" {
+			item.Kind = models.StatementItemMatched
+			backed[m.order.ID] = true
+			matchedTotal.Add(matchedTotal, amount)
+			report.Matched++
+		} else {
+			item.Kind = models.StatementItemUnmatchedCredit
+			unmatchedTotal.Add(unmatchedTotal, amount)
+			report.UnmatchedCredits++
+		}
+		if m.order != nil {
+			setStatementOrder(&item, m.order)
+		}
+		items = append(items, item)
+	}
+
+	// Minted in the statement period without a credit: TLC with no IDR behind it
+	for i := range orders {
+		order := &orders[i]
+		if backed[order.ID] || order.Status != models.TopupOrderStatusMinted ||
+			order.PaidAt.Before(statement.From) || !order.PaidAt.Before(periodEnd) {
+			continue
+		}
+		item := models.BankStatementItem{
+			Kind:   models.StatementItemUnbackedMint,
+			Reason: "no credit on the statement",
+		}
+		setStatementOrder(&item, order)
+		items = append(items, item)
+		unbackedTotal.Add(unbackedTotal, ratAmount(order.Amount))
+		report.UnbackedMints++
+	}
+
+	report.CreditAmount = bankstatement.FormatAmount(creditTotal)
+	report.MatchedAmount = bankstatement.FormatAmount(matchedTotal)
+	report.UnmatchedAmount = bankstatement.FormatAmount(unmatchedTotal)
+	report.UnbackedAmount = bankstatement.FormatAmount(unbackedTotal)
+
+	if err := s.statementRepo.CreateReport(report, items); err != nil {
+		// Imported by a concurrent request in the meantime
+		if err := s.checkNotImported(fileSHA256); err != nil {
+			return nil, err
+		}
+		log.Printf("[ERROR] Failed to save bank statement report: %v", err)
+		return nil, errors.New("failed to save bank statement report")
+	}
+	log.Printf("🏦 Bank statement %s imported: %d matched, %d unmatched credits, %d unbacked mints",
+		fileName, report.Matched, report.UnmatchedCredits, report.UnbackedMints)
+
+	return newBankStatementReportResponse(report, items), nil
+}
+
+// checkNotImported fails with ErrStatementAlreadyImported when a report of the file exists
+func (s *BankStatementService) checkNotImported(fileSHA256 string) error {
+	existing, err := s.statementRepo.GetReportBySHA256(fileSHA256)
+	if err == nil {
+		return fmt.Errorf("%w as report %d", ErrStatementAlreadyImported, existing.ID)
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Printf("[ERROR] Failed to look up bank statement report: %v", err)
+		return errors.New("failed to check earlier imports")
+	}
+	return nil
+}
+
+// GetReport returns an imported statement report with its items
+func (s *BankStatementService) GetReport(id int64) (*response.BankStatementReportResponse, error) {
+	report, err := s.statementRepo.GetReportByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrStatementReportNotFound
+		}
+		return nil, err
+	}
+
+	items, err := s.statementRepo.GetItems(report.ID)
+	if err != nil {
+		return nil, err
+	}
+	return newBankStatementReportResponse(report, items), nil
+}
+
+// ListReports lists imported statement reports, newest first
+func (s *BankStatementService) ListReports(page, limit int) (*response.BankStatementReportListResponse, error) {
+	reports, totalCount, err := s.statementRepo.GetReports(page, limit)
+	if err != nil {
+		log.Printf("[ERROR] Failed to get bank statement reports: %v", err)
+		return nil, errors.New("failed to retrieve bank statement reports")
+	}
+
+	return &response.BankStatementReportListResponse{
+		Reports:    reports,
+		TotalCount: int(totalCount),
+		Page:       page,
+		Limit:      limit,
+		TotalPages: (int(totalCount) + limit - 1) / limit,
+	}, nil
+}
+
+// ExportReportCSV returns the items of a report as CSV for the auditors
+func (s *BankStatementService) ExportReportCSV(id int64) ([]byte, error) {
+	result, err := s.GetReport(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Write([]string{"kind", "line", "entry_date", "amount", "description", "bank_reference",
+		"order_reference", "order_amount", "payment_method", "paid_at", "matched_by", "reason"})
+	for _, group := range [][]models.BankStatementItem{result.Matched, result.UnmatchedCredits, result.UnbackedMints} {
+		for _, item := range group {
+			line := ""
+			if item.Line > 0 {
+				line = strconv.Itoa(item.Line)
+			}
+			w.Write([]string{item.Kind, line, formatOptionalTime(item.EntryDate), item.Amount, item.Description, item.BankReference,
+				item.OrderReference, item.OrderAmount, item.PaymentMethod, formatOptionalTime(item.PaidAt), item.MatchedBy, item.Reason})
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// match pairs credits with orders: first by the topup reference in the
+// description, then credits without one by amount and time. Each order backs
+// at most one credit.
+func (s *BankStatementService) match(credits []bankstatement.Entry, orders []models.TopupOrder, window time.Duration) []statementMatch {
+	matches := make([]statementMatch, len(credits))
+	claimed := make(map[int64]int) // Order ID -> statement line
+	byReference := make(map[string]*models.TopupOrder, len(orders))
+	for i := range orders {
+		byReference[orders[i].Reference] = &orders[i]
+	}
+
+	for i, entry := range credits {
+		matches[i].entry = entry
+		reference := findTopupReference(entry.Description + " " + entry.BankReference)
+		if reference == "" {
+			continue
+		}
+
+		order := byReference[reference]
+		if order == nil {
+			// Paid long before or after the statement, or never paid at all
+			found, err := s.orderRepo.GetByReference(reference)
+			if err != nil {
+				matches[i].reason = fmt.Sprintf("%s is not a topup order", reference)
+				continue
+			}
+			order = found
+		}
+		matches[i].order = order
+
+		switch {
+		case order.Status != models.TopupOrderStatusPaid && order.Status != models.TopupOrderStatusMinted &&
+			order.Status != models.TopupOrderStatusMintFailed:
+			matches[i].reason = fmt.Sprintf("topup order %s is %s", reference, order.Status)
+		case ratAmount(order.Amount).Cmp(ratAmount(entry.Amount)) != 0:
+			matches[i].reason = fmt.Sprintf("topup order %s is %s IDR", reference, order.Amount)
+		case claimed[order.ID] != 0:
+			matches[i].reason = fmt.Sprintf("topup order %s is already backed by line %d", reference, claimed[order.ID])
+		default:
+			matches[i].matchedBy = models.StatementMatchReference
+			claimed[order.ID] = entry.Line
+		}
+	}
+
+	// Credits without a reference pair by amount and time only when the pairing
+	// is unambiguous: one candidate order for the credit, and no other credit that
+	// could back that order. Anything else is left for a human with the reason.
+	candidates := make(map[int][]*models.TopupOrder) // Index in matches -> orders
+	contenders := make(map[int64][]int)              // Order ID -> statement lines
+	for i := range matches {
+		m := &matches[i]
+		if m.order != nil || m.reason != "" {
+			continue
+		}
+		for j := range orders {
+			order := &orders[j]
+			if claimed[order.ID] != 0 || ratAmount(order.Amount).Cmp(ratAmount(m.entry.Amount)) != 0 {
+				continue
+			}
+			if bookingDistance(m.entry, *order.PaidAt) <= window {
+				candidates[i] = append(candidates[i], order)
+				contenders[order.ID] = append(contenders[order.ID], m.entry.Line)
+			}
+		}
+	}
+
+	windowText := strings.TrimSuffix(window.String(), "0m0s")
+	for i := range matches {
+		m := &matches[i]
+		if m.order != nil || m.reason != "" {
+			continue
+		}
+
+		switch found := candidates[i]; {
+		case len(found) == 0:
+			m.reason = fmt.Sprintf("no topup order of %s IDR paid within %s", m.entry.Amount, windowText)
+		case len(found) > 1:
+			m.reason = fmt.Sprintf("%d topup orders of %s IDR paid within %s, needs the topup reference", len(found), m.entry.Amount, windowText)
+		case len(contenders[found[0].ID]) > 1:
+			m.reason = fmt.Sprintf("topup order %s could be backed by lines %s, needs the topup reference", found[0].Reference, joinLines(contenders[found[0].ID]))
+		default:
+			m.order = found[0]
+			m.matchedBy = models.StatementMatchAmountTime
+			claimed[found[0].ID] = m.entry.Line
+		}
+	}
+	return matches
+}
+
+// joinLines lists statement line numbers as "3, 7"
+func joinLines(lines []int) string {
+	parts := make([]string, len(lines))
+	for i, line := range lines {
+		parts[i] = strconv.Itoa(line)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// findTopupReference returns the first topup reference in text, normalized
+func findTopupReference(text string) string {
+	match := topupReferencePattern.FindStringSubmatch(strings.ToUpper(text))
+	if match == nil {
+		return ""
+	}
+	return "TOP-" + match[1] + "-" + match[2]
+}
+
+// bookingDistance is how far a payment is from a statement entry; statements
+// without times book a whole day
+func bookingDistance(entry bankstatement.Entry, paidAt time.Time) time.Duration {
+	start, end := entry.Date, entry.Date
+	if !entry.HasTime {
+		end = start.AddDate(0, 0, 1)
+	}
+	switch {
+	case paidAt.Before(start):
+		return start.Sub(paidAt)
+	case paidAt.After(end):
+		return paidAt.Sub(end)
+	}
+	return 0
+}
+
+func setStatementOrder(item *models.BankStatementItem, order *models.TopupOrder) {
+	orderID := order.ID
+	item.OrderID = &orderID
+	item.OrderReference = order.Reference
+	item.OrderAmount = order.Amount
+	item.PaymentMethod = order.PaymentMethod
+	item.PaidAt = order.PaidAt
+}
+
+// ratAmount reads a stored IDR amount; invalid amounts count as zero
+func ratAmount(amount string) *big.Rat {
+	value, ok := new(big.Rat).SetString(amount)
+	if !ok {
+		return new(big.Rat)
+	}
+	return value
+}
+
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func newBankStatementReportResponse(report *models.BankStatementReport, items []models.BankStatementItem) *response.BankStatementReportResponse {
+	result := &response.BankStatementReportResponse{
+		Report:           report,
+		Matched:          []models.BankStatementItem{},
+		UnmatchedCredits: []models.BankStatementItem{},
+		UnbackedMints:    []models.BankStatementItem{},
+	}
+	for _, item := range items {
+		switch item.Kind {
+		case models.StatementItemMatched:
+			result.Matched = append(result.Matched, item)
+		case models.StatementItemUnmatchedCredit:
+			result.UnmatchedCredits = append(result.UnmatchedCredits, item)
+		case models.StatementItemUnbackedMint:
+			result.UnbackedMints = append(result.UnbackedMints, item)
+		}
+	}
+	return result
+}
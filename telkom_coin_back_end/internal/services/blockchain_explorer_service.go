@@ -2,239 +2,102 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
 	"math/big"
-	"sort"
-	"strings"
+	"telkom_coin_back_end/config"
 	"telkom_coin_back_end/internal/dto/response"
+	"telkom_coin_back_end/internal/models"
+	"telkom_coin_back_end/internal/repository"
 	"telkom_coin_back_end/internal/web3"
-	"time"
 
-	"github.com/ethereum/go-ethereum"
-	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/ethclient"
+	"gorm.io/gorm"
 )
 
+// BlockchainExplorerService serves explorer queries from the contract_events table
+// filled by ContractEventIndexer, so no request scans the chain itself.
 type BlockchainExplorerService struct {
-	client          *ethclient.Client
+	client          web3.Backend
+	web3Client      *web3.Web3Client
+	network         config.Network
+	chainID         int64
 	contractAddress common.Address
-	contractABI     abi.ABI
+	eventRepo       *repository.ContractEventRepository
 }
 
-func NewBlockchainExplorerService() (*BlockchainExplorerService, error) {
+func NewBlockchainExplorerService(eventRepo *repository.ContractEventRepository) (*BlockchainExplorerService, error) {
 	web3Client, err := web3.NewWeb3Client()
 	if err != nil {
 		return nil, err
 	}
 
-	// Contract ABI untuk parsing events
-	contractABIString := `[
-		{
-			"anonymous": false,
-			"inputs": [
-				{"indexed": true, "name": "to", "type": "address"},
-				{"indexed": false, "name": "amount", "type": "uint256"},
-				{"indexed": true, "name": "requestId", "type": "bytes32"}
-			],
-			"name": "TokensMinted",
-			"type": "event"
-		},
-		{
-			"anonymous": false,
-			"inputs": [
-				{"indexed": true, "name": "from", "type": "address"},
-				{"indexed": false, "name": "amount", "type": "uint256"},
-				{"indexed": true, "name": "requestId", "type": "bytes32"}
-			],
-			"name": "TokensBurned",
-			"type": "event"
-		},
-		{
-			"anonymous": false,
-			"inputs": [
-				{"indexed": true, "name": "from", "type": "address"},
-				{"indexed": true, "name": "to", "type": "address"},
-				{"indexed": false, "name": "amount", "type": "uint256"},
-				{"indexed": false, "name": "note", "type": "string"}
-			],
-			"name": "PaymentProcessed",
-			"type": "event"
-		},
-		{
-			"anonymous": false,
-			"inputs": [
-				{"indexed": true, "name": "user", "type": "address"},
-				{"indexed": false, "name": "amount", "type": "uint256"},
-				{"indexed": true, "name": "requestId", "type": "bytes32"},
-				{"indexed": false, "name": "paymentProof", "type": "string"}
-			],
-			"name": "TopupRequested",
-			"type": "event"
-		},
-		{
-			"anonymous": false,
-			"inputs": [
-				{"indexed": true, "name": "user", "type": "address"},
-				{"indexed": false, "name": "amount", "type": "uint256"},
-				{"indexed": true, "name": "requestId", "type": "bytes32"},
-				{"indexed": false, "name": "bankAccount", "type": "string"}
-			],
-			"name": "WithdrawRequested",
-			"type": "event"
-		},
-		{
-			"anonymous": false,
-			"inputs": [
-				{"indexed": true, "name": "from", "type": "address"},
-				{"indexed": true, "name": "to", "type": "address"},
-				{"indexed": false, "name": "value", "type": "uint256"}
-			],
-			"name": "Transfer",
-			"type": "event"
-		}
-	]`
-
-	contractABI, err := abi.JSON(strings.NewReader(contractABIString))
-	if err != nil {
-		return nil, err
-	}
+	chainID := web3Client.GetChainID()
+	contractAddress := web3Client.GetContractAddress()
 
 	return &BlockchainExplorerService{
 		client:          web3Client.GetClient(),
-		contractAddress: web3Client.GetContractAddress(),
-		contractABI:     contractABI,
+		web3Client:      web3Client,
+		network:         web3Client.GetNetwork(),
+		chainID:         chainID,
+		contractAddress: contractAddress,
+		eventRepo:       eventRepo.ForContract(chainID, contractAddress.Hex()),
 	}, nil
 }
 
-// GetAllTransactions gets all transactions with PROPER pagination and deduplication
-func (s *BlockchainExplorerService) GetAllTransactions(fromBlock, toBlock *big.Int, page, limit int) (*response.TLCTransactionHistoryResponse, error) {
-	if fromBlock == nil {
-		fromBlock = big.NewInt(0) // Start from genesis block
-	}
-	if toBlock == nil {
-		toBlock = nil // Latest block
-	}
+// GetNetwork returns the registry entry of the network being explored
+func (s *BlockchainExplorerService) GetNetwork() config.Network {
+	return s.network
+}
 
-	// Default pagination values
-	if page <= 0 {
-		page = 1
-	}
-	if limit <= 0 {
-		limit = 10 // Default 10 items per page
-	}
+// GetChainStatus returns the connectivity of the network's RPC endpoints
+func (s *BlockchainExplorerService) GetChainStatus() web3.ChainStatus {
+	return s.web3Client.ChainStatus()
+}
 
-	// Query all events from contract
-	query := ethereum.FilterQuery{
-		FromBlock: fromBlock,
-		ToBlock:   toBlock,
-		Addresses: []common.Address{s.contractAddress},
-	}
+// GetContractAddress returns the PaymentToken address (deployed at startup on the simulated chain)
+func (s *BlockchainExplorerService) GetContractAddress() string {
+	return s.contractAddress.Hex()
+}
 
-	logs, err := s.client.FilterLogs(context.Background(), query)
-	if err != nil {
-		return nil, fmt.Errorf("failed to filter logs: %v", err)
+// GetAllTransactions gets indexed transactions (one per tx hash) with pagination
+func (s *BlockchainExplorerService) GetAllTransactions(fromBlock, toBlock *big.Int, page, limit int) (*response.TLCTransactionHistoryResponse, error) {
+	filter := repository.ContractEventFilter{}
+	if fromBlock != nil {
+		from := fromBlock.Uint64()
+		filter.FromBlock = &from
 	}
-
-	var allTransactions []response.TLCTransactionItem
-	processedTx := make(map[string]bool)
-
-	// Group logs by transaction hash untuk deteksi duplikat
-	txLogs := make(map[string][]types.Log)
-	for _, vLog := range logs {
-		txHash := vLog.TxHash.Hex()
-		txLogs[txHash] = append(txLogs[txHash], vLog)
+	if toBlock != nil {
+		to := toBlock.Uint64()
+		filter.ToBlock = &to
 	}
 
-	// Process each transaction (deduplication)
-	for txHash, logsInTx := range txLogs {
-		// Skip if already processed
-		if processedTx[txHash] {
-			continue
-		}
-
-		// Prioritas: ambil event custom dulu, skip Transfer
-		var selectedLog *types.Log
-		hasCustomEvent := false
-
-		// Cari custom event terlebih dahulu (prioritas tinggi)
-		for i := range logsInTx {
-			eventSig := logsInTx[i].Topics[0].Hex()
-			
-			// Cek apakah ada custom event
-			if eventSig == s.contractABI.Events["TokensMinted"].ID.Hex() ||
-				eventSig == s.contractABI.Events["TokensBurned"].ID.Hex() ||
-				eventSig == s.contractABI.Events["PaymentProcessed"].ID.Hex() ||
-				eventSig == s.contractABI.Events["TopupRequested"].ID.Hex() ||
-				eventSig == s.contractABI.Events["WithdrawRequested"].ID.Hex() {
-				selectedLog = &logsInTx[i]
-				hasCustomEvent = true
-				break
-			}
-		}
-
-		// Jika tidak ada custom event, gunakan Transfer event saja
-		if !hasCustomEvent {
-			for i := range logsInTx {
-				if logsInTx[i].Topics[0].Hex() == s.contractABI.Events["Transfer"].ID.Hex() {
-					selectedLog = &logsInTx[i]
-					break
-				}
-			}
-		}
+	return s.findTransactions(filter, page, limit)
+}
 
-		// Parse selected log (hanya 1 per transaksi)
-		if selectedLog != nil {
-			tx, err := s.parseLogToTransaction(*selectedLog)
-			if err == nil && tx != nil {
-				allTransactions = append(allTransactions, *tx)
-				processedTx[txHash] = true
-			}
-		}
+// GetTransactionsByAddress gets all transactions for a specific address with pagination
+func (s *BlockchainExplorerService) GetTransactionsByAddress(address string, page, limit int) (*response.TLCTransactionHistoryResponse, error) {
+	if !common.IsHexAddress(address) {
+		return nil, errors.New("invalid address format")
 	}
 
-	// Sort by block number and transaction hash (newest first)
-	sort.Slice(allTransactions, func(i, j int) bool {
-		if allTransactions[i].BlockNumber == allTransactions[j].BlockNumber {
-			return allTransactions[i].TxHash > allTransactions[j].TxHash
-		}
-		return allTransactions[i].BlockNumber > allTransactions[j].BlockNumber
-	})
-
-	// Calculate pagination
-	totalCount := len(allTransactions)
-
-	// Apply pagination
-	startIdx := (page - 1) * limit
-	endIdx := startIdx + limit
-
-	if startIdx >= totalCount {
-		// Page out of range
-		return &response.TLCTransactionHistoryResponse{
-			Transactions: []response.TLCTransactionItem{},
-			TotalCount:   totalCount,
-			Page:         page,
-			Limit:        limit,
-		}, nil
+	filter := repository.ContractEventFilter{
+		Address: common.HexToAddress(address).Hex(),
 	}
+	return s.findTransactions(filter, page, limit)
+}
 
-	if endIdx > totalCount {
-		endIdx = totalCount
+// GetTransactionsByType gets all transactions of one type with pagination
+func (s *BlockchainExplorerService) GetTransactionsByType(txType string, page, limit int) (*response.TLCTransactionHistoryResponse, error) {
+	filter := repository.ContractEventFilter{
+		TxType: txType,
 	}
-
-	paginatedTransactions := allTransactions[startIdx:endIdx]
-
-	return &response.TLCTransactionHistoryResponse{
-		Transactions: paginatedTransactions,
-		TotalCount:   totalCount,
-		Page:         page,
-		Limit:        limit,
-	}, nil
+	return s.findTransactions(filter, page, limit)
 }
 
-// GetTransactionsByAddress gets all transactions for a specific address with pagination
-func (s *BlockchainExplorerService) GetTransactionsByAddress(address string, page, limit int) (*response.TLCTransactionHistoryResponse, error) {
+// findTransactions runs a paginated query over primary indexed events
+func (s *BlockchainExplorerService) findTransactions(filter repository.ContractEventFilter, page, limit int) (*response.TLCTransactionHistoryResponse, error) {
 	// Default pagination values
 	if page <= 0 {
 		page = 1
@@ -243,46 +106,19 @@ func (s *BlockchainExplorerService) GetTransactionsByAddress(address string, pag
 		limit = 10
 	}
 
-	// Get all transactions first (without pagination)
-	allTx, err := s.GetAllTransactions(nil, nil, 1, 999999) // Get all data
+	events, totalCount, err := s.eventRepo.FindPrimary(filter, limit, (page-1)*limit)
 	if err != nil {
-		return nil, err
-	}
-
-	var userTransactions []response.TLCTransactionItem
-
-	// Filter transactions involving this address
-	for _, tx := range allTx.Transactions {
-		if strings.EqualFold(tx.FromAddress, address) || strings.EqualFold(tx.ToAddress, address) {
-			userTransactions = append(userTransactions, tx)
-		}
-	}
-
-	// Calculate pagination
-	totalCount := len(userTransactions)
-
-	// Apply pagination
-	startIdx := (page - 1) * limit
-	endIdx := startIdx + limit
-
-	if startIdx >= totalCount {
-		return &response.TLCTransactionHistoryResponse{
-			Transactions: []response.TLCTransactionItem{},
-			TotalCount:   totalCount,
-			Page:         page,
-			Limit:        limit,
-		}, nil
+		return nil, fmt.Errorf("failed to query indexed transactions: %v", err)
 	}
 
-	if endIdx > totalCount {
-		endIdx = totalCount
+	transactions := make([]response.TLCTransactionItem, 0, len(events))
+	for _, event := range events {
+		transactions = append(transactions, eventToTransactionItem(event))
 	}
 
-	paginatedTransactions := userTransactions[startIdx:endIdx]
-
 	return &response.TLCTransactionHistoryResponse{
-		Transactions: paginatedTransactions,
-		TotalCount:   totalCount,
+		Transactions: transactions,
+		TotalCount:   int(totalCount),
 		Page:         page,
 		Limit:        limit,
 	}, nil
@@ -290,377 +126,114 @@ func (s *BlockchainExplorerService) GetTransactionsByAddress(address string, pag
 
 // GetTransactionByHash gets specific transaction by hash
 func (s *BlockchainExplorerService) GetTransactionByHash(txHash string) (*response.TLCTransactionItem, error) {
-	hash := common.HexToHash(txHash)
-
-	// Get transaction receipt
-	receipt, err := s.client.TransactionReceipt(context.Background(), hash)
+	event, err := s.eventRepo.GetPrimaryByHash(common.HexToHash(txHash).Hex())
 	if err != nil {
-		return nil, fmt.Errorf("transaction not found: %v", err)
-	}
-
-	// Parse logs in this transaction (prioritas custom event)
-	var selectedLog *types.Log
-	hasCustomEvent := false
-
-	// Cari custom event dulu
-	for i := range receipt.Logs {
-		if receipt.Logs[i].Address == s.contractAddress {
-			eventSig := receipt.Logs[i].Topics[0].Hex()
-			
-			if eventSig == s.contractABI.Events["TokensMinted"].ID.Hex() ||
-				eventSig == s.contractABI.Events["TokensBurned"].ID.Hex() ||
-				eventSig == s.contractABI.Events["PaymentProcessed"].ID.Hex() ||
-				eventSig == s.contractABI.Events["TopupRequested"].ID.Hex() ||
-				eventSig == s.contractABI.Events["WithdrawRequested"].ID.Hex() {
-				selectedLog = receipt.Logs[i]
-				hasCustomEvent = true
-				break
-			}
-		}
-	}
-
-	// Kalau tidak ada custom event, cari Transfer
-	if !hasCustomEvent {
-		for i := range receipt.Logs {
-			if receipt.Logs[i].Address == s.contractAddress {
-				if receipt.Logs[i].Topics[0].Hex() == s.contractABI.Events["Transfer"].ID.Hex() {
-					selectedLog = receipt.Logs[i]
-					break
-				}
-			}
-		}
-	}
-
-	// Parse log terpilih
-	if selectedLog != nil {
-		tx, err := s.parseLogToTransaction(*selectedLog)
-		if err == nil && tx != nil {
-			return tx, nil
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("no TLC transaction found in hash %s", txHash)
 		}
-	}
-
-	return nil, fmt.Errorf("no TLC transaction found in hash %s", txHash)
-}
-
-// parseLogToTransaction converts blockchain log to transaction item
-func (s *BlockchainExplorerService) parseLogToTransaction(vLog types.Log) (*response.TLCTransactionItem, error) {
-	// Get block info for timestamp
-	block, err := s.client.BlockByNumber(context.Background(), big.NewInt(int64(vLog.BlockNumber)))
-	if err != nil {
 		return nil, err
 	}
 
-	timestamp := time.Unix(int64(block.Time()), 0)
-
-	// Parse different event types
-	switch vLog.Topics[0].Hex() {
-	case s.contractABI.Events["TokensMinted"].ID.Hex():
-		return s.parseTokensMinted(vLog, timestamp)
-	case s.contractABI.Events["TokensBurned"].ID.Hex():
-		return s.parseTokensBurned(vLog, timestamp)
-	case s.contractABI.Events["PaymentProcessed"].ID.Hex():
-		return s.parsePaymentProcessed(vLog, timestamp)
-	case s.contractABI.Events["TopupRequested"].ID.Hex():
-		return s.parseTopupRequested(vLog, timestamp)
-	case s.contractABI.Events["WithdrawRequested"].ID.Hex():
-		return s.parseWithdrawRequested(vLog, timestamp)
-	case s.contractABI.Events["Transfer"].ID.Hex():
-		return s.parseTransfer(vLog, timestamp)
-	}
-
-	return nil, fmt.Errorf("unknown event type")
+	item := eventToTransactionItem(*event)
+	return &item, nil
 }
 
-// Parse TokensMinted event
-func (s *BlockchainExplorerService) parseTokensMinted(vLog types.Log, timestamp time.Time) (*response.TLCTransactionItem, error) {
-	event := struct {
-		To        common.Address
-		Amount    *big.Int
-		RequestId [32]byte
-	}{}
-
-	err := s.contractABI.UnpackIntoInterface(&event, "TokensMinted", vLog.Data)
-	if err != nil {
-		return nil, err
-	}
-
-	// Get indexed parameter (to address) from topics
-	if len(vLog.Topics) > 1 {
-		event.To = common.HexToAddress(vLog.Topics[1].Hex())
-	}
-
-	// Convert amount from wei to TLC
-	divisor := new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
-	amountTLC := new(big.Int).Div(event.Amount, divisor)
-
-	return &response.TLCTransactionItem{
-		TxHash:      vLog.TxHash.Hex(),
-		BlockNumber: vLog.BlockNumber,
-		FromAddress: "0x0000000000000000000000000000000000000000",
-		ToAddress:   event.To.Hex(),
-		Amount:      amountTLC.String(),
-		AmountWei:   event.Amount.String(),
-		Type:        "topup",
-		Status:      "confirmed",
-		GasUsed:     0,
-		GasPrice:    "0",
-		Memo:        fmt.Sprintf("Tokens minted - Request ID: %x", event.RequestId),
-		Timestamp:   timestamp,
-	}, nil
+// GetTransactionTypeCounts counts indexed transactions per type
+func (s *BlockchainExplorerService) GetTransactionTypeCounts() (map[string]int64, error) {
+	return s.eventRepo.CountPrimaryByType()
 }
 
-// Parse TokensBurned event
-func (s *BlockchainExplorerService) parseTokensBurned(vLog types.Log, timestamp time.Time) (*response.TLCTransactionItem, error) {
-	event := struct {
-		From      common.Address
-		Amount    *big.Int
-		RequestId [32]byte
-	}{}
-
-	err := s.contractABI.UnpackIntoInterface(&event, "TokensBurned", vLog.Data)
+func (s *BlockchainExplorerService) GetBlockchainStats() (*response.BlockchainStatsResponse, error) {
+	totalMintedWei, err := s.eventRepo.SumAmountsByEvent("TokensMinted")
 	if err != nil {
-		return nil, err
-	}
-
-	// Get indexed parameter (from address) from topics
-	if len(vLog.Topics) > 1 {
-		event.From = common.HexToAddress(vLog.Topics[1].Hex())
+		return nil, fmt.Errorf("failed to sum minted tokens: %v", err)
 	}
 
-	// Convert amount from wei to TLC
-	divisor := new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
-	amountTLC := new(big.Int).Div(event.Amount, divisor)
-
-	return &response.TLCTransactionItem{
-		TxHash:      vLog.TxHash.Hex(),
-		BlockNumber: vLog.BlockNumber,
-		FromAddress: event.From.Hex(),
-		ToAddress:   "0x0000000000000000000000000000000000000000",
-		Amount:      amountTLC.String(),
-		AmountWei:   event.Amount.String(),
-		Type:        "withdraw",
-		Status:      "confirmed",
-		GasUsed:     0,
-		GasPrice:    "0",
-		Memo:        fmt.Sprintf("Tokens burned - Request ID: %x", event.RequestId),
-		Timestamp:   timestamp,
-	}, nil
-}
-
-// Parse PaymentProcessed event
-func (s *BlockchainExplorerService) parsePaymentProcessed(vLog types.Log, timestamp time.Time) (*response.TLCTransactionItem, error) {
-	event := struct {
-		From   common.Address
-		To     common.Address
-		Amount *big.Int
-		Note   string
-	}{}
-
-	err := s.contractABI.UnpackIntoInterface(&event, "PaymentProcessed", vLog.Data)
+	totalBurnedWei, err := s.eventRepo.SumAmountsByEvent("TokensBurned")
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to sum burned tokens: %v", err)
 	}
 
-	// Get indexed parameters from topics
-	if len(vLog.Topics) > 1 {
-		event.From = common.HexToAddress(vLog.Topics[1].Hex())
+	counts, err := s.eventRepo.CountPrimaryByType()
+	if err != nil {
+		return nil, fmt.Errorf("failed to count transactions: %v", err)
 	}
-	if len(vLog.Topics) > 2 {
-		event.To = common.HexToAddress(vLog.Topics[2].Hex())
+	var totalTransactions int64
+	for _, count := range counts {
+		totalTransactions += count
 	}
 
-	// Convert amount from wei to TLC
-	divisor := new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
-	amountTLC := new(big.Int).Div(event.Amount, divisor)
-
-	return &response.TLCTransactionItem{
-		TxHash:      vLog.TxHash.Hex(),
-		BlockNumber: vLog.BlockNumber,
-		FromAddress: event.From.Hex(),
-		ToAddress:   event.To.Hex(),
-		Amount:      amountTLC.String(),
-		AmountWei:   event.Amount.String(),
-		Type:        "transfer",
-		Status:      "confirmed",
-		GasUsed:     0,
-		GasPrice:    "0",
-		Memo:        event.Note,
-		Timestamp:   timestamp,
-	}, nil
-}
-
-// Parse TopupRequested event
-func (s *BlockchainExplorerService) parseTopupRequested(vLog types.Log, timestamp time.Time) (*response.TLCTransactionItem, error) {
-	event := struct {
-		User         common.Address
-		Amount       *big.Int
-		RequestId    [32]byte
-		PaymentProof string
-	}{}
-
-	err := s.contractABI.UnpackIntoInterface(&event, "TopupRequested", vLog.Data)
+	totalAddresses, err := s.eventRepo.CountUniqueAddresses()
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to count addresses: %v", err)
 	}
 
-	// Get indexed parameter (user address) from topics
-	if len(vLog.Topics) > 1 {
-		event.User = common.HexToAddress(vLog.Topics[1].Hex())
+	// Get latest block number; indexed stats are still served while the node is unreachable
+	latestBlock, err := s.client.BlockNumber(context.Background())
+	if err != nil {
+		log.Printf("[WARN] Explorer stats without latest block: %v", err)
+		latestBlock = 0
 	}
 
-	// Convert amount from wei to TLC
-	divisor := new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
-	amountTLC := new(big.Int).Div(event.Amount, divisor)
-
-	return &response.TLCTransactionItem{
-		TxHash:      vLog.TxHash.Hex(),
-		BlockNumber: vLog.BlockNumber,
-		FromAddress: "0x0000000000000000000000000000000000000000",
-		ToAddress:   event.User.Hex(),
-		Amount:      amountTLC.String(),
-		AmountWei:   event.Amount.String(),
-		Type:        "topup",
-		Status:      "pending",
-		GasUsed:     0,
-		GasPrice:    "0",
-		Memo:        fmt.Sprintf("Topup request - Proof: %s", event.PaymentProof),
-		Timestamp:   timestamp,
-	}, nil
-}
-
-// Parse WithdrawRequested event
-func (s *BlockchainExplorerService) parseWithdrawRequested(vLog types.Log, timestamp time.Time) (*response.TLCTransactionItem, error) {
-	event := struct {
-		User        common.Address
-		Amount      *big.Int
-		RequestId   [32]byte
-		BankAccount string
-	}{}
-
-	err := s.contractABI.UnpackIntoInterface(&event, "WithdrawRequested", vLog.Data)
+	var lastIndexedBlock uint64
+	checkpoint, err := s.eventRepo.GetCheckpoint(paymentTokenCheckpointName(s.chainID, s.contractAddress))
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to get indexer checkpoint: %v", err)
 	}
-
-	// Get indexed parameter (user address) from topics
-	if len(vLog.Topics) > 1 {
-		event.User = common.HexToAddress(vLog.Topics[1].Hex())
+	if checkpoint != nil {
+		lastIndexedBlock = checkpoint.LastIndexedBlock
 	}
 
 	// Convert amount from wei to TLC
 	divisor := new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
-	amountTLC := new(big.Int).Div(event.Amount, divisor)
-
-	return &response.TLCTransactionItem{
-		TxHash:      vLog.TxHash.Hex(),
-		BlockNumber: vLog.BlockNumber,
-		FromAddress: event.User.Hex(),
-		ToAddress:   "0x0000000000000000000000000000000000000000",
-		Amount:      amountTLC.String(),
-		AmountWei:   event.Amount.String(),
-		Type:        "withdraw",
-		Status:      "confirmed",
-		GasUsed:     0,
-		GasPrice:    "0",
-		Memo:        fmt.Sprintf("Withdrawal to: %s", event.BankAccount),
-		Timestamp:   timestamp,
+	totalMinted := new(big.Int).Div(totalMintedWei, divisor)
+	totalBurned := new(big.Int).Div(totalBurnedWei, divisor)
+	circulatingSupply := new(big.Int).Sub(totalMinted, totalBurned)
+
+	return &response.BlockchainStatsResponse{
+		TotalMinted:       totalMinted.String(),
+		TotalBurned:       totalBurned.String(),
+		CirculatingSupply: circulatingSupply.String(),
+		MaxSupply:         "Unlimited",
+		TotalTransactions: int(totalTransactions),
+		TotalAddresses:    int(totalAddresses),
+		LatestBlock:       latestBlock,
+		LastIndexedBlock:  lastIndexedBlock,
 	}, nil
 }
 
-// Parse Transfer event (standard ERC20)
-func (s *BlockchainExplorerService) parseTransfer(vLog types.Log, timestamp time.Time) (*response.TLCTransactionItem, error) {
-	if len(vLog.Topics) < 3 {
-		return nil, fmt.Errorf("invalid transfer event")
+// GetAddressFlow sums the tokens an address has received and sent according to indexed Transfer events
+func (s *BlockchainExplorerService) GetAddressFlow(address string) (received, sent *big.Int, err error) {
+	if !common.IsHexAddress(address) {
+		return nil, nil, errors.New("invalid address format")
 	}
+	normalized := common.HexToAddress(address).Hex()
 
-	from := common.HexToAddress(vLog.Topics[1].Hex())
-	to := common.HexToAddress(vLog.Topics[2].Hex())
-
-	// Amount is in data
-	amount := new(big.Int).SetBytes(vLog.Data)
-
-	// Convert amount from wei to TLC
-	divisor := new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
-	amountTLC := new(big.Int).Div(amount, divisor)
-
-	// Skip zero transfers
-	if amount.Cmp(big.NewInt(0)) == 0 {
-		return nil, fmt.Errorf("zero transfer")
+	received, err = s.eventRepo.SumTransferAmounts(normalized, true)
+	if err != nil {
+		return nil, nil, err
 	}
-
-	txType := "transfer"
-	if from.Hex() == "0x0000000000000000000000000000000000000000" {
-		txType = "mint"
-	} else if to.Hex() == "0x0000000000000000000000000000000000000000" {
-		txType = "burn"
+	sent, err = s.eventRepo.SumTransferAmounts(normalized, false)
+	if err != nil {
+		return nil, nil, err
 	}
+	return received, sent, nil
+}
 
-	return &response.TLCTransactionItem{
-		TxHash:      vLog.TxHash.Hex(),
-		BlockNumber: vLog.BlockNumber,
-		FromAddress: from.Hex(),
-		ToAddress:   to.Hex(),
-		Amount:      amountTLC.String(),
-		AmountWei:   amount.String(),
-		Type:        txType,
-		Status:      "confirmed",
+// eventToTransactionItem converts an indexed event to the explorer response item
+func eventToTransactionItem(event models.ContractEvent) response.TLCTransactionItem {
+	return response.TLCTransactionItem{
+		TxHash:      event.TxHash,
+		BlockNumber: event.BlockNumber,
+		FromAddress: event.FromAddress,
+		ToAddress:   event.ToAddress,
+		Amount:      event.Amount,
+		AmountWei:   event.AmountWei,
+		Type:        event.TxType,
+		Status:      event.Status,
 		GasUsed:     0,
 		GasPrice:    "0",
-		Memo:        "Standard transfer",
-		Timestamp:   timestamp,
-	}, nil
-}
-
-func (s *BlockchainExplorerService) GetBlockchainStats() (*response.BlockchainStatsResponse, error) {
-	// Get all transactions (no pagination untuk akurasi stats)
-	allTx, err := s.GetAllTransactions(nil, nil, 1, 999999)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch transactions: %v", err)
+		Memo:        event.Memo,
+		Timestamp:   event.BlockTime,
 	}
-
-	var totalMinted big.Int
-	var totalBurned big.Int
-	uniqueAddresses := make(map[string]bool)
-
-	// Calculate stats from all transactions
-	for _, tx := range allTx.Transactions {
-		// Parse amount to big.Int
-		amount := new(big.Int)
-		amount.SetString(tx.Amount, 10)
-
-		switch tx.Type {
-		case "mint":
-			totalMinted.Add(&totalMinted, amount)
-		case "burn":
-			totalBurned.Add(&totalBurned, amount)
-		}
-
-		// Track unique addresses (exclude zero address)
-		zeroAddress := "0x0000000000000000000000000000000000000000"
-		if tx.FromAddress != zeroAddress {
-			uniqueAddresses[strings.ToLower(tx.FromAddress)] = true
-		}
-		if tx.ToAddress != zeroAddress {
-			uniqueAddresses[strings.ToLower(tx.ToAddress)] = true
-		}
-	}
-
-	// Calculate circulating supply
-	circulatingSupply := new(big.Int).Sub(&totalMinted, &totalBurned)
-
-	// Get latest block number
-	latestBlock, err := s.client.BlockNumber(context.Background())
-	if err != nil {
-		return nil, fmt.Errorf("failed to get latest block: %v", err)
-	}
-
-	return &response.BlockchainStatsResponse{
-		TotalMinted:       totalMinted.String(),
-		TotalBurned:       totalBurned.String(),
-		CirculatingSupply: circulatingSupply.String(),
-		MaxSupply:         "Unlimited",
-		TotalTransactions: allTx.TotalCount,
-		TotalAddresses:    len(uniqueAddresses),
-		LatestBlock:       latestBlock,
-	}, nil
-}
\ No newline at end of file
+}
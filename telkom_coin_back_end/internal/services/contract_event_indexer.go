@@ -0,0 +1,461 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+	"sync"
+	"telkom_coin_back_end/config"
+	"telkom_coin_back_end/internal/models"
+	"telkom_coin_back_end/internal/repository"
+	"telkom_coin_back_end/internal/web3"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	zeroAddressHex = "0x0000000000000000000000000000000000000000"
+
+	// Indexed blocks checked for the fork point after a reorg
+	indexerReorgScanBlocks = 256
+)
+
+// indexedEvents are the PaymentToken events pulled by the indexer
+var indexedEvents = []string{
+	"TokensMinted",
+	"TokensBurned",
+	"PaymentProcessed",
+	"TopupRequested",
+	"WithdrawRequested",
+	"Transfer",
+}
+
+// ContractEventIndexer pulls PaymentToken logs in block ranges into contract_events
+type ContractEventIndexer struct {
+	client          web3.Backend
+	contractAddress common.Address
+	contractABI     *abi.ABI
+	filterer        *web3.PaymentTokenFilterer
+	eventRepo       *repository.ContractEventRepository
+	checkpointName  string
+	confirmations   uint64
+	startBlock      uint64
+	batchSize       uint64
+	pollInterval    time.Duration
+
+	stopOnce sync.Once
+	stop     chan struct{}
+}
+
+func NewContractEventIndexer(eventRepo *repository.ContractEventRepository) (*ContractEventIndexer, error) {
+	web3Client, err := web3.NewWeb3Client()
+	if err != nil {
+		return nil, err
+	}
+
+	contractABI, err := web3.PaymentTokenMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	chainID := web3Client.GetChainID()
+	contractAddress := web3Client.GetContractAddress()
+	filterer, err := web3.NewPaymentTokenFilterer(contractAddress, web3Client.GetClient())
+	if err != nil {
+		return nil, err
+	}
+
+	return &ContractEventIndexer{
+		client:          web3Client.GetClient(),
+		contractAddress: contractAddress,
+		contractABI:     contractABI,
+		filterer:        filterer,
+		eventRepo:       eventRepo.ForContract(chainID, contractAddress.Hex()),
+		checkpointName:  paymentTokenCheckpointName(chainID, contractAddress),
+		confirmations:   web3Client.GetNetwork().Confirmations,
+		startBlock:      config.AppConfig.IndexerStartBlock,
+		batchSize:       config.AppConfig.IndexerBatchSize,
+		pollInterval:    config.AppConfig.IndexerPollInterval,
+		stop:            make(chan struct{}),
+	}, nil
+}
+
+// paymentTokenCheckpointName is the checkpoint key for a PaymentToken deployment
+func paymentTokenCheckpointName(chainID int64, contractAddress common.Address) string {
+	return fmt.Sprintf("payment_token:%d:%s", chainID, strings.ToLower(contractAddress.Hex()))
+}
+
+// Start runs the indexer loop in the background until Stop is called
+func (s *ContractEventIndexer) Start() {
+	go func() {
+		log.Printf("🔎 Contract event indexer started for %s", s.contractAddress.Hex())
+
+		ticker := time.NewTicker(s.pollInterval)
+		defer ticker.Stop()
+
+		for {
+			if err := s.IndexPending(context.Background()); err != nil {
+				log.Printf("[ERROR] Contract event indexer: %v", err)
+			}
+
+			select {
+			case <-s.stop:
+				log.Println("Contract event indexer stopped")
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop signals the indexer loop to exit
+func (s *ContractEventIndexer) Stop() {
+	s.stopOnce.Do(func() { close(s.stop) })
+}
+
+// IndexPending indexes every block between the checkpoint and the chain head,
+// leaving out the last blocks that do not have the network's confirmation depth yet.
+// The checkpoint block must still have the hash it was indexed with; otherwise a
+// reorg replaced it and the orphaned events are dropped first.
+func (s *ContractEventIndexer) IndexPending(ctx context.Context) error {
+	checkpoint, err := s.eventRepo.GetCheckpoint(s.checkpointName)
+	if err != nil {
+		return fmt.Errorf("failed to load checkpoint: %v", err)
+	}
+
+	fromBlock := s.startBlock
+	if checkpoint != nil {
+		lastBlock, err := s.checkReorg(ctx, checkpoint)
+		if err != nil {
+			return err
+		}
+		fromBlock = lastBlock + 1
+	}
+
+	head, err := s.client.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get latest block: %v", err)
+	}
+	if head < s.confirmations {
+		return nil
+	}
+	head -= s.confirmations
+
+	for fromBlock <= head {
+		toBlock := fromBlock + s.batchSize - 1
+		if toBlock > head {
+			toBlock = head
+		}
+
+		count, err := s.indexRange(ctx, fromBlock, toBlock)
+		if err != nil {
+			return fmt.Errorf("failed to index blocks %d-%d: %v", fromBlock, toBlock, err)
+		}
+		if count > 0 {
+			log.Printf("🔎 Indexed %d events from blocks %d-%d", count, fromBlock, toBlock)
+		}
+
+		fromBlock = toBlock + 1
+	}
+
+	return nil
+}
+
+// checkReorg compares the checkpoint block with the chain and returns the last
+// block that is still canonical. After a reorg it walks back over the blocks
+// with indexed events to the newest one whose hash still matches, and rewinds
+// the events and the checkpoint to it.
+func (s *ContractEventIndexer) checkReorg(ctx context.Context, checkpoint *models.IndexerCheckpoint) (uint64, error) {
+	// Checkpoints written before the hash was stored are taken as they are
+	if checkpoint.LastIndexedHash == "" {
+		return checkpoint.LastIndexedBlock, nil
+	}
+
+	hash, err := s.blockHash(ctx, checkpoint.LastIndexedBlock)
+	if err != nil {
+		return 0, err
+	}
+	if hash == checkpoint.LastIndexedHash {
+		return checkpoint.LastIndexedBlock, nil
+	}
+
+	blocks, err := s.eventRepo.GetBlockHashes(checkpoint.LastIndexedBlock, indexerReorgScanBlocks)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load indexed blocks: %v", err)
+	}
+
+	// Without a matching block, everything scanned is orphaned
+	var lastBlock uint64
+	switch {
+	case len(blocks) > 0 && blocks[len(blocks)-1].BlockNumber > 0:
+		lastBlock = blocks[len(blocks)-1].BlockNumber - 1
+	case len(blocks) == 0 && checkpoint.LastIndexedBlock > indexerReorgScanBlocks:
+		lastBlock = checkpoint.LastIndexedBlock - indexerReorgScanBlocks
+	}
+	for _, block := range blocks {
+		hash, err := s.blockHash(ctx, block.BlockNumber)
+		if err != nil {
+			return 0, err
+		}
+		if hash == block.BlockHash {
+			lastBlock = block.BlockNumber
+			break
+		}
+	}
+
+	lastHash, err := s.blockHash(ctx, lastBlock)
+	if err != nil {
+		return 0, err
+	}
+	if err := s.eventRepo.Rewind(s.checkpointName, lastBlock, lastHash); err != nil {
+		return 0, fmt.Errorf("failed to rewind to block %d: %v", lastBlock, err)
+	}
+	log.Printf("[WARN] Reorg detected at block %d, contract events rewound to block %d", checkpoint.LastIndexedBlock, lastBlock)
+	return lastBlock, nil
+}
+
+// blockHash returns the hash of a block on the current chain
+func (s *ContractEventIndexer) blockHash(ctx context.Context, number uint64) (string, error) {
+	header, err := s.client.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+	if err != nil {
+		return "", fmt.Errorf("failed to get block %d: %v", number, err)
+	}
+	return header.Hash().Hex(), nil
+}
+
+// indexRange fetches, parses and stores the logs of one block range
+func (s *ContractEventIndexer) indexRange(ctx context.Context, fromBlock, toBlock uint64) (int, error) {
+	eventIDs := make([]common.Hash, 0, len(indexedEvents))
+	for _, name := range indexedEvents {
+		eventIDs = append(eventIDs, s.contractABI.Events[name].ID)
+	}
+
+	query := ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(fromBlock),
+		ToBlock:   new(big.Int).SetUint64(toBlock),
+		Addresses: []common.Address{s.contractAddress},
+		Topics:    [][]common.Hash{eventIDs},
+	}
+
+	logs, err := s.client.FilterLogs(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to filter logs: %v", err)
+	}
+
+	var events []models.ContractEvent
+	blockTimes := make(map[uint64]time.Time)
+	primarySet := make(map[string]int) // tx hash -> index in events
+	customSet := make(map[string]bool)
+
+	for _, vLog := range logs {
+		if vLog.Removed || len(vLog.Topics) == 0 {
+			continue
+		}
+
+		event, err := s.parseLog(vLog)
+		if err != nil {
+			log.Printf("[WARN] Skipping log %s#%d: %v", vLog.TxHash.Hex(), vLog.Index, err)
+			continue
+		}
+
+		blockTime, ok := blockTimes[vLog.BlockNumber]
+		if !ok {
+			header, err := s.client.HeaderByNumber(ctx, new(big.Int).SetUint64(vLog.BlockNumber))
+			if err != nil {
+				return 0, fmt.Errorf("failed to get block %d: %v", vLog.BlockNumber, err)
+			}
+			blockTime = time.Unix(int64(header.Time), 0)
+			blockTimes[vLog.BlockNumber] = blockTime
+		}
+		event.BlockTime = blockTime
+
+		// Prioritas: event custom pertama, Transfer hanya kalau tidak ada event custom
+		txHash := event.TxHash
+		isCustom := event.EventName != "Transfer"
+		idx, hasPrimary := primarySet[txHash]
+		switch {
+		case !hasPrimary:
+			event.IsPrimary = true
+			primarySet[txHash] = len(events)
+			customSet[txHash] = isCustom
+		case isCustom && !customSet[txHash]:
+			events[idx].IsPrimary = false
+			event.IsPrimary = true
+			primarySet[txHash] = len(events)
+			customSet[txHash] = true
+		}
+
+		events = append(events, *event)
+	}
+
+	lastHash, err := s.blockHash(ctx, toBlock)
+	if err != nil {
+		return 0, err
+	}
+	if err := s.eventRepo.SaveBatch(events, s.checkpointName, toBlock, lastHash); err != nil {
+		return 0, fmt.Errorf("failed to save events: %v", err)
+	}
+
+	return len(events), nil
+}
+
+// parseLog converts a PaymentToken log to a contract event row
+func (s *ContractEventIndexer) parseLog(vLog types.Log) (*models.ContractEvent, error) {
+	event := &models.ContractEvent{
+		TxHash:      vLog.TxHash.Hex(),
+		LogIndex:    vLog.Index,
+		BlockNumber: vLog.BlockNumber,
+		BlockHash:   vLog.BlockHash.Hex(),
+		Status:      "confirmed",
+	}
+
+	var err error
+	switch vLog.Topics[0] {
+	case s.contractABI.Events["TokensMinted"].ID:
+		err = s.parseTokensMinted(vLog, event)
+	case s.contractABI.Events["TokensBurned"].ID:
+		err = s.parseTokensBurned(vLog, event)
+	case s.contractABI.Events["PaymentProcessed"].ID:
+		err = s.parsePaymentProcessed(vLog, event)
+	case s.contractABI.Events["TopupRequested"].ID:
+		err = s.parseTopupRequested(vLog, event)
+	case s.contractABI.Events["WithdrawRequested"].ID:
+		err = s.parseWithdrawRequested(vLog, event)
+	case s.contractABI.Events["Transfer"].ID:
+		err = s.parseTransfer(vLog, event)
+	default:
+		err = errors.New("unknown event type")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// setAmount fills wei and whole-TLC amounts
+func setAmount(event *models.ContractEvent, amountWei *big.Int) {
+	divisor := new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
+	event.AmountWei = amountWei.String()
+	event.Amount = new(big.Int).Div(amountWei, divisor).String()
+}
+
+// Parse TokensMinted event
+func (s *ContractEventIndexer) parseTokensMinted(vLog types.Log, event *models.ContractEvent) error {
+	minted, err := s.filterer.ParseTokensMinted(vLog)
+	if err != nil {
+		return err
+	}
+
+	requestID := common.Hash(minted.RequestId)
+	event.EventName = "TokensMinted"
+	event.TxType = "topup"
+	event.FromAddress = zeroAddressHex
+	event.ToAddress = minted.To.Hex()
+	event.RequestID = requestID.Hex()
+	event.Memo = fmt.Sprintf("Tokens minted - Request ID: %x", requestID)
+	setAmount(event, minted.Amount)
+	return nil
+}
+
+// Parse TokensBurned event
+func (s *ContractEventIndexer) parseTokensBurned(vLog types.Log, event *models.ContractEvent) error {
+	burned, err := s.filterer.ParseTokensBurned(vLog)
+	if err != nil {
+		return err
+	}
+
+	requestID := common.Hash(burned.RequestId)
+	event.EventName = "TokensBurned"
+	event.TxType = "withdraw"
+	event.FromAddress = burned.From.Hex()
+	event.ToAddress = zeroAddressHex
+	event.RequestID = requestID.Hex()
+	event.Memo = fmt.Sprintf("Tokens burned - Request ID: %x", requestID)
+	setAmount(event, burned.Amount)
+	return nil
+}
+
+// Parse PaymentProcessed event
+func (s *ContractEventIndexer) parsePaymentProcessed(vLog types.Log, event *models.ContractEvent) error {
+	payment, err := s.filterer.ParsePaymentProcessed(vLog)
+	if err != nil {
+		return err
+	}
+
+	event.EventName = "PaymentProcessed"
+	event.TxType = "transfer"
+	event.FromAddress = payment.From.Hex()
+	event.ToAddress = payment.To.Hex()
+	event.Memo = payment.Note
+	setAmount(event, payment.Amount)
+	return nil
+}
+
+// Parse TopupRequested event
+func (s *ContractEventIndexer) parseTopupRequested(vLog types.Log, event *models.ContractEvent) error {
+	topup, err := s.filterer.ParseTopupRequested(vLog)
+	if err != nil {
+		return err
+	}
+
+	event.EventName = "TopupRequested"
+	event.TxType = "topup"
+	event.Status = "pending"
+	event.FromAddress = zeroAddressHex
+	event.ToAddress = topup.User.Hex()
+	event.RequestID = common.Hash(topup.RequestId).Hex()
+	event.Memo = fmt.Sprintf("Topup request - Proof: %s", topup.PaymentProof)
+	setAmount(event, topup.Amount)
+	return nil
+}
+
+// Parse WithdrawRequested event
+func (s *ContractEventIndexer) parseWithdrawRequested(vLog types.Log, event *models.ContractEvent) error {
+	withdraw, err := s.filterer.ParseWithdrawRequested(vLog)
+	if err != nil {
+		return err
+	}
+
+	event.EventName = "WithdrawRequested"
+	event.TxType = "withdraw"
+	event.FromAddress = withdraw.User.Hex()
+	event.ToAddress = zeroAddressHex
+	event.RequestID = common.Hash(withdraw.RequestId).Hex()
+	event.Memo = fmt.Sprintf("Withdrawal to: %s", withdraw.BankAccount)
+	setAmount(event, withdraw.Amount)
+	return nil
+}
+
+// Parse Transfer event (standard ERC20)
+func (s *ContractEventIndexer) parseTransfer(vLog types.Log, event *models.ContractEvent) error {
+	transfer, err := s.filterer.ParseTransfer(vLog)
+	if err != nil {
+		return err
+	}
+	if transfer.Value.Sign() == 0 {
+		return errors.New("zero transfer")
+	}
+
+	txType := "transfer"
+	if transfer.From.Hex() == zeroAddressHex {
+		txType = "mint"
+	} else if transfer.To.Hex() == zeroAddressHex {
+		txType = "burn"
+	}
+
+	event.EventName = "Transfer"
+	event.TxType = txType
+	event.FromAddress = transfer.From.Hex()
+	event.ToAddress = transfer.To.Hex()
+	event.Memo = "Standard transfer"
+	setAmount(event, transfer.Value)
+	return nil
+}
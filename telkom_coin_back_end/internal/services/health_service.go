@@ -0,0 +1,47 @@
+package service
+
+import (
+	"telkom_coin_back_end/internal/blockchain"
+	"telkom_coin_back_end/internal/dto/response"
+	"telkom_coin_back_end/internal/web3"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type HealthService struct {
+	db                *gorm.DB
+	blockchainService *blockchain.BlockchainService
+}
+
+func NewHealthService(db *gorm.DB, blockchainService *blockchain.BlockchainService) *HealthService {
+	return &HealthService{
+		db:                db,
+		blockchainService: blockchainService,
+	}
+}
+
+// Check reports database and chain connectivity. The app is down when either
+// the database or every RPC endpoint is unreachable, and degraded when only
+// some RPC endpoints are.
+func (s *HealthService) Check() *response.HealthResponse {
+	health := &response.HealthResponse{
+		Status:    web3.ChainStatusHealthy,
+		Database:  "up",
+		Chain:     s.blockchainService.ChainStatus(),
+		CheckedAt: time.Now(),
+	}
+
+	if sqlDB, err := s.db.DB(); err != nil || sqlDB.Ping() != nil {
+		health.Database = "down"
+	}
+
+	switch {
+	case health.Database == "down" || health.Chain.Status == web3.ChainStatusDown:
+		health.Status = web3.ChainStatusDown
+	case health.Chain.Status == web3.ChainStatusDegraded:
+		health.Status = web3.ChainStatusDegraded
+	}
+
+	return health
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log"
+	"sync"
+	"telkom_coin_back_end/config"
+	"telkom_coin_back_end/internal/models"
+	"telkom_coin_back_end/internal/repository"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	maxIdempotencyKeyLength  = 255
+	idempotencyCleanupPeriod = time.Hour
+)
+
+var (
+	ErrIdempotencyKeyInvalid    = errors.New("Idempotency-Key must be 1-255 printable characters")
+	ErrIdempotencyKeyInProgress = errors.New("a request with this Idempotency-Key is still being processed")
+	ErrIdempotencyKeyReused     = errors.New("Idempotency-Key was already used with a different request")
+)
+
+// IdempotencyService remembers the response of money-moving requests per
+// (user, Idempotency-Key) so retries return the first result instead of
+// minting, transferring or burning again
+type IdempotencyService struct {
+	repo *repository.IdempotencyRepository
+	ttl  time.Duration
+
+	stopOnce sync.Once
+	stop     chan struct{}
+}
+
+func NewIdempotencyService(repo *repository.IdempotencyRepository) *IdempotencyService {
+	return &IdempotencyService{
+		repo: repo,
+		ttl:  config.AppConfig.IdempotencyKeyTTL,
+		stop: make(chan struct{}),
+	}
+}
+
+// Start removes expired keys every hour until Stop is called
+func (s *IdempotencyService) Start() {
+	go func() {
+		ticker := time.NewTicker(idempotencyCleanupPeriod)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-s.stop:
+				return
+			case <-ticker.C:
+				deleted, err := s.repo.DeleteExpired(time.Now())
+				if err != nil {
+					log.Printf("[ERROR] Failed to delete expired idempotency keys: %v", err)
+				} else if deleted > 0 {
+					log.Printf("🧹 Deleted %d expired idempotency keys", deleted)
+				}
+			}
+		}
+	}()
+}
+
+// Stop signals the cleanup loop to exit
+func (s *IdempotencyService) Stop() {
+	s.stopOnce.Do(func() { close(s.stop) })
+}
+
+// Begin reserves the key for this request. When the key was used before with
+// the same request, the stored record is returned with replay=true and the
+// caller must send its response instead of running the request.
+func (s *IdempotencyService) Begin(userID int64, key, method, path string, body []byte) (record *models.IdempotencyKey, replay bool, err error) {
+	if !validIdempotencyKey(key) {
+		return nil, false, ErrIdempotencyKeyInvalid
+	}
+
+	record = &models.IdempotencyKey{
+		UserID:      userID,
+		Key:         key,
+		Method:      method,
+		Path:        path,
+		RequestHash: hashIdempotentRequest(method, path, body),
+		Status:      models.IdempotencyStatusProcessing,
+		ExpiresAt:   time.Now().Add(s.ttl),
+	}
+
+	// Second attempt only happens after an expired key was removed
+	for attempt := 0; attempt < 2; attempt++ {
+		reserved, err := s.repo.Reserve(record)
+		if err != nil {
+			return nil, false, err
+		}
+		if reserved {
+			return record, false, nil
+		}
+
+		existing, err := s.repo.GetByUserAndKey(userID, key)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, false, err
+		}
+
+		if time.Now().After(existing.ExpiresAt) {
+			if err := s.repo.Delete(existing.ID); err != nil {
+				return nil, false, err
+			}
+			continue
+		}
+		if existing.RequestHash != record.RequestHash {
+			return nil, false, ErrIdempotencyKeyReused
+		}
+		if existing.Status != models.IdempotencyStatusCompleted {
+			return nil, false, ErrIdempotencyKeyInProgress
+		}
+		return existing, true, nil
+	}
+
+	return nil, false, ErrIdempotencyKeyInProgress
+}
+
+// Complete stores the response sent for a reserved key
+func (s *IdempotencyService) Complete(record *models.IdempotencyKey, responseCode int, responseBody []byte) error {
+	return s.repo.Complete(record.ID, responseCode, string(responseBody))
+}
+
+// Release frees a reserved key without storing a response, so the request can be
+// retried with the same key (server errors and panics)
+func (s *IdempotencyService) Release(record *models.IdempotencyKey) error {
+	return s.repo.Delete(record.ID)
+}
+
+func validIdempotencyKey(key string) bool {
+	if key == "" || len(key) > maxIdempotencyKeyLength {
+		return false
+	}
+	for _, r := range key {
+		if r < 0x21 || r > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// hashIdempotentRequest hashes the request with its JSON body re-encoded, so
+// key order and whitespace do not count as a different request
+func hashIdempotentRequest(method, path string, body []byte) string {
+	canonical := bytes.TrimSpace(body)
+	var decoded interface{}
+	if len(canonical) > 0 && json.Unmarshal(canonical, &decoded) == nil {
+		if encoded, err := json.Marshal(decoded); err == nil {
+			canonical = encoded
+		}
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(method + " " + path + "\n"))
+	hash.Write(canonical)
+	return hex.EncodeToString(hash.Sum(nil))
+}
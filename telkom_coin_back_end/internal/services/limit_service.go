@@ -0,0 +1,382 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+	"sync"
+	"telkom_coin_back_end/config"
+	"telkom_coin_back_end/internal/blockchain"
+	"telkom_coin_back_end/internal/dto/response"
+	"telkom_coin_back_end/internal/models"
+	"telkom_coin_back_end/internal/repository"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	limitDailyWindow   = 24 * time.Hour
+	limitMonthlyWindow = 30 * 24 * time.Hour
+	// A reservation left by a crash stops counting after this long
+	limitReservationTTL           = 10 * time.Minute
+	limitReservationCleanupPeriod = time.Hour
+
+	LimitPeriodPerTransaction = "per_transaction"
+	LimitPeriodDaily          = "daily"
+	LimitPeriodMonthly        = "monthly"
+)
+
+var ErrLimitExceeded = errors.New("limit exceeded")
+
+// LimitExceededError says which limit an amount went over and how much of it is left
+type LimitExceededError struct {
+	Operation string `json:"operation"`
+	Period    string `json:"period"` // per_transaction, daily or monthly
+	Limit     string `json:"limit"`  // TLC
+	Remaining string `json:"remaining"`
+}
+
+func (e *LimitExceededError) Error() string {
+	if e.Period == LimitPeriodPerTransaction {
+		return fmt.Sprintf("%s limit exceeded: at most %s TLC per transaction", e.Operation, e.Limit)
+	}
+	return fmt.Sprintf("%s %s limit exceeded: %s TLC remaining of %s TLC", e.Operation, e.Period, e.Remaining, e.Limit)
+}
+
+func (e *LimitExceededError) Unwrap() error {
+	return ErrLimitExceeded
+}
+
+// LimitService enforces the per-transaction, daily and monthly limits of the
+// user's account tier (KYC status). Usage is summed over rolling windows from
+// the transactions table, or for topups from the topup orders (so paid orders
+// waiting for their mint count too), plus outbox messages not broadcast yet and
+// the limit reservations of operations still in progress. Reservations are
+// checked and saved under a lock on the user's row, so concurrent requests, on
+// any instance, can't both use the last of an allowance.
+type LimitService struct {
+	userRepo        *repository.UserRepository
+	txRepo          *repository.TransactionRepository
+	orderRepo       *repository.TopupOrderRepository
+	outboxRepo      *repository.OutboxRepository
+	reservationRepo *repository.LimitReservationRepository
+
+	userLocks sync.Map // user ID -> *sync.Mutex, one Reserve per user at a time in this process
+
+	stopOnce sync.Once
+	stop     chan struct{}
+}
+
+func NewLimitService(
+	userRepo *repository.UserRepository,
+	txRepo *repository.TransactionRepository,
+	orderRepo *repository.TopupOrderRepository,
+	outboxRepo *repository.OutboxRepository,
+	reservationRepo *repository.LimitReservationRepository,
+) *LimitService {
+	return &LimitService{
+		userRepo:        userRepo,
+		txRepo:          txRepo,
+		orderRepo:       orderRepo,
+		outboxRepo:      outboxRepo,
+		reservationRepo: reservationRepo,
+		stop:            make(chan struct{}),
+	}
+}
+
+// Start removes expired limit reservations every hour until Stop is called
+func (s *LimitService) Start() {
+	go func() {
+		ticker := time.NewTicker(limitReservationCleanupPeriod)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-s.stop:
+				return
+			case <-ticker.C:
+				deleted, err := s.reservationRepo.DeleteExpired(time.Now())
+				if err != nil {
+					log.Printf("[ERROR] Failed to delete expired limit reservations: %v", err)
+				} else if deleted > 0 {
+					log.Printf("🧹 Deleted %d expired limit reservations", deleted)
+				}
+			}
+		}
+	}()
+}
+
+// Stop signals the cleanup loop to exit
+func (s *LimitService) Stop() {
+	s.stopOnce.Do(func() { close(s.stop) })
+}
+
+// Reserve checks amount (TLC) against the user's limits and holds it until
+// release is called. Call release once the operation's own row (transaction,
+// outbox message or topup order) is saved, or the operation failed.
+func (s *LimitService) Reserve(user *models.User, operation, amount string) (func(), error) {
+	amountWei, err := blockchain.ParseTokenAmount(amount)
+	if err != nil {
+		return nil, err
+	}
+
+	limits := config.Limits().Get(accountTier(user), operation)
+	if limit := parseLimit(limits.PerTransaction); limit != nil && amountWei.Cmp(limit) > 0 {
+		return nil, &LimitExceededError{
+			Operation: operation,
+			Period:    LimitPeriodPerTransaction,
+			Limit:     limits.PerTransaction,
+			Remaining: limits.PerTransaction,
+		}
+	}
+
+	userLock := s.userLock(user.ID)
+	userLock.Lock()
+	defer userLock.Unlock()
+
+	now := time.Now()
+	reservation := &models.LimitReservation{
+		UserID:    user.ID,
+		Operation: operation,
+		Amount:    amount,
+		ExpiresAt: now.Add(limitReservationTTL),
+	}
+	err = s.reservationRepo.Transaction(func(tx *gorm.DB) error {
+		if err := s.reservationRepo.WithTx(tx).LockUser(user.ID); err != nil {
+			return err
+		}
+
+		daily, monthly, err := s.usage(tx, user, operation, now)
+		if err != nil {
+			return err
+		}
+
+		periods := []struct {
+			period string
+			limit  string
+			used   *big.Int
+		}{
+			{LimitPeriodDaily, limits.Daily, daily},
+			{LimitPeriodMonthly, limits.Monthly, monthly},
+		}
+		for _, p := range periods {
+			limit := parseLimit(p.limit)
+			if limit == nil {
+				continue
+			}
+			if new(big.Int).Add(p.used, amountWei).Cmp(limit) > 0 {
+				return &LimitExceededError{
+					Operation: operation,
+					Period:    p.period,
+					Limit:     p.limit,
+					Remaining: remaining(limit, p.used),
+				}
+			}
+		}
+
+		return s.reservationRepo.WithTx(tx).Create(reservation)
+	})
+	if err != nil {
+		var limitErr *LimitExceededError
+		if errors.As(err, &limitErr) {
+			return nil, err
+		}
+		log.Printf("[ERROR] Failed to reserve %s limit of user %d: %v", operation, user.ID, err)
+		return nil, errors.New("failed to check limits")
+	}
+
+	var once sync.Once
+	release := func() {
+		once.Do(func() {
+			if err := s.reservationRepo.Delete(reservation.ID); err != nil {
+				// Berhenti dihitung sendiri setelah expires_at
+				log.Printf("[WARN] Failed to release limit reservation %d: %v", reservation.ID, err)
+			}
+		})
+	}
+	return release, nil
+}
+
+// Check tells whether amount (TLC) is within the user's limits right now,
+// without holding it
+func (s *LimitService) Check(user *models.User, operation, amount string) error {
+	release, err := s.Reserve(user, operation, amount)
+	if err != nil {
+		return err
+	}
+	release()
+	return nil
+}
+
+// GetLimits returns the user's limits and usage for every operation
+func (s *LimitService) GetLimits(userID int64) (*response.LimitsResponse, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.New("user not found")
+		}
+		return nil, err
+	}
+
+	result := &response.LimitsResponse{Tier: accountTier(user)}
+	for _, operation := range []string{config.LimitOperationTransfer, config.LimitOperationWithdraw, config.LimitOperationTopup} {
+		limits, err := s.GetOperationLimits(user, operation)
+		if err != nil {
+			return nil, err
+		}
+		result.Limits = append(result.Limits, *limits)
+	}
+	return result, nil
+}
+
+// GetOperationLimits returns the user's limits and usage for one operation
+func (s *LimitService) GetOperationLimits(user *models.User, operation string) (*response.OperationLimitResponse, error) {
+	limits := config.Limits().Get(accountTier(user), operation)
+
+	daily, monthly, err := s.usage(nil, user, operation, time.Now())
+	if err != nil {
+		log.Printf("[ERROR] Failed to get %s usage of user %d: %v", operation, user.ID, err)
+		return nil, errors.New("failed to check limits")
+	}
+
+	result := &response.OperationLimitResponse{
+		Operation:      operation,
+		PerTransaction: limits.PerTransaction,
+		DailyLimit:     limits.Daily,
+		DailyUsed:      blockchain.FormatTokenAmount(daily),
+		MonthlyLimit:   limits.Monthly,
+		MonthlyUsed:    blockchain.FormatTokenAmount(monthly),
+	}
+	if limit := parseLimit(limits.Daily); limit != nil {
+		result.DailyRemaining = remaining(limit, daily)
+	}
+	if limit := parseLimit(limits.Monthly); limit != nil {
+		result.MonthlyRemaining = remaining(limit, monthly)
+	}
+	return result, nil
+}
+
+// usage sums the user's daily and monthly usage in wei, reservations included.
+// With tx set it reads inside that DB transaction.
+func (s *LimitService) usage(tx *gorm.DB, user *models.User, operation string, now time.Time) (*big.Int, *big.Int, error) {
+	rows, err := s.usageSince(tx, user, operation, now.Add(-limitMonthlyWindow))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	reservationRepo := s.reservationRepo
+	if tx != nil {
+		reservationRepo = reservationRepo.WithTx(tx)
+	}
+	reservations, err := reservationRepo.GetActive(user.ID, operation, now)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, reservation := range reservations {
+		rows = append(rows, usageRow{amount: reservation.Amount, createdAt: now})
+	}
+
+	daily, monthly := new(big.Int), new(big.Int)
+	dailySince := now.Add(-limitDailyWindow)
+	for _, row := range rows {
+		amountWei, err := blockchain.ParseTokenAmount(row.amount)
+		if err != nil {
+			continue
+		}
+		monthly.Add(monthly, amountWei)
+		if !row.createdAt.Before(dailySince) {
+			daily.Add(daily, amountWei)
+		}
+	}
+	return daily, monthly, nil
+}
+
+type usageRow struct {
+	amount    string
+	createdAt time.Time
+}
+
+// usageSince loads the amounts that count for an operation. Every topup has an
+// order from its request to the mint, which is written to transactions only
+// when it is broadcast (or, when delayed, processed), so topups use the orders.
+// A withdraw is in the outbox before its transactions row exists.
+func (s *LimitService) usageSince(tx *gorm.DB, user *models.User, operation string, since time.Time) ([]usageRow, error) {
+	txRepo, orderRepo, outboxRepo := s.txRepo, s.orderRepo, s.outboxRepo
+	if tx != nil {
+		txRepo, orderRepo, outboxRepo = txRepo.WithTx(tx), orderRepo.WithTx(tx), outboxRepo.WithTx(tx)
+	}
+
+	var rows []usageRow
+	if operation == config.LimitOperationTopup {
+		orders, err := orderRepo.GetUsageSince(user.ID, since)
+		if err != nil {
+			return nil, err
+		}
+		for _, order := range orders {
+			rows = append(rows, usageRow{amount: order.Amount, createdAt: order.CreatedAt})
+		}
+		return rows, nil
+	}
+
+	transactions, err := txRepo.GetUsageSince(user.WalletAddress, operation, since)
+	if err != nil {
+		return nil, err
+	}
+	for _, transaction := range transactions {
+		rows = append(rows, usageRow{amount: transaction.Amount, createdAt: transaction.CreatedAt})
+	}
+
+	if operation == config.LimitOperationWithdraw {
+		messages, err := outboxRepo.GetUnbroadcastSince(user.ID, models.OutboxActionWithdraw, since)
+		if err != nil {
+			return nil, err
+		}
+		for _, msg := range messages {
+			rows = append(rows, usageRow{amount: msg.Amount, createdAt: msg.CreatedAt})
+		}
+	}
+	return rows, nil
+}
+
+// userLock returns the in-process lock of a user
+func (s *LimitService) userLock(userID int64) *sync.Mutex {
+	lock, _ := s.userLocks.LoadOrStore(userID, &sync.Mutex{})
+	return lock.(*sync.Mutex)
+}
+
+// accountTier maps the user's KYC status to a limit tier
+func accountTier(user *models.User) string {
+	switch strings.ToLower(user.KYCStatus) {
+	case config.TierVerified:
+		return config.TierVerified
+	case config.TierPending:
+		return config.TierPending
+	default:
+		return config.TierUnverified
+	}
+}
+
+// parseLimit converts a TLC limit to wei; nil means no limit. Limits are
+// validated when they are loaded, an unreadable one allows nothing.
+func parseLimit(limit string) *big.Int {
+	if limit == "" {
+		return nil
+	}
+	limitWei, err := blockchain.ParseTokenAmount(limit)
+	if err != nil {
+		log.Printf("[ERROR] Invalid limit %q", limit)
+		return new(big.Int)
+	}
+	return limitWei
+}
+
+func remaining(limit, used *big.Int) string {
+	left := new(big.Int).Sub(limit, used)
+	if left.Sign() < 0 {
+		left.SetInt64(0)
+	}
+	return blockchain.FormatTokenAmount(left)
+}
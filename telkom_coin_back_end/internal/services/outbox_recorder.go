@@ -0,0 +1,301 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"sync"
+	"telkom_coin_back_end/config"
+	"telkom_coin_back_end/internal/blockchain"
+	"telkom_coin_back_end/internal/models"
+	"telkom_coin_back_end/internal/repository"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"gorm.io/gorm"
+)
+
+// OutboxRecorder settles broadcast outbox messages from their receipt. The
+// outbox status, the transactions row and the balance change are written in
+// one DB transaction, so a mint or burn is applied exactly once.
+type OutboxRecorder struct {
+	outboxRepo        *repository.OutboxRepository
+	balanceRepo       *repository.BalanceRepository
+	txRepo            *repository.TransactionRepository
+	pendingTopupRepo  *repository.PendingTopupRepository
+	blockchainService *blockchain.BlockchainService
+	relay             *OutboxRelay
+	interval          time.Duration
+	notFoundTimeout   time.Duration
+
+	stopOnce sync.Once
+	stop     chan struct{}
+}
+
+func NewOutboxRecorder(
+	outboxRepo *repository.OutboxRepository,
+	balanceRepo *repository.BalanceRepository,
+	txRepo *repository.TransactionRepository,
+	pendingTopupRepo *repository.PendingTopupRepository,
+	blockchainService *blockchain.BlockchainService,
+	relay *OutboxRelay,
+) *OutboxRecorder {
+	return &OutboxRecorder{
+		outboxRepo:        outboxRepo,
+		balanceRepo:       balanceRepo,
+		txRepo:            txRepo,
+		pendingTopupRepo:  pendingTopupRepo,
+		blockchainService: blockchainService,
+		relay:             relay,
+		interval:          config.AppConfig.OutboxPollInterval,
+		notFoundTimeout:   config.AppConfig.ReconcilerNotFoundTimeout,
+		stop:              make(chan struct{}),
+	}
+}
+
+// Start checks the receipts of broadcast messages in the background until Stop is called
+func (r *OutboxRecorder) Start() {
+	go func() {
+		log.Printf("📥 Outbox recorder started (every %s)", r.interval)
+
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-r.stop:
+				log.Println("Outbox recorder stopped")
+				return
+			case <-ticker.C:
+				r.RecordOnce()
+			}
+		}
+	}()
+}
+
+// Stop signals the recorder loop to exit
+func (r *OutboxRecorder) Stop() {
+	r.stopOnce.Do(func() { close(r.stop) })
+}
+
+// RecordOnce settles every broadcast message whose receipt is final
+func (r *OutboxRecorder) RecordOnce() {
+	if !r.blockchainService.IsWeb3Enabled() {
+		return
+	}
+
+	messages, err := r.outboxRepo.GetByStatus(models.OutboxStatusBroadcast, outboxBatchSize)
+	if err != nil {
+		log.Printf("[ERROR] Outbox recorder failed to load broadcast messages: %v", err)
+		return
+	}
+
+	for i := range messages {
+		msg := &messages[i]
+
+		receipt, err := r.blockchainService.GetTransactionReceipt(msg.TxHash)
+		if err != nil {
+			log.Printf("[WARN] Outbox recorder failed to get receipt of %s: %v", msg.TxHash, err)
+			continue
+		}
+
+		switch receipt.Status {
+		case blockchain.TxStatusConfirmed, blockchain.TxStatusFailed:
+			if err := r.settle(msg, receipt); err != nil {
+				// Rollback, dicoba lagi di putaran berikutnya
+				log.Printf("[ERROR] Failed to settle outbox message %d (%s): %v", msg.ID, msg.TxHash, err)
+			}
+		case blockchain.TxStatusNotFound:
+			r.handleNotFound(msg)
+		case blockchain.TxStatusPending:
+			if msg.NotFoundSince != nil {
+				// Node kenal lagi transaksinya (mis. setelah rebroadcast)
+				r.outboxRepo.Transition(msg.ID, models.OutboxStatusBroadcast, models.OutboxStatusBroadcast, map[string]interface{}{
+					"not_found_since": nil,
+				})
+			}
+		}
+	}
+}
+
+// settle applies the final receipt of a message
+func (r *OutboxRecorder) settle(msg *models.ChainOutbox, receipt *blockchain.TransactionReceipt) error {
+	var gasPrice int64
+	if receipt.EffectiveGasPrice != nil {
+		gasPrice = receipt.EffectiveGasPrice.Int64()
+	}
+
+	status := models.OutboxStatusConfirmed
+	if receipt.Status == blockchain.TxStatusFailed {
+		status = models.OutboxStatusFailed
+	}
+
+	// A confirmed topup request is only minted later, by the topup keeper
+	var requested map[string]interface{}
+	if msg.Action == models.OutboxActionTopupRequest && status == models.OutboxStatusConfirmed {
+		var err error
+		if requested, err = r.topupRequest(msg); err != nil {
+			return err
+		}
+	}
+
+	settled := false
+	err := r.outboxRepo.Transaction(func(tx *gorm.DB) error {
+		moved, err := r.outboxRepo.WithTx(tx).Transition(msg.ID, models.OutboxStatusBroadcast, status, map[string]interface{}{
+			"finalized_at": time.Now(),
+		})
+		if err != nil || !moved {
+			return err
+		}
+
+		// The reconciler or tracker may have finalized the row already
+		if _, err := r.txRepo.WithTx(tx).UpdateFinalStatus(msg.TxHash, receipt.Status, int64(receipt.BlockNumber), int64(receipt.GasUsed), gasPrice); err != nil {
+			return err
+		}
+
+		if err := r.applyBalance(r.balanceRepo.WithTx(tx), msg, status); err != nil {
+			return err
+		}
+		if msg.Action == models.OutboxActionTopupRequest {
+			if err := r.applyTopupRequest(r.pendingTopupRepo.WithTx(tx), msg, requested); err != nil {
+				return err
+			}
+		}
+		settled = true
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if settled {
+		log.Printf("📥 Outbox message %d (%s %s TLC) %s in block %d", msg.ID, msg.Action, msg.Amount, status, receipt.BlockNumber)
+	}
+	return nil
+}
+
+// applyBalance mirrors a settled mint or burn in balances
+func (r *OutboxRecorder) applyBalance(balanceRepo *repository.BalanceRepository, msg *models.ChainOutbox, status string) error {
+	switch msg.Action {
+	case models.OutboxActionTopup:
+		if status == models.OutboxStatusConfirmed {
+			return balanceRepo.AddBalance(msg.UserID, msg.Amount)
+		}
+	case models.OutboxActionWithdraw:
+		if err := balanceRepo.UnlockBalance(msg.UserID, msg.Amount); err != nil {
+			return err
+		}
+		if status == models.OutboxStatusConfirmed {
+			err := balanceRepo.SubtractBalance(msg.UserID, msg.Amount)
+			// Saldo DB belum tentu sinkron dengan on-chain (mis. transfer masuk), burn tetap sah
+			if err != nil && err.Error() == "insufficient balance" {
+				log.Printf("[WARN] DB balance of user %d below burned amount %s, left unchanged", msg.UserID, msg.Amount)
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// topupRequest reads the request ID of a mined requestTopup and when the
+// contract allows processTopup for it
+func (r *OutboxRecorder) topupRequest(msg *models.ChainOutbox) (map[string]interface{}, error) {
+	if r.relay.contractService == nil {
+		return nil, errors.New("blockchain not available")
+	}
+
+	requestID, err := r.relay.contractService.GetTopupRequestID(msg.TxHash)
+	if err != nil {
+		return nil, err
+	}
+	request, err := r.relay.contractService.GetTopupRequest(requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	processableAt := time.Unix(request.Timestamp.Int64(), 0).Add(topupProcessDelay)
+	return map[string]interface{}{
+		"request_id":      common.Hash(requestID).Hex(),
+		"request_tx_hash": msg.TxHash,
+		"processable_at":  processableAt,
+	}, nil
+}
+
+// applyTopupRequest hands a mined topup request to the topup keeper, or
+// fails its pending topup when requestTopup reverted
+func (r *OutboxRecorder) applyTopupRequest(pendingTopupRepo *repository.PendingTopupRepository, msg *models.ChainOutbox, requested map[string]interface{}) error {
+	pending, err := pendingTopupRepo.GetByOutboxID(msg.ID)
+	if err != nil {
+		return err
+	}
+
+	if requested == nil {
+		_, err = pendingTopupRepo.Transition(pending.ID, models.PendingTopupStatusRequesting, models.PendingTopupStatusFailed, map[string]interface{}{
+			"request_tx_hash": msg.TxHash,
+			"last_error":      "requestTopup reverted",
+		})
+		return err
+	}
+
+	_, err = pendingTopupRepo.Transition(pending.ID, models.PendingTopupStatusRequesting, models.PendingTopupStatusRequested, requested)
+	return err
+}
+
+// handleNotFound resends a transaction the node forgot (e.g. dropped from its
+// mempool after a restart). The first miss is stored on the message; once the
+// node still does not know it after RECONCILER_NOT_FOUND_TIMEOUT, the message is
+// failed and its balance lock released. This does not depend on a transactions
+// row, which delayed topup requests don't have.
+func (r *OutboxRecorder) handleNotFound(msg *models.ChainOutbox) {
+	now := time.Now()
+	if msg.NotFoundSince == nil {
+		if _, err := r.outboxRepo.Transition(msg.ID, models.OutboxStatusBroadcast, models.OutboxStatusBroadcast, map[string]interface{}{
+			"not_found_since": now,
+		}); err != nil {
+			log.Printf("[ERROR] Failed to record missing transaction of outbox message %d: %v", msg.ID, err)
+		}
+		msg.NotFoundSince = &now
+	} else if now.Sub(*msg.NotFoundSince) >= r.notFoundTimeout {
+		log.Printf("[WARN] Outbox message %d (%s) never reached the chain, marking failed", msg.ID, msg.TxHash)
+		r.failNotFound(msg)
+		return
+	}
+
+	signedTx, err := decodeRawTx(msg.RawTx)
+	if err != nil {
+		log.Printf("[ERROR] Outbox message %d: %v", msg.ID, err)
+		return
+	}
+	if r.relay.contractService == nil {
+		return
+	}
+	if err := r.relay.contractService.SendSigned(signedTx); err != nil {
+		log.Printf("[WARN] Rebroadcast of %s failed: %v", msg.TxHash, err)
+	}
+}
+
+// failNotFound fails a message whose transaction never reached the chain, and
+// flags its transactions row, or fails its pending topup for a delayed topup request
+func (r *OutboxRecorder) failNotFound(msg *models.ChainOutbox) {
+	cause := errors.New("transaction not found on chain after " + r.notFoundTimeout.String())
+	r.relay.fail(msg, cause)
+
+	if msg.Action == models.OutboxActionTopupRequest {
+		pending, err := r.pendingTopupRepo.GetByOutboxID(msg.ID)
+		if err != nil {
+			log.Printf("[ERROR] Failed to load pending topup of outbox message %d: %v", msg.ID, err)
+			return
+		}
+		if _, err := r.pendingTopupRepo.Transition(pending.ID, models.PendingTopupStatusRequesting, models.PendingTopupStatusFailed, map[string]interface{}{
+			"request_tx_hash": msg.TxHash,
+			"last_error":      cause.Error(),
+		}); err != nil {
+			log.Printf("[ERROR] Failed to fail pending topup %d: %v", pending.ID, err)
+		}
+		return
+	}
+
+	if _, err := r.txRepo.MarkNotFound(msg.TxHash); err != nil {
+		log.Printf("[ERROR] Failed to flag transaction %s as not_found: %v", msg.TxHash, err)
+	}
+}
@@ -0,0 +1,444 @@
+package service
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"telkom_coin_back_end/config"
+	"telkom_coin_back_end/internal/blockchain"
+	"telkom_coin_back_end/internal/models"
+	"telkom_coin_back_end/internal/repository"
+	"telkom_coin_back_end/internal/web3"
+	"telkom_coin_back_end/pkg/crypto"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"gorm.io/gorm"
+)
+
+const (
+	outboxBatchSize = 100
+	// Longest wait between two broadcast attempts of a message
+	outboxMaxBackoff = 10 * time.Minute
+	zeroAddress      = "0x0000000000000000000000000000000000000000"
+)
+
+// OutboxRelay signs and broadcasts the mint/burn intents of chain_outbox.
+// The signed transaction and its hash are stored before the broadcast, so a
+// crash in between is recovered by sending the same transaction again, and
+// the transactions row is only written once a node has accepted it.
+type OutboxRelay struct {
+	outboxRepo        *repository.OutboxRepository
+	userRepo          *repository.UserRepository
+	balanceRepo       *repository.BalanceRepository
+	txRepo            *repository.TransactionRepository
+	blockchainService *blockchain.BlockchainService
+	contractService   *web3.ContractService
+	interval          time.Duration
+	maxAttempts       int
+
+	mu       sync.Mutex
+	inFlight map[int64]bool // outbox IDs being relayed right now
+
+	stopOnce sync.Once
+	stop     chan struct{}
+}
+
+func NewOutboxRelay(
+	outboxRepo *repository.OutboxRepository,
+	userRepo *repository.UserRepository,
+	balanceRepo *repository.BalanceRepository,
+	txRepo *repository.TransactionRepository,
+	blockchainService *blockchain.BlockchainService,
+) *OutboxRelay {
+	var contractService *web3.ContractService
+	if blockchainService.IsWeb3Enabled() {
+		web3Client, err := web3.NewWeb3Client()
+		if err == nil {
+			contractService, _ = web3.NewContractService(web3Client)
+		}
+	}
+
+	return &OutboxRelay{
+		outboxRepo:        outboxRepo,
+		userRepo:          userRepo,
+		balanceRepo:       balanceRepo,
+		txRepo:            txRepo,
+		blockchainService: blockchainService,
+		contractService:   contractService,
+		interval:          config.AppConfig.OutboxPollInterval,
+		maxAttempts:       config.AppConfig.OutboxMaxAttempts,
+		inFlight:          make(map[int64]bool),
+		stop:              make(chan struct{}),
+	}
+}
+
+// Start sends signed transactions left over from before a restart, then keeps
+// relaying outbox messages in the background until Stop is called
+func (r *OutboxRelay) Start() {
+	if r.contractService == nil {
+		log.Println("⚠️  Outbox relay not started, blockchain not available")
+		return
+	}
+
+	// Sebelum melayani request baru, supaya nonce yang sudah ditandatangani tidak dipakai ulang
+	r.relayStatus(models.OutboxStatusSigned)
+
+	go func() {
+		log.Printf("📤 Outbox relay started (every %s)", r.interval)
+
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-r.stop:
+				log.Println("Outbox relay stopped")
+				return
+			case <-ticker.C:
+				r.relayStatus(models.OutboxStatusPending)
+				r.relayStatus(models.OutboxStatusSigned)
+			}
+		}
+	}()
+}
+
+// Stop signals the relay loop to exit
+func (r *OutboxRelay) Stop() {
+	r.stopOnce.Do(func() { close(r.stop) })
+}
+
+// Dispatch relays one message right away, so the API can answer with the tx hash.
+// An error means nothing was sent and the message is failed. A message whose
+// broadcast failed stays signed and is retried by the relay loop.
+func (r *OutboxRelay) Dispatch(id int64) (*models.ChainOutbox, error) {
+	if !r.claim(id) {
+		return r.outboxRepo.GetByID(id)
+	}
+	defer r.unclaim(id)
+
+	msg, err := r.outboxRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.relay(msg); err != nil {
+		return msg, err
+	}
+	return msg, nil
+}
+
+// relayStatus relays a batch of messages in the given status that are due; a
+// message whose broadcast keeps failing waits out its backoff instead of
+// taking a place in every batch
+func (r *OutboxRelay) relayStatus(status string) {
+	messages, err := r.outboxRepo.GetDue(status, time.Now(), outboxBatchSize)
+	if err != nil {
+		log.Printf("[ERROR] Outbox relay failed to load %s messages: %v", status, err)
+		return
+	}
+
+	for i := range messages {
+		msg := &messages[i]
+		if !r.claim(msg.ID) {
+			continue
+		}
+		if err := r.relay(msg); err != nil {
+			log.Printf("[WARN] Outbox message %d (%s) failed: %v", msg.ID, msg.Action, err)
+		}
+		r.unclaim(msg.ID)
+	}
+}
+
+func (r *OutboxRelay) claim(id int64) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.inFlight[id] {
+		return false
+	}
+	r.inFlight[id] = true
+	return true
+}
+
+func (r *OutboxRelay) unclaim(id int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.inFlight, id)
+}
+
+// relay moves a message as far as it can: pending -> signed -> broadcast
+func (r *OutboxRelay) relay(msg *models.ChainOutbox) error {
+	if r.contractService == nil {
+		return errors.New("blockchain not available")
+	}
+
+	// A message signed earlier may already have reached a node before a failed attempt or a restart
+	checkChain := msg.Status == models.OutboxStatusSigned
+
+	if msg.Status == models.OutboxStatusPending {
+		if err := r.sign(msg); err != nil {
+			return err
+		}
+	}
+	if msg.Status == models.OutboxStatusSigned {
+		r.broadcast(msg, checkChain)
+	}
+	return nil
+}
+
+// sign signs the contract call of a pending message and stores hash and raw tx
+func (r *OutboxRelay) sign(msg *models.ChainOutbox) error {
+	user, err := r.userRepo.GetByID(msg.UserID)
+	if err != nil {
+		return r.fail(msg, errors.New("user not found"))
+	}
+
+	privateKey, err := crypto.DecryptPrivateKey(user.PrivateKeyEncrypted)
+	if err != nil {
+		return r.fail(msg, errors.New("failed to decrypt private key"))
+	}
+
+	amountWei, err := blockchain.ParseTokenAmount(msg.Amount)
+	if err != nil {
+		return r.fail(msg, err)
+	}
+
+	var signedTx *types.Transaction
+	switch msg.Action {
+	case models.OutboxActionTopup:
+		paymentProof, _ := msg.Payload["payment_proof"].(string)
+		signedTx, err = r.contractService.SignInstantTopup(privateKey, amountWei, paymentProof)
+	case models.OutboxActionTopupRequest:
+		paymentProof, _ := msg.Payload["payment_proof"].(string)
+		signedTx, err = r.contractService.SignRequestTopup(privateKey, amountWei, paymentProof)
+	case models.OutboxActionWithdraw:
+		bankAccount, _ := msg.Payload["bank_account"].(string)
+		signedTx, err = r.contractService.SignRequestWithdraw(privateKey, amountWei, bankAccount)
+	default:
+		err = fmt.Errorf("unknown outbox action %q", msg.Action)
+	}
+	if err != nil {
+		return r.fail(msg, err)
+	}
+
+	rawTx, err := signedTx.MarshalBinary()
+	if err != nil {
+		r.contractService.DiscardSigned(signedTx, err)
+		return r.fail(msg, err)
+	}
+
+	txHash := signedTx.Hash().Hex()
+	rawTxHex := hex.EncodeToString(rawTx)
+	signed, err := r.outboxRepo.MarkSigned(msg.ID, txHash, rawTxHex)
+	if err != nil || !signed {
+		// Tidak boleh broadcast transaksi yang hash-nya tidak tercatat
+		r.contractService.DiscardSigned(signedTx, errors.New("outbox message not saved"))
+		if err != nil {
+			return fmt.Errorf("failed to save signed transaction: %v", err)
+		}
+		return errors.New("outbox message was changed by another worker")
+	}
+
+	msg.Status = models.OutboxStatusSigned
+	msg.TxHash = txHash
+	msg.RawTx = rawTxHex
+	return nil
+}
+
+// broadcast sends a signed message; failures are retried up to OUTBOX_MAX_ATTEMPTS
+func (r *OutboxRelay) broadcast(msg *models.ChainOutbox, checkChain bool) {
+	signedTx, err := decodeRawTx(msg.RawTx)
+	if err != nil {
+		r.fail(msg, err)
+		return
+	}
+
+	if checkChain {
+		if receipt, err := r.blockchainService.GetTransactionReceipt(msg.TxHash); err == nil && receipt.Status != blockchain.TxStatusNotFound {
+			r.markBroadcast(msg)
+			return
+		}
+	}
+
+	err = r.contractService.SendSigned(signedTx)
+	if err == nil || strings.Contains(strings.ToLower(err.Error()), "already known") {
+		r.markBroadcast(msg)
+		return
+	}
+
+	log.Printf("[WARN] Broadcast of outbox message %d (%s) failed (attempt %d/%d): %v", msg.ID, msg.TxHash, msg.Attempts+1, r.maxAttempts, err)
+	if recordErr := r.outboxRepo.RecordAttempt(msg.ID, err.Error(), time.Now().Add(r.backoff(msg.Attempts+1))); recordErr != nil {
+		log.Printf("[ERROR] Failed to record outbox attempt %d: %v", msg.ID, recordErr)
+	}
+	msg.Attempts++
+
+	if msg.Attempts >= r.maxAttempts {
+		r.fail(msg, err)
+	}
+}
+
+// backoff is the wait after the given number of failed attempts: the poll
+// interval, doubled per attempt, at most outboxMaxBackoff
+func (r *OutboxRelay) backoff(attempts int) time.Duration {
+	wait := r.interval
+	for i := 1; i < attempts && wait < outboxMaxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > outboxMaxBackoff {
+		wait = outboxMaxBackoff
+	}
+	return wait
+}
+
+// markBroadcast records that a node accepted the transaction and creates its transactions row
+func (r *OutboxRelay) markBroadcast(msg *models.ChainOutbox) {
+	now := time.Now()
+	err := r.outboxRepo.Transaction(func(tx *gorm.DB) error {
+		moved, err := r.outboxRepo.WithTx(tx).Transition(msg.ID, models.OutboxStatusSigned, models.OutboxStatusBroadcast, map[string]interface{}{
+			"broadcast_at": now,
+		})
+		if err != nil || !moved {
+			return err
+		}
+
+		row := newOutboxTransaction(msg, now)
+		if row == nil {
+			return nil
+		}
+		txRepo := r.txRepo.WithTx(tx)
+		exists, err := txRepo.HashExists(msg.TxHash)
+		if err != nil || exists {
+			return err
+		}
+		return txRepo.Create(row)
+	})
+	if err != nil {
+		// Tetap signed, dicoba lagi oleh relay loop
+		log.Printf("[ERROR] Failed to record broadcast of outbox message %d: %v", msg.ID, err)
+		return
+	}
+
+	msg.Status = models.OutboxStatusBroadcast
+	msg.BroadcastAt = &now
+	log.Printf("📤 Outbox message %d (%s) broadcast as %s", msg.ID, msg.Action, msg.TxHash)
+}
+
+// fail marks a message failed and releases its balance lock. A signed message
+// gives up its nonce: the local nonce state is reloaded from the chain, so the
+// next transaction of the sender does not wait behind the gap.
+func (r *OutboxRelay) fail(msg *models.ChainOutbox, cause error) error {
+	from := msg.Status
+	err := r.outboxRepo.Transaction(func(tx *gorm.DB) error {
+		moved, err := r.outboxRepo.WithTx(tx).Transition(msg.ID, from, models.OutboxStatusFailed, map[string]interface{}{
+			"last_error":   cause.Error(),
+			"finalized_at": time.Now(),
+		})
+		if err != nil || !moved {
+			return err
+		}
+		if msg.Action == models.OutboxActionWithdraw {
+			return r.balanceRepo.WithTx(tx).UnlockBalance(msg.UserID, msg.Amount)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("[ERROR] Failed to mark outbox message %d failed: %v", msg.ID, err)
+	}
+
+	if msg.RawTx != "" && r.contractService != nil {
+		if signedTx, err := decodeRawTx(msg.RawTx); err == nil {
+			r.contractService.ResyncSigned(signedTx)
+		}
+	}
+
+	msg.Status = models.OutboxStatusFailed
+	msg.LastError = cause.Error()
+	return cause
+}
+
+// newOutboxTransaction builds the transactions row of a broadcast message. A
+// delayed topup request mints nothing; its row is written by the topup keeper
+// for the processTopup transaction.
+func newOutboxTransaction(msg *models.ChainOutbox, now time.Time) *models.Transaction {
+	if msg.Action == models.OutboxActionTopupRequest {
+		return nil
+	}
+
+	tx := &models.Transaction{
+		TxHash:    msg.TxHash,
+		Amount:    msg.Amount,
+		TxType:    msg.Action,
+		CreatedAt: now,
+		Metadata: models.TransactionMetadata{
+			"outbox_id": msg.ID,
+		},
+	}
+
+	switch msg.Action {
+	case models.OutboxActionTopup:
+		tx.FromAddress = zeroAddress // dianggap dari sistem
+		tx.ToAddress = msg.WalletAddress
+		tx.Status = "pending"
+		tx.Metadata["payment_method"] = msg.Payload["payment_method"]
+		if reference, ok := msg.Payload["topup_reference"]; ok {
+			tx.Metadata["topup_reference"] = reference
+		}
+		tx.Metadata["note"] = "Blockchain topup synced to DB"
+	case models.OutboxActionWithdraw:
+		tx.FromAddress = msg.WalletAddress
+		tx.ToAddress = "" // kosong karena withdraw gak kirim ke address lain
+		tx.Status = "processing"
+	}
+	return tx
+}
+
+func decodeRawTx(rawTxHex string) (*types.Transaction, error) {
+	rawTx, err := hex.DecodeString(rawTxHex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid raw transaction: %v", err)
+	}
+
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(rawTx); err != nil {
+		return nil, fmt.Errorf("invalid raw transaction: %v", err)
+	}
+	return tx, nil
+}
+
+// HeldNonces reads the nonces taken by signed transactions that are stored to be
+// broadcast again: outbox messages not final yet and processTopup sends of the
+// keeper. A failed broadcast can back off longer than the nonce manager's idle
+// resync, so without them a new transaction could be given the same nonce.
+func HeldNonces(outboxRepo *repository.OutboxRepository, pendingTopupRepo *repository.PendingTopupRepository) web3.HeldNonces {
+	return func(address common.Address) (uint64, bool, error) {
+		outboxTxs, err := outboxRepo.GetHeldRawTxs()
+		if err != nil {
+			return 0, false, err
+		}
+		topupTxs, err := pendingTopupRepo.GetHeldRawTxs()
+		if err != nil {
+			return 0, false, err
+		}
+
+		var highest uint64
+		found := false
+		for _, rawTx := range append(outboxTxs, topupTxs...) {
+			tx, err := decodeRawTx(rawTx)
+			if err != nil {
+				continue
+			}
+			from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+			if err != nil || from != address {
+				continue
+			}
+			if !found || tx.Nonce() > highest {
+				highest, found = tx.Nonce(), true
+			}
+		}
+		return highest, found, nil
+	}
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"encoding/hex"
+	"math/big"
+	"telkom_coin_back_end/internal/models"
+	"telkom_coin_back_end/internal/repository"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestOutboxRelayBackoff(t *testing.T) {
+	relay := &OutboxRelay{interval: 5 * time.Second}
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, 5 * time.Second},
+		{2, 10 * time.Second},
+		{3, 20 * time.Second},
+		{7, 320 * time.Second},
+		{8, outboxMaxBackoff},
+		{1000, outboxMaxBackoff},
+	}
+	for _, tt := range tests {
+		if got := relay.backoff(tt.attempts); got != tt.want {
+			t.Errorf("backoff(%d) = %s, want %s", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestHeldNonces(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("open test database: %v", err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	defer sqlDB.Close()
+	if err := db.AutoMigrate(&models.ChainOutbox{}, &models.PendingTopup{}); err != nil {
+		t.Fatalf("migrate test database: %v", err)
+	}
+
+	key, _ := crypto.GenerateKey()
+	signer := crypto.PubkeyToAddress(key.PublicKey)
+	chainID := big.NewInt(1337)
+	rawTx := func(nonce uint64) string {
+		tx, err := types.SignNewTx(key, types.LatestSignerForChainID(chainID), &types.DynamicFeeTx{ChainID: chainID, Nonce: nonce, Gas: 21000})
+		if err != nil {
+			t.Fatalf("sign: %v", err)
+		}
+		data, _ := tx.MarshalBinary()
+		return hex.EncodeToString(data)
+	}
+
+	outbox := []models.ChainOutbox{
+		{Status: models.OutboxStatusSigned, RawTx: rawTx(4)},
+		{Status: models.OutboxStatusBroadcast, RawTx: rawTx(5)},
+		{Status: models.OutboxStatusConfirmed, RawTx: rawTx(9)}, // Mined, the chain counts it
+		{Status: models.OutboxStatusPending},
+	}
+	for i := range outbox {
+		outbox[i].UserID, outbox[i].Action, outbox[i].WalletAddress, outbox[i].Amount = 1, models.OutboxActionTopup, "0x1", "1"
+		if err := db.Create(&outbox[i]).Error; err != nil {
+			t.Fatalf("create outbox: %v", err)
+		}
+	}
+	if err := db.Create(&models.PendingTopup{UserID: 1, OutboxID: 1, WalletAddress: "0x1", Amount: "1", Status: models.PendingTopupStatusProcessing, RawTx: rawTx(6)}).Error; err != nil {
+		t.Fatalf("create pending topup: %v", err)
+	}
+
+	held := HeldNonces(repository.NewOutboxRepository(db), repository.NewPendingTopupRepository(db))
+	tests := []struct {
+		address common.Address
+		want    uint64
+		wantOK  bool
+	}{
+		{address: signer, want: 6, wantOK: true},
+		{address: common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")},
+	}
+	for _, tt := range tests {
+		got, ok, err := held(tt.address)
+		if err != nil {
+			t.Fatalf("HeldNonces(%s) error = %v", tt.address.Hex(), err)
+		}
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("HeldNonces(%s) = %d, %v, want %d, %v", tt.address.Hex(), got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
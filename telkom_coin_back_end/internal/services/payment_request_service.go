@@ -0,0 +1,424 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"telkom_coin_back_end/internal/blockchain"
+	"telkom_coin_back_end/internal/dto/request"
+	"telkom_coin_back_end/internal/dto/response"
+	"telkom_coin_back_end/internal/models"
+	"telkom_coin_back_end/internal/repository"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	defaultPaymentRequestExpiry = 72 * time.Hour
+	// A paying request without a receipt after this long is checked again on read
+	paymentRequestSettleAfter = 10 * time.Minute
+	paymentRequestSettleBatch = 50
+
+	PaymentRequestIncoming = "incoming"
+	PaymentRequestOutgoing = "outgoing"
+)
+
+var ErrPaymentRequestNotFound = errors.New("payment request not found")
+
+// PaymentRequestService lets users ask each other for TLC. Paying a request is
+// a normal validate + execute transfer from the payer to the requester; the
+// request is paid once the transaction tracker sees that transfer confirmed.
+type PaymentRequestService struct {
+	paymentRequestRepo *repository.PaymentRequestRepository
+	userRepo           *repository.UserRepository
+	txRepo             *repository.TransactionRepository
+	recipientResolver  *RecipientResolver
+	walletService      *TLCWalletService
+}
+
+func NewPaymentRequestService(
+	paymentRequestRepo *repository.PaymentRequestRepository,
+	userRepo *repository.UserRepository,
+	txRepo *repository.TransactionRepository,
+	recipientResolver *RecipientResolver,
+	walletService *TLCWalletService,
+) *PaymentRequestService {
+	return &PaymentRequestService{
+		paymentRequestRepo: paymentRequestRepo,
+		userRepo:           userRepo,
+		txRepo:             txRepo,
+		recipientResolver:  recipientResolver,
+		walletService:      walletService,
+	}
+}
+
+// Create asks the payer (wallet address, @username, email or phone) for amount TLC
+func (s *PaymentRequestService) Create(userID int64, req *request.CreatePaymentRequestRequest) (*response.PaymentRequestResponse, error) {
+	requester, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.New("user not found")
+		}
+		return nil, err
+	}
+	if requester.Status != "active" {
+		return nil, errors.New("account is not active")
+	}
+
+	payer, err := s.recipientResolver.Resolve(userID, req.Payer)
+	if err != nil {
+		return nil, err
+	}
+	if payer.User == nil || payer.User.Status != "active" {
+		return nil, ErrRecipientNotFound
+	}
+	if payer.User.ID == requester.ID {
+		return nil, errors.New("cannot request payment from yourself")
+	}
+
+	amount, ok := new(big.Int).SetString(req.Amount, 10)
+	if !ok {
+		return nil, errors.New("invalid amount format")
+	}
+	if amount.Sign() <= 0 {
+		return nil, errors.New("amount must be greater than 0")
+	}
+
+	memo, err := normalizeMemo(req.Memo)
+	if err != nil {
+		return nil, err
+	}
+
+	expiry := defaultPaymentRequestExpiry
+	if req.ExpiresInHours > 0 {
+		expiry = time.Duration(req.ExpiresInHours) * time.Hour
+	}
+
+	paymentRequest := &models.PaymentRequest{
+		RequesterID: requester.ID,
+		PayerID:     payer.User.ID,
+		Amount:      amount.String(),
+		Memo:        memo,
+		Status:      models.PaymentRequestStatusOpen,
+		ExpiresAt:   time.Now().Add(expiry),
+	}
+	if err := s.paymentRequestRepo.Create(paymentRequest); err != nil {
+		return nil, err
+	}
+
+	log.Printf("🧾 Payment request %d: user %d asked user %d for %s TLC", paymentRequest.ID, requester.ID, payer.User.ID, paymentRequest.Amount)
+	return s.toResponse(paymentRequest, userID, map[int64]models.User{
+		requester.ID:  *requester,
+		payer.User.ID: *payer.User,
+	}), nil
+}
+
+// List returns the user's incoming or outgoing requests, optionally filtered by status
+func (s *PaymentRequestService) List(userID int64, direction, status string, page, limit int) (*response.PaymentRequestListResponse, error) {
+	s.expireOverdue()
+
+	var (
+		requests []models.PaymentRequest
+		total    int64
+		err      error
+	)
+	switch direction {
+	case PaymentRequestIncoming:
+		requests, total, err = s.paymentRequestRepo.GetIncoming(userID, status, page, limit)
+	case PaymentRequestOutgoing:
+		requests, total, err = s.paymentRequestRepo.GetOutgoing(userID, status, page, limit)
+	default:
+		return nil, errors.New("direction must be incoming or outgoing")
+	}
+	if err != nil {
+		log.Printf("[ERROR] Failed to get payment requests: %v", err)
+		return nil, errors.New("failed to retrieve payment requests")
+	}
+
+	userIDs := make([]int64, 0, len(requests)*2)
+	for _, paymentRequest := range requests {
+		userIDs = append(userIDs, paymentRequest.RequesterID, paymentRequest.PayerID)
+	}
+	users, err := s.userRepo.GetByIDs(userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &response.PaymentRequestListResponse{
+		Requests:   make([]response.PaymentRequestResponse, 0, len(requests)),
+		TotalCount: int(total),
+		Page:       page,
+		Limit:      limit,
+		TotalPages: (int(total) + limit - 1) / limit,
+	}
+	for i := range requests {
+		result.Requests = append(result.Requests, *s.toResponse(&requests[i], userID, users))
+	}
+	return result, nil
+}
+
+// Get returns one request the user made or received
+func (s *PaymentRequestService) Get(userID, id int64) (*response.PaymentRequestResponse, error) {
+	s.expireOverdue()
+
+	paymentRequest, err := s.getForUser(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.respond(paymentRequest, userID)
+}
+
+// Pay sends the requested amount from the payer to the requester. The request
+// is claimed as paying first so it can't be paid twice, and reopened if the
+// transfer could not be broadcast. The transfer's receipt settles it: paid when
+// confirmed, open again when it failed (see OnTransactionFinal).
+func (s *PaymentRequestService) Pay(userID, id int64, pin string) (*response.PaymentRequestResponse, error) {
+	paymentRequest, err := s.getOpenForPayer(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	requester, err := s.userRepo.GetByID(paymentRequest.RequesterID)
+	if err != nil {
+		return nil, err
+	}
+	if requester.Status != "active" {
+		return nil, errors.New("requester account is not active")
+	}
+
+	claimed, err := s.paymentRequestRepo.Transition(id, models.PaymentRequestStatusOpen, models.PaymentRequestStatusPaying, nil)
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		return nil, errors.New("payment request is no longer open")
+	}
+
+	txHash, err := s.transfer(userID, requester.WalletAddress, paymentRequest, pin)
+	if err != nil {
+		s.reopen(id, err.Error())
+		return nil, err
+	}
+
+	linked, err := s.paymentRequestRepo.Transition(id, models.PaymentRequestStatusPaying, models.PaymentRequestStatusPaying, map[string]interface{}{
+		"tx_hash": txHash,
+	})
+	if err != nil || !linked {
+		// Transfer sudah terkirim; settlePaying mencari transfernya nanti
+		log.Printf("[ERROR] Failed to link transfer %s to payment request %d: %v", txHash, id, err)
+	}
+
+	log.Printf("🧾 Payment request %d paying with %s", id, txHash)
+	paymentRequest.Status = models.PaymentRequestStatusPaying
+	paymentRequest.TxHash = txHash
+	return s.respond(paymentRequest, userID)
+}
+
+// OnTransactionFinal settles the paying request of a transfer that became
+// final. Registered with TransactionTracker.OnFinal.
+func (s *PaymentRequestService) OnTransactionFinal(tx *models.Transaction) {
+	if tx.TxType != "transfer" {
+		return
+	}
+	paymentRequest, err := s.paymentRequestRepo.GetByTxHash(tx.TxHash)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Printf("[ERROR] Failed to load payment request of %s: %v", tx.TxHash, err)
+		}
+		return
+	}
+	s.settle(paymentRequest, tx.Status)
+}
+
+// settle moves a paying request to paid or back to open from its transfer status
+func (s *PaymentRequestService) settle(paymentRequest *models.PaymentRequest, txStatus string) {
+	switch txStatus {
+	case blockchain.TxStatusConfirmed:
+		paid, err := s.paymentRequestRepo.Transition(paymentRequest.ID, models.PaymentRequestStatusPaying, models.PaymentRequestStatusPaid, map[string]interface{}{
+			"paid_at": time.Now(),
+		})
+		if err != nil {
+			log.Printf("[ERROR] Failed to mark payment request %d paid: %v", paymentRequest.ID, err)
+		} else if paid {
+			log.Printf("🧾 Payment request %d paid with %s", paymentRequest.ID, paymentRequest.TxHash)
+		}
+	case blockchain.TxStatusFailed, blockchain.TxStatusNotFound:
+		s.reopen(paymentRequest.ID, "transfer "+paymentRequest.TxHash+" "+txStatus)
+	}
+}
+
+// reopen puts a paying request back to open so it can be paid again
+func (s *PaymentRequestService) reopen(id int64, reason string) {
+	reopened, err := s.paymentRequestRepo.Transition(id, models.PaymentRequestStatusPaying, models.PaymentRequestStatusOpen, map[string]interface{}{
+		"tx_hash": "",
+	})
+	if err != nil {
+		log.Printf("[ERROR] Failed to reopen payment request %d: %v", id, err)
+	} else if reopened {
+		log.Printf("[WARN] Payment request %d reopened: %s", id, reason)
+	}
+}
+
+// settlePaying checks paying requests whose receipt was missed (e.g. across a
+// restart): a linked transfer that is final settles the request, and a request
+// that lost its hash is linked to the payer's matching transfer or reopened
+func (s *PaymentRequestService) settlePaying() {
+	requests, err := s.paymentRequestRepo.GetStalePaying(time.Now().Add(-paymentRequestSettleAfter), paymentRequestSettleBatch)
+	if err != nil {
+		log.Printf("[WARN] Failed to load paying payment requests: %v", err)
+		return
+	}
+
+	for i := range requests {
+		paymentRequest := &requests[i]
+		if paymentRequest.TxHash != "" {
+			tx, err := s.txRepo.GetByHash(paymentRequest.TxHash)
+			if err != nil {
+				log.Printf("[WARN] Failed to load transfer %s of payment request %d: %v", paymentRequest.TxHash, paymentRequest.ID, err)
+				continue
+			}
+			s.settle(paymentRequest, tx.Status)
+			continue
+		}
+
+		requester, err := s.userRepo.GetByID(paymentRequest.RequesterID)
+		if err != nil {
+			continue
+		}
+		txHash, err := s.walletService.FindSentTransfer(paymentRequest.PayerID, requester.WalletAddress, paymentRequest.Amount, paymentRequest.UpdatedAt)
+		if err != nil {
+			log.Printf("[WARN] Failed to look up transfer of payment request %d: %v", paymentRequest.ID, err)
+			continue
+		}
+		if txHash == "" {
+			s.reopen(paymentRequest.ID, "no transfer was saved")
+			continue
+		}
+		if _, err := s.paymentRequestRepo.Transition(paymentRequest.ID, models.PaymentRequestStatusPaying, models.PaymentRequestStatusPaying, map[string]interface{}{
+			"tx_hash": txHash,
+		}); err != nil {
+			log.Printf("[ERROR] Failed to link transfer %s to payment request %d: %v", txHash, paymentRequest.ID, err)
+		}
+	}
+}
+
+// transfer runs the same validate + execute steps as /api/transfer
+func (s *PaymentRequestService) transfer(userID int64, toAddress string, paymentRequest *models.PaymentRequest, pin string) (string, error) {
+	quote, err := s.walletService.ValidateTransfer(userID, toAddress, paymentRequest.Amount, paymentRequest.Memo)
+	if err != nil {
+		return "", err
+	}
+
+	result, err := s.walletService.TransferTLC(userID, quote.QuoteToken, pin, "")
+	if err != nil {
+		return "", err
+	}
+	return result.TxHash, nil
+}
+
+// Decline closes an open request without paying it
+func (s *PaymentRequestService) Decline(userID, id int64) (*response.PaymentRequestResponse, error) {
+	paymentRequest, err := s.getOpenForPayer(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	declinedAt := time.Now()
+	declined, err := s.paymentRequestRepo.Transition(id, models.PaymentRequestStatusOpen, models.PaymentRequestStatusDeclined, map[string]interface{}{
+		"declined_at": declinedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !declined {
+		return nil, errors.New("payment request is no longer open")
+	}
+
+	paymentRequest.Status = models.PaymentRequestStatusDeclined
+	paymentRequest.DeclinedAt = &declinedAt
+	return s.respond(paymentRequest, userID)
+}
+
+// getOpenForPayer loads a request the user has to pay and checks it can still be paid
+func (s *PaymentRequestService) getOpenForPayer(userID, id int64) (*models.PaymentRequest, error) {
+	paymentRequest, err := s.getForUser(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if paymentRequest.PayerID != userID {
+		return nil, ErrPaymentRequestNotFound
+	}
+
+	if paymentRequest.Status == models.PaymentRequestStatusOpen && !time.Now().Before(paymentRequest.ExpiresAt) {
+		if _, err := s.paymentRequestRepo.Transition(id, models.PaymentRequestStatusOpen, models.PaymentRequestStatusExpired, nil); err != nil {
+			return nil, err
+		}
+		paymentRequest.Status = models.PaymentRequestStatusExpired
+	}
+	if paymentRequest.Status != models.PaymentRequestStatusOpen {
+		return nil, fmt.Errorf("payment request is %s", paymentRequest.Status)
+	}
+	return paymentRequest, nil
+}
+
+// getForUser hides requests the user is not part of behind the same not found error
+func (s *PaymentRequestService) getForUser(userID, id int64) (*models.PaymentRequest, error) {
+	paymentRequest, err := s.paymentRequestRepo.GetByID(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrPaymentRequestNotFound
+		}
+		return nil, err
+	}
+	if paymentRequest.RequesterID != userID && paymentRequest.PayerID != userID {
+		return nil, ErrPaymentRequestNotFound
+	}
+	return paymentRequest, nil
+}
+
+func (s *PaymentRequestService) expireOverdue() {
+	s.settlePaying()
+	if _, err := s.paymentRequestRepo.ExpireOverdue(time.Now()); err != nil {
+		log.Printf("[WARN] Failed to expire payment requests: %v", err)
+	}
+}
+
+func (s *PaymentRequestService) respond(paymentRequest *models.PaymentRequest, userID int64) (*response.PaymentRequestResponse, error) {
+	users, err := s.userRepo.GetByIDs([]int64{paymentRequest.RequesterID, paymentRequest.PayerID})
+	if err != nil {
+		return nil, err
+	}
+	return s.toResponse(paymentRequest, userID, users), nil
+}
+
+func (s *PaymentRequestService) toResponse(paymentRequest *models.PaymentRequest, userID int64, users map[int64]models.User) *response.PaymentRequestResponse {
+	direction := PaymentRequestIncoming
+	if paymentRequest.RequesterID == userID {
+		direction = PaymentRequestOutgoing
+	}
+
+	requester := users[paymentRequest.RequesterID]
+	payer := users[paymentRequest.PayerID]
+
+	result := &response.PaymentRequestResponse{
+		ID:                paymentRequest.ID,
+		Direction:         direction,
+		RequesterUsername: requester.Username,
+		RequesterAddress:  requester.WalletAddress,
+		PayerDisplayName:  maskDisplayName(payer.Username),
+		Amount:            paymentRequest.Amount,
+		Memo:              paymentRequest.Memo,
+		Status:            paymentRequest.Status,
+		TxHash:            paymentRequest.TxHash,
+		ExpiresAt:         paymentRequest.ExpiresAt,
+		PaidAt:            paymentRequest.PaidAt,
+		DeclinedAt:        paymentRequest.DeclinedAt,
+		CreatedAt:         paymentRequest.CreatedAt,
+	}
+	if paymentRequest.TxHash != "" {
+		result.StatusURL = "/api/transfer/status/" + paymentRequest.TxHash
+	}
+	return result
+}
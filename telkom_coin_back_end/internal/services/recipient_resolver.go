@@ -0,0 +1,241 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"strings"
+	"sync"
+	"telkom_coin_back_end/config"
+	"telkom_coin_back_end/internal/models"
+	"telkom_coin_back_end/internal/repository"
+	"telkom_coin_back_end/pkg/helpers"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"gorm.io/gorm"
+)
+
+const (
+	RecipientTypeAddress  = "address"
+	RecipientTypeUsername = "username"
+	RecipientTypeEmail    = "email"
+	RecipientTypePhone    = "phone"
+
+	recipientLookupCleanupPeriod = 10 * time.Minute
+)
+
+var (
+	// Same error for unknown, inactive and undiscoverable users, so lookups
+	// don't reveal which emails or phone numbers are registered
+	ErrRecipientNotFound     = errors.New("recipient not found")
+	ErrRecipientLookupLimit  = errors.New("too many recipient lookups, try again later")
+	ErrInvalidRecipientInput = errors.New("recipient must be a wallet address, @username, email or phone number")
+)
+
+// ResolvedRecipient is the wallet a transfer recipient handle points to
+type ResolvedRecipient struct {
+	Address     string
+	Type        string
+	User        *models.User // nil for an address that is not a TLC Wallet user
+	DisplayName string       // Masked username, empty when User is nil
+}
+
+// RecipientResolver turns a recipient handle (wallet address, @username, email
+// or phone number) into a wallet address. Handle lookups are limited per sender
+// with a sliding window so they can't be used to enumerate users.
+type RecipientResolver struct {
+	userRepo *repository.UserRepository
+	limit    int
+	window   time.Duration
+
+	mu      sync.Mutex
+	lookups map[int64][]time.Time // sender ID -> times of recent handle lookups
+
+	stopOnce sync.Once
+	stop     chan struct{}
+}
+
+func NewRecipientResolver(userRepo *repository.UserRepository) *RecipientResolver {
+	return &RecipientResolver{
+		userRepo: userRepo,
+		limit:    config.AppConfig.RecipientLookupLimit,
+		window:   config.AppConfig.RecipientLookupWindow,
+		lookups:  make(map[int64][]time.Time),
+		stop:     make(chan struct{}),
+	}
+}
+
+// Start forgets senders without a lookup in the window every ten minutes until
+// Stop is called, so the lookup map doesn't grow with every sender ever seen
+func (r *RecipientResolver) Start() {
+	go func() {
+		ticker := time.NewTicker(recipientLookupCleanupPeriod)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-r.stop:
+				return
+			case <-ticker.C:
+				if pruned := r.pruneLookups(time.Now()); pruned > 0 {
+					log.Printf("🧹 Forgot recipient lookups of %d idle senders", pruned)
+				}
+			}
+		}
+	}()
+}
+
+// Stop signals the cleanup loop to exit
+func (r *RecipientResolver) Stop() {
+	r.stopOnce.Do(func() { close(r.stop) })
+}
+
+// pruneLookups drops the senders whose last lookup is outside the window
+func (r *RecipientResolver) pruneLookups(now time.Time) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cutoff := now.Add(-r.window)
+	pruned := 0
+	for senderID, times := range r.lookups {
+		if len(times) == 0 || !times[len(times)-1].After(cutoff) {
+			delete(r.lookups, senderID)
+			pruned++
+		}
+	}
+	return pruned
+}
+
+// Resolve looks up the recipient for senderID. A wallet address resolves even
+// when it doesn't belong to a user; every other handle must match an active user.
+func (r *RecipientResolver) Resolve(senderID int64, handle string) (*ResolvedRecipient, error) {
+	handle = strings.TrimSpace(handle)
+
+	if common.IsHexAddress(handle) {
+		recipient := &ResolvedRecipient{Address: handle, Type: RecipientTypeAddress}
+		user, err := r.userRepo.GetByWalletAddress(handle)
+		if err != nil && err != gorm.ErrRecordNotFound {
+			return nil, err
+		}
+		if err == nil {
+			recipient.Address = user.WalletAddress
+			recipient.User = user
+			recipient.DisplayName = maskDisplayName(user.Username)
+		}
+		return recipient, nil
+	}
+
+	recipientType, err := recipientTypeOf(handle)
+	if err != nil {
+		return nil, err
+	}
+	if !r.allowLookup(senderID) {
+		return nil, ErrRecipientLookupLimit
+	}
+
+	var user *models.User
+	switch recipientType {
+	case RecipientTypeUsername:
+		user, err = r.userRepo.GetByUsername(strings.TrimPrefix(handle, "@"))
+	case RecipientTypeEmail:
+		user, err = r.userRepo.GetByEmail(handle)
+	case RecipientTypePhone:
+		user, err = r.getByPhone(handle)
+	}
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrRecipientNotFound
+		}
+		return nil, err
+	}
+	if user.Status != "active" {
+		return nil, ErrRecipientNotFound
+	}
+
+	return &ResolvedRecipient{
+		Address:     user.WalletAddress,
+		Type:        recipientType,
+		User:        user,
+		DisplayName: maskDisplayName(user.Username),
+	}, nil
+}
+
+// getByPhone matches the number in its local (08…) and international (62…, +62…)
+// forms. Users who opted out of phone discovery and numbers shared by more than
+// one account are treated as not found.
+func (r *RecipientResolver) getByPhone(phone string) (*models.User, error) {
+	users, err := r.userRepo.GetByPhones(phoneVariants(phone))
+	if err != nil {
+		return nil, err
+	}
+	if len(users) != 1 || !users[0].PhoneDiscoverable {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &users[0], nil
+}
+
+// allowLookup records a handle lookup for the sender and reports whether it is
+// within RECIPIENT_LOOKUP_LIMIT per RECIPIENT_LOOKUP_WINDOW
+func (r *RecipientResolver) allowLookup(senderID int64) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cutoff := time.Now().Add(-r.window)
+	recent := r.lookups[senderID][:0]
+	for _, at := range r.lookups[senderID] {
+		if at.After(cutoff) {
+			recent = append(recent, at)
+		}
+	}
+
+	if len(recent) >= r.limit {
+		r.lookups[senderID] = recent
+		return false
+	}
+	r.lookups[senderID] = append(recent, time.Now())
+	return true
+}
+
+func recipientTypeOf(handle string) (string, error) {
+	switch {
+	case strings.HasPrefix(handle, "@") && helpers.ValidateUsername(strings.TrimPrefix(handle, "@")):
+		return RecipientTypeUsername, nil
+	case helpers.ValidateEmail(handle):
+		return RecipientTypeEmail, nil
+	case helpers.ValidatePhone(handle):
+		return RecipientTypePhone, nil
+	}
+	return "", ErrInvalidRecipientInput
+}
+
+// phoneVariants returns the formats a number may have been saved in
+func phoneVariants(phone string) []string {
+	digits := strings.NewReplacer(" ", "", "-", "", "+", "").Replace(phone)
+
+	national := digits
+	switch {
+	case strings.HasPrefix(digits, "0"):
+		national = digits[1:]
+	case strings.HasPrefix(digits, "62"):
+		national = digits[2:]
+	}
+
+	return []string{phone, digits, "+" + digits, "0" + national, "62" + national, "+62" + national}
+}
+
+// maskDisplayName keeps the first and last character, e.g. "budi" -> "b**i"
+func maskDisplayName(name string) string {
+	runes := []rune(name)
+	switch len(runes) {
+	case 0:
+		return ""
+	case 1, 2:
+		return string(runes[0]) + "*"
+	}
+
+	stars := len(runes) - 2
+	if stars > 5 {
+		stars = 5
+	}
+	return string(runes[0]) + strings.Repeat("*", stars) + string(runes[len(runes)-1])
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRecipientResolverPruneLookups(t *testing.T) {
+	now := time.Now()
+	r := &RecipientResolver{
+		limit:  30,
+		window: time.Hour,
+		lookups: map[int64][]time.Time{
+			1: {now.Add(-2 * time.Hour), now.Add(-time.Minute)},      // Still looking up
+			2: {now.Add(-3 * time.Hour), now.Add(-90 * time.Minute)}, // Idle
+			3: {now.Add(-time.Hour)},                                 // Just left the window
+			4: {},
+		},
+	}
+
+	if pruned := r.pruneLookups(now); pruned != 3 {
+		t.Errorf("pruneLookups = %d, want 3", pruned)
+	}
+
+	tests := []struct {
+		senderID int64
+		kept     bool
+	}{
+		{1, true},
+		{2, false},
+		{3, false},
+		{4, false},
+	}
+	for _, tt := range tests {
+		if _, kept := r.lookups[tt.senderID]; kept != tt.kept {
+			t.Errorf("sender %d kept = %v, want %v", tt.senderID, kept, tt.kept)
+		}
+	}
+}
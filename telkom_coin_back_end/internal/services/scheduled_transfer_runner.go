@@ -0,0 +1,336 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"sync"
+	"telkom_coin_back_end/config"
+	"telkom_coin_back_end/internal/models"
+	"telkom_coin_back_end/internal/repository"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	scheduledTransferBatchSize = 50
+	// A claimed schedule is not picked up again for this long, in case the
+	// process dies between claiming and recording the run
+	scheduledTransferLease = 10 * time.Minute
+)
+
+// ScheduledTransferRunner sends due scheduled transfers through the same
+// balance check and broadcast as /api/transfer. A failed run (usually a short
+// balance) is retried every SCHEDULED_TRANSFER_RETRY_INTERVAL up to
+// SCHEDULED_TRANSFER_MAX_RETRIES times, then that occurrence is skipped.
+// Each occurrence has one execution row, saved as sending before the broadcast.
+type ScheduledTransferRunner struct {
+	scheduleRepo  *repository.ScheduledTransferRepository
+	walletService *TLCWalletService
+	interval      time.Duration
+	retryInterval time.Duration
+	maxRetries    int
+
+	runMu    sync.Mutex
+	stopOnce sync.Once
+	stop     chan struct{}
+}
+
+func NewScheduledTransferRunner(
+	scheduleRepo *repository.ScheduledTransferRepository,
+	walletService *TLCWalletService,
+) *ScheduledTransferRunner {
+	return &ScheduledTransferRunner{
+		scheduleRepo:  scheduleRepo,
+		walletService: walletService,
+		interval:      config.AppConfig.ScheduledTransferPollInterval,
+		retryInterval: config.AppConfig.ScheduledTransferRetryInterval,
+		maxRetries:    config.AppConfig.ScheduledTransferMaxRetries,
+		stop:          make(chan struct{}),
+	}
+}
+
+// Start runs due schedules every SCHEDULED_TRANSFER_POLL_INTERVAL until Stop is called
+func (r *ScheduledTransferRunner) Start() {
+	go func() {
+		log.Printf("🗓️  Scheduled transfer runner started (every %s)", r.interval)
+
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-r.stop:
+				log.Println("Scheduled transfer runner stopped")
+				return
+			case <-ticker.C:
+				if _, err := r.RunOnce(); err != nil {
+					log.Printf("[ERROR] Scheduled transfer run failed: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+// Stop signals the runner loop to exit
+func (r *ScheduledTransferRunner) Stop() {
+	r.stopOnce.Do(func() { close(r.stop) })
+}
+
+// RunOnce sends every schedule that is due now and returns how many were run
+func (r *ScheduledTransferRunner) RunOnce() (int, error) {
+	if !r.runMu.TryLock() {
+		return 0, errors.New("scheduled transfer run already in progress")
+	}
+	defer r.runMu.Unlock()
+
+	now := time.Now()
+	r.reconcileSending(now.Add(-scheduledTransferLease))
+
+	schedules, err := r.scheduleRepo.GetDue(now, scheduledTransferBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	ran := 0
+	for i := range schedules {
+		schedule := &schedules[i]
+
+		claimed, err := r.scheduleRepo.Claim(schedule.ID, now, now.Add(scheduledTransferLease))
+		if err != nil {
+			log.Printf("[ERROR] Failed to claim scheduled transfer %d: %v", schedule.ID, err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		if err := r.run(schedule); err != nil {
+			log.Printf("[ERROR] Failed to record scheduled transfer %d: %v", schedule.ID, err)
+			continue
+		}
+		ran++
+	}
+	return ran, nil
+}
+
+// run records the occurrence as sending and advances the schedule before the
+// broadcast, so a crash after it can never send the occurrence twice; the
+// result then settles the execution (and rewinds the schedule for a retry)
+func (r *ScheduledTransferRunner) run(schedule *models.ScheduledTransfer) error {
+	execution, advanced, err := r.begin(schedule)
+	if err != nil || execution == nil {
+		return err
+	}
+
+	result, sendErr := r.walletService.SendScheduledTransfer(schedule.UserID, schedule.ID, schedule.ToAddress, schedule.Amount, schedule.Memo)
+	now := time.Now()
+	switch {
+	case sendErr == nil:
+		log.Printf("🗓️  Scheduled transfer %d sent %s", schedule.ID, result.TxHash)
+		return r.finish(execution, models.ScheduleExecutionSent, map[string]interface{}{"tx_hash": result.TxHash, "error": ""}, schedule.ID, map[string]interface{}{"last_error": ""})
+
+	case schedule.RetryCount < r.maxRetries:
+		log.Printf("[WARN] Scheduled transfer %d failed, retrying in %s: %v", schedule.ID, r.retryInterval, sendErr)
+		// Back to the occurrence that failed
+		fields := map[string]interface{}{
+			"occurrence_index": execution.Occurrence,
+			"retry_count":      schedule.RetryCount + 1,
+			"next_run_at":      now.Add(r.retryInterval),
+			"last_error":       sendErr.Error(),
+		}
+		return r.scheduleRepo.Transaction(func(tx *gorm.DB) error {
+			scheduleRepo := r.scheduleRepo.WithTx(tx)
+			if _, err := scheduleRepo.TransitionExecution(execution.ID, models.ScheduleExecutionSending, models.ScheduleExecutionRetrying, map[string]interface{}{"error": sendErr.Error()}); err != nil {
+				return err
+			}
+			return r.updateSchedule(scheduleRepo, schedule.ID, statusOf(advanced), fields)
+		})
+
+	default:
+		log.Printf("[WARN] Scheduled transfer %d failed after %d attempts, skipping this run: %v", schedule.ID, execution.Attempt, sendErr)
+		return r.finish(execution, models.ScheduleExecutionFailed, map[string]interface{}{"error": sendErr.Error()}, schedule.ID, map[string]interface{}{"last_error": sendErr.Error()})
+	}
+}
+
+// begin saves the sending execution of the current occurrence and moves the
+// schedule past it in one DB transaction. A nil execution means the occurrence
+// must not be sent now (it is already sending, or was run before).
+func (r *ScheduledTransferRunner) begin(schedule *models.ScheduledTransfer) (*models.ScheduledTransferExecution, map[string]interface{}, error) {
+	now := time.Now()
+	fields := map[string]interface{}{
+		"last_run_at": now,
+	}
+	r.advance(schedule, now, fields)
+
+	var execution *models.ScheduledTransferExecution
+	err := r.scheduleRepo.Transaction(func(tx *gorm.DB) error {
+		scheduleRepo := r.scheduleRepo.WithTx(tx)
+
+		existing, err := scheduleRepo.GetExecution(schedule.ID, schedule.OccurrenceIndex)
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			existing = &models.ScheduledTransferExecution{
+				ScheduleID:   schedule.ID,
+				Occurrence:   schedule.OccurrenceIndex,
+				ScheduledFor: occurrenceAt(schedule, schedule.OccurrenceIndex),
+				Attempt:      schedule.RetryCount + 1,
+				Status:       models.ScheduleExecutionSending,
+			}
+			// The unique (schedule_id, occurrence) key stops a second worker here
+			if err := scheduleRepo.CreateExecution(existing); err != nil {
+				return err
+			}
+			execution = existing
+
+		case err != nil:
+			return err
+
+		case existing.Status == models.ScheduleExecutionRetrying:
+			updated, err := scheduleRepo.TransitionExecution(existing.ID, models.ScheduleExecutionRetrying, models.ScheduleExecutionSending, map[string]interface{}{
+				"attempt": schedule.RetryCount + 1,
+			})
+			if err != nil {
+				return err
+			}
+			if !updated {
+				return errors.New("execution changed during the run")
+			}
+			existing.Attempt = schedule.RetryCount + 1
+			execution = existing
+
+		case existing.Status == models.ScheduleExecutionSending:
+			// Left by a run that died; reconcileSending settles it, never resent
+			log.Printf("[WARN] Scheduled transfer %d occurrence %d is still sending, not sent again", schedule.ID, existing.Occurrence)
+		}
+
+		return r.updateSchedule(scheduleRepo, schedule.ID, models.ScheduleStatusActive, fields)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return execution, fields, nil
+}
+
+// finish settles a sending execution and updates the schedule with it
+func (r *ScheduledTransferRunner) finish(execution *models.ScheduledTransferExecution, status string, executionFields map[string]interface{}, scheduleID int64, fields map[string]interface{}) error {
+	return r.scheduleRepo.Transaction(func(tx *gorm.DB) error {
+		scheduleRepo := r.scheduleRepo.WithTx(tx)
+		if _, err := scheduleRepo.TransitionExecution(execution.ID, models.ScheduleExecutionSending, status, executionFields); err != nil {
+			return err
+		}
+		return scheduleRepo.UpdateFields(scheduleID, fields)
+	})
+}
+
+// updateSchedule applies fields while the schedule is still in from. A pause or
+// cancel during the run wins, but the occurrence still counts as run.
+func (r *ScheduledTransferRunner) updateSchedule(scheduleRepo *repository.ScheduledTransferRepository, scheduleID int64, from string, fields map[string]interface{}) error {
+	updated, err := scheduleRepo.Transition(scheduleID, from, models.ScheduleStatusActive, fields)
+	if err != nil || updated {
+		return err
+	}
+	if from != models.ScheduleStatusActive {
+		// Not rewinding a completed schedule that changed meanwhile
+		return nil
+	}
+	copied := make(map[string]interface{}, len(fields))
+	for key, value := range fields {
+		copied[key] = value
+	}
+	delete(copied, "status")
+	delete(copied, "next_run_at")
+	return scheduleRepo.UpdateFields(scheduleID, copied)
+}
+
+// reconcileSending settles executions left sending by a run that died between
+// recording them and saving the result: a transfer saved since then means it
+// was sent, otherwise it is marked failed. Neither case sends it again.
+func (r *ScheduledTransferRunner) reconcileSending(before time.Time) {
+	executions, err := r.scheduleRepo.GetStaleSending(before, scheduledTransferBatchSize)
+	if err != nil {
+		log.Printf("[ERROR] Failed to load sending scheduled transfers: %v", err)
+		return
+	}
+
+	for i := range executions {
+		execution := &executions[i]
+		schedule, err := r.scheduleRepo.GetByID(execution.ScheduleID)
+		if err != nil {
+			log.Printf("[ERROR] Failed to load scheduled transfer %d: %v", execution.ScheduleID, err)
+			continue
+		}
+
+		txHash, err := r.walletService.FindSentTransfer(schedule.UserID, schedule.ToAddress, schedule.Amount, execution.UpdatedAt)
+		if err != nil {
+			log.Printf("[ERROR] Failed to look up scheduled transfer %d: %v", schedule.ID, err)
+			continue
+		}
+
+		status := models.ScheduleExecutionSent
+		fields := map[string]interface{}{"tx_hash": txHash}
+		if txHash == "" {
+			status = models.ScheduleExecutionFailed
+			fields = map[string]interface{}{"error": "interrupted before the transfer was saved, not sent again"}
+		}
+		if _, err := r.scheduleRepo.TransitionExecution(execution.ID, models.ScheduleExecutionSending, status, fields); err != nil {
+			log.Printf("[ERROR] Failed to settle scheduled transfer %d occurrence %d: %v", schedule.ID, execution.Occurrence, err)
+			continue
+		}
+		log.Printf("🗓️  Scheduled transfer %d occurrence %d was interrupted, settled as %s", schedule.ID, execution.Occurrence, status)
+	}
+}
+
+// advance moves the schedule to its next occurrence, or completes it. When
+// runs were missed (e.g. downtime) only the latest one is still sent.
+func (r *ScheduledTransferRunner) advance(schedule *models.ScheduledTransfer, now time.Time, fields map[string]interface{}) {
+	fields["retry_count"] = 0
+
+	index := schedule.OccurrenceIndex + 1
+	fields["occurrence_index"] = index
+	if schedule.Frequency == models.ScheduleFrequencyOnce {
+		fields["status"] = models.ScheduleStatusCompleted
+		return
+	}
+
+	for !occurrenceAt(schedule, index+1).After(now) {
+		index++
+	}
+	nextRunAt := occurrenceAt(schedule, index)
+
+	if schedule.EndAt != nil && nextRunAt.After(*schedule.EndAt) {
+		fields["status"] = models.ScheduleStatusCompleted
+		return
+	}
+	fields["occurrence_index"] = index
+	fields["next_run_at"] = nextRunAt
+}
+
+func statusOf(fields map[string]interface{}) string {
+	if status, ok := fields["status"].(string); ok {
+		return status
+	}
+	return models.ScheduleStatusActive
+}
+
+// occurrenceAt returns when occurrence index of a schedule is due. Monthly
+// schedules keep the start day, or the last day of shorter months.
+func occurrenceAt(schedule *models.ScheduledTransfer, index int) time.Time {
+	start := schedule.StartAt
+	switch schedule.Frequency {
+	case models.ScheduleFrequencyDaily:
+		return start.AddDate(0, 0, index)
+	case models.ScheduleFrequencyWeekly:
+		return start.AddDate(0, 0, 7*index)
+	case models.ScheduleFrequencyMonthly:
+		firstOfMonth := time.Date(start.Year(), start.Month()+time.Month(index), 1, start.Hour(), start.Minute(), start.Second(), start.Nanosecond(), start.Location())
+		lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+		day := start.Day()
+		if day > lastDay {
+			day = lastDay
+		}
+		return firstOfMonth.AddDate(0, 0, day-1)
+	default:
+		return start
+	}
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"telkom_coin_back_end/internal/models"
+	"testing"
+	"time"
+)
+
+var testLocation = time.FixedZone("WIB", 7*60*60)
+
+func testDate(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 9, 30, 0, 0, testLocation)
+}
+
+func TestOccurrenceAt(t *testing.T) {
+	tests := []struct {
+		name      string
+		frequency string
+		start     time.Time
+		index     int
+		want      time.Time
+	}{
+		{name: "once", frequency: models.ScheduleFrequencyOnce, start: testDate(2024, 1, 31), index: 3, want: testDate(2024, 1, 31)},
+		{name: "daily first", frequency: models.ScheduleFrequencyDaily, start: testDate(2024, 1, 31), index: 0, want: testDate(2024, 1, 31)},
+		{name: "daily across month", frequency: models.ScheduleFrequencyDaily, start: testDate(2024, 1, 31), index: 1, want: testDate(2024, 2, 1)},
+		{name: "weekly", frequency: models.ScheduleFrequencyWeekly, start: testDate(2024, 12, 25), index: 2, want: testDate(2025, 1, 8)},
+		{name: "monthly", frequency: models.ScheduleFrequencyMonthly, start: testDate(2024, 1, 15), index: 11, want: testDate(2024, 12, 15)},
+		{name: "monthly next year", frequency: models.ScheduleFrequencyMonthly, start: testDate(2024, 1, 15), index: 12, want: testDate(2025, 1, 15)},
+		{name: "monthly end of leap February", frequency: models.ScheduleFrequencyMonthly, start: testDate(2024, 1, 31), index: 1, want: testDate(2024, 2, 29)},
+		{name: "monthly back to the 31st", frequency: models.ScheduleFrequencyMonthly, start: testDate(2024, 1, 31), index: 2, want: testDate(2024, 3, 31)},
+		{name: "monthly 30-day month", frequency: models.ScheduleFrequencyMonthly, start: testDate(2024, 1, 31), index: 3, want: testDate(2024, 4, 30)},
+		{name: "monthly end of February", frequency: models.ScheduleFrequencyMonthly, start: testDate(2024, 1, 31), index: 13, want: testDate(2025, 2, 28)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule := &models.ScheduledTransfer{Frequency: tt.frequency, StartAt: tt.start}
+			if got := occurrenceAt(schedule, tt.index); !got.Equal(tt.want) {
+				t.Errorf("occurrenceAt(%d) = %s, want %s", tt.index, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScheduledTransferAdvance(t *testing.T) {
+	start := testDate(2024, 1, 1)
+	endAt := testDate(2024, 1, 3)
+
+	tests := []struct {
+		name          string
+		schedule      models.ScheduledTransfer
+		now           time.Time
+		wantIndex     int
+		wantStatus    string
+		wantNextRunAt time.Time
+	}{
+		{
+			name:       "once completes",
+			schedule:   models.ScheduledTransfer{Frequency: models.ScheduleFrequencyOnce, StartAt: start},
+			now:        start,
+			wantIndex:  1,
+			wantStatus: models.ScheduleStatusCompleted,
+		},
+		{
+			name:          "daily moves to the next day",
+			schedule:      models.ScheduledTransfer{Frequency: models.ScheduleFrequencyDaily, StartAt: start},
+			now:           start.Add(time.Minute),
+			wantIndex:     1,
+			wantStatus:    models.ScheduleStatusActive,
+			wantNextRunAt: testDate(2024, 1, 2),
+		},
+		{
+			name:          "missed runs are skipped to the latest",
+			schedule:      models.ScheduledTransfer{Frequency: models.ScheduleFrequencyDaily, StartAt: start},
+			now:           testDate(2024, 1, 5).Add(time.Hour),
+			wantIndex:     4,
+			wantStatus:    models.ScheduleStatusActive,
+			wantNextRunAt: testDate(2024, 1, 5),
+		},
+		{
+			name:          "last occurrence before end",
+			schedule:      models.ScheduledTransfer{Frequency: models.ScheduleFrequencyDaily, StartAt: start, EndAt: &endAt, OccurrenceIndex: 1},
+			now:           testDate(2024, 1, 2),
+			wantIndex:     2,
+			wantStatus:    models.ScheduleStatusActive,
+			wantNextRunAt: endAt,
+		},
+		{
+			name:       "past end completes",
+			schedule:   models.ScheduledTransfer{Frequency: models.ScheduleFrequencyDaily, StartAt: start, EndAt: &endAt, OccurrenceIndex: 2},
+			now:        endAt,
+			wantIndex:  3,
+			wantStatus: models.ScheduleStatusCompleted,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields := map[string]interface{}{"retry_count": 2}
+			runner := &ScheduledTransferRunner{}
+			runner.advance(&tt.schedule, tt.now, fields)
+
+			if fields["occurrence_index"] != tt.wantIndex {
+				t.Errorf("occurrence_index = %v, want %d", fields["occurrence_index"], tt.wantIndex)
+			}
+			if status := statusOf(fields); status != tt.wantStatus {
+				t.Errorf("status = %s, want %s", status, tt.wantStatus)
+			}
+			if fields["retry_count"] != 0 {
+				t.Errorf("retry_count = %v, want 0", fields["retry_count"])
+			}
+			next, hasNext := fields["next_run_at"].(time.Time)
+			if tt.wantNextRunAt.IsZero() {
+				if hasNext {
+					t.Errorf("next_run_at = %s, want none", next)
+				}
+			} else if !hasNext || !next.Equal(tt.wantNextRunAt) {
+				t.Errorf("next_run_at = %v, want %s", fields["next_run_at"], tt.wantNextRunAt)
+			}
+		})
+	}
+}
@@ -0,0 +1,371 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+	"telkom_coin_back_end/internal/dto/request"
+	"telkom_coin_back_end/internal/dto/response"
+	"telkom_coin_back_end/internal/models"
+	"telkom_coin_back_end/internal/repository"
+	"telkom_coin_back_end/pkg/crypto"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Start times this far in the past still count as "now" (client clock skew)
+const scheduleStartGrace = time.Minute
+
+var ErrScheduledTransferNotFound = errors.New("scheduled transfer not found")
+
+// ScheduledTransferService manages future-dated and recurring transfers. The PIN
+// is checked when a schedule is created or edited; the scheduled transfer worker
+// then sends each run without it.
+type ScheduledTransferService struct {
+	scheduleRepo      *repository.ScheduledTransferRepository
+	userRepo          *repository.UserRepository
+	recipientResolver *RecipientResolver
+}
+
+func NewScheduledTransferService(
+	scheduleRepo *repository.ScheduledTransferRepository,
+	userRepo *repository.UserRepository,
+	recipientResolver *RecipientResolver,
+) *ScheduledTransferService {
+	return &ScheduledTransferService{
+		scheduleRepo:      scheduleRepo,
+		userRepo:          userRepo,
+		recipientResolver: recipientResolver,
+	}
+}
+
+// Create sets up a schedule to a registered recipient
+func (s *ScheduledTransferService) Create(userID int64, req *request.CreateScheduledTransferRequest) (*response.ScheduledTransferResponse, error) {
+	user, err := s.authorize(userID, req.Pin)
+	if err != nil {
+		return nil, err
+	}
+
+	recipient, err := s.recipientResolver.Resolve(userID, req.ToAddress)
+	if err != nil {
+		return nil, err
+	}
+	if recipient.User == nil {
+		return nil, errors.New("recipient address not found in system")
+	}
+	if strings.EqualFold(recipient.Address, user.WalletAddress) {
+		return nil, errors.New("cannot transfer to yourself")
+	}
+
+	amount, err := parseScheduleAmount(req.Amount)
+	if err != nil {
+		return nil, err
+	}
+	memo, err := normalizeMemo(req.Memo)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	startAt := now
+	if req.StartAt != nil {
+		if req.StartAt.Before(now.Add(-scheduleStartGrace)) {
+			return nil, errors.New("start_at must not be in the past")
+		}
+		startAt = *req.StartAt
+	}
+	if req.EndAt != nil && req.EndAt.Before(startAt) {
+		return nil, errors.New("end_at must be after start_at")
+	}
+
+	schedule := &models.ScheduledTransfer{
+		UserID:    userID,
+		ToAddress: recipient.Address,
+		Amount:    amount,
+		Memo:      memo,
+		Frequency: req.Frequency,
+		StartAt:   startAt,
+		EndAt:     req.EndAt,
+		Status:    models.ScheduleStatusActive,
+		NextRunAt: startAt,
+	}
+	if err := s.scheduleRepo.Create(schedule); err != nil {
+		return nil, err
+	}
+
+	log.Printf("🗓️  Scheduled transfer %d: user %d sends %s TLC %s from %s", schedule.ID, userID, amount, schedule.Frequency, startAt.Format(time.RFC3339))
+	return newScheduledTransferResponse(schedule), nil
+}
+
+// List returns the user's schedules, newest first
+func (s *ScheduledTransferService) List(userID int64, page, limit int) (*response.ScheduledTransferListResponse, error) {
+	schedules, total, err := s.scheduleRepo.GetByUserID(userID, page, limit)
+	if err != nil {
+		log.Printf("[ERROR] Failed to get scheduled transfers: %v", err)
+		return nil, errors.New("failed to retrieve scheduled transfers")
+	}
+
+	result := &response.ScheduledTransferListResponse{
+		Schedules:  make([]response.ScheduledTransferResponse, 0, len(schedules)),
+		TotalCount: int(total),
+		Page:       page,
+		Limit:      limit,
+		TotalPages: (int(total) + limit - 1) / limit,
+	}
+	for i := range schedules {
+		result.Schedules = append(result.Schedules, *newScheduledTransferResponse(&schedules[i]))
+	}
+	return result, nil
+}
+
+// Get returns one of the user's schedules
+func (s *ScheduledTransferService) Get(userID, id int64) (*response.ScheduledTransferResponse, error) {
+	schedule, err := s.getForUser(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	return newScheduledTransferResponse(schedule), nil
+}
+
+// Update changes the amount, memo or end of an active or paused schedule
+func (s *ScheduledTransferService) Update(userID, id int64, req *request.UpdateScheduledTransferRequest) (*response.ScheduledTransferResponse, error) {
+	if _, err := s.authorize(userID, req.Pin); err != nil {
+		return nil, err
+	}
+
+	schedule, err := s.getEditable(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]interface{}{}
+	if req.Amount != "" {
+		amount, err := parseScheduleAmount(req.Amount)
+		if err != nil {
+			return nil, err
+		}
+		fields["amount"] = amount
+		schedule.Amount = amount
+	}
+	if req.Memo != nil {
+		memo, err := normalizeMemo(*req.Memo)
+		if err != nil {
+			return nil, err
+		}
+		fields["memo"] = memo
+		schedule.Memo = memo
+	}
+	if req.EndAt != nil {
+		if req.EndAt.Before(time.Now()) || req.EndAt.Before(schedule.StartAt) {
+			return nil, errors.New("end_at must be in the future and after start_at")
+		}
+		fields["end_at"] = *req.EndAt
+		schedule.EndAt = req.EndAt
+	}
+	if len(fields) == 0 {
+		return nil, errors.New("nothing to update")
+	}
+
+	if err := s.scheduleRepo.UpdateFields(id, fields); err != nil {
+		return nil, err
+	}
+	return newScheduledTransferResponse(schedule), nil
+}
+
+// Pause stops runs until the schedule is resumed
+func (s *ScheduledTransferService) Pause(userID, id int64) (*response.ScheduledTransferResponse, error) {
+	if _, err := s.getForUser(userID, id); err != nil {
+		return nil, err
+	}
+
+	paused, err := s.scheduleRepo.Transition(id, models.ScheduleStatusActive, models.ScheduleStatusPaused, nil)
+	if err != nil {
+		return nil, err
+	}
+	if !paused {
+		return nil, errors.New("only an active scheduled transfer can be paused")
+	}
+	return s.Get(userID, id)
+}
+
+// Resume continues a paused schedule. Occurrences missed while paused are
+// skipped, not sent late.
+func (s *ScheduledTransferService) Resume(userID, id int64) (*response.ScheduledTransferResponse, error) {
+	schedule, err := s.getForUser(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if schedule.Status != models.ScheduleStatusPaused {
+		return nil, errors.New("only a paused scheduled transfer can be resumed")
+	}
+
+	now := time.Now()
+	index := schedule.OccurrenceIndex
+	var nextRunAt time.Time
+	if schedule.Frequency == models.ScheduleFrequencyOnce {
+		if index > 0 {
+			return nil, errors.New("scheduled transfer has already run")
+		}
+		// A one-off transfer that came due while paused is sent right away
+		nextRunAt = occurrenceAt(schedule, index)
+		if nextRunAt.Before(now) {
+			nextRunAt = now
+		}
+	} else {
+		for occurrenceAt(schedule, index).Before(now) {
+			index++
+		}
+		nextRunAt = occurrenceAt(schedule, index)
+	}
+	if schedule.EndAt != nil && nextRunAt.After(*schedule.EndAt) {
+		return nil, errors.New("scheduled transfer has no runs left before end_at")
+	}
+
+	resumed, err := s.scheduleRepo.Transition(id, models.ScheduleStatusPaused, models.ScheduleStatusActive, map[string]interface{}{
+		"occurrence_index": index,
+		"next_run_at":      nextRunAt,
+		"retry_count":      0,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !resumed {
+		return nil, errors.New("only a paused scheduled transfer can be resumed")
+	}
+	return s.Get(userID, id)
+}
+
+// Cancel stops the schedule for good
+func (s *ScheduledTransferService) Cancel(userID, id int64) (*response.ScheduledTransferResponse, error) {
+	if _, err := s.getEditable(userID, id); err != nil {
+		return nil, err
+	}
+
+	for _, from := range []string{models.ScheduleStatusActive, models.ScheduleStatusPaused} {
+		cancelled, err := s.scheduleRepo.Transition(id, from, models.ScheduleStatusCancelled, nil)
+		if err != nil {
+			return nil, err
+		}
+		if cancelled {
+			return s.Get(userID, id)
+		}
+	}
+	return nil, errors.New("scheduled transfer can no longer be cancelled")
+}
+
+// GetExecutions returns the run history of one of the user's schedules
+func (s *ScheduledTransferService) GetExecutions(userID, id int64, page, limit int) (*response.ScheduledTransferExecutionListResponse, error) {
+	if _, err := s.getForUser(userID, id); err != nil {
+		return nil, err
+	}
+
+	executions, total, err := s.scheduleRepo.GetExecutions(id, page, limit)
+	if err != nil {
+		log.Printf("[ERROR] Failed to get scheduled transfer executions: %v", err)
+		return nil, errors.New("failed to retrieve scheduled transfer history")
+	}
+
+	result := &response.ScheduledTransferExecutionListResponse{
+		Executions: make([]response.ScheduledTransferExecutionResponse, 0, len(executions)),
+		TotalCount: int(total),
+		Page:       page,
+		Limit:      limit,
+		TotalPages: (int(total) + limit - 1) / limit,
+	}
+	for _, execution := range executions {
+		item := response.ScheduledTransferExecutionResponse{
+			ID:           execution.ID,
+			ScheduledFor: execution.ScheduledFor,
+			Attempt:      execution.Attempt,
+			Status:       execution.Status,
+			TxHash:       execution.TxHash,
+			Error:        execution.Error,
+			CreatedAt:    execution.CreatedAt,
+		}
+		if execution.TxHash != "" {
+			item.StatusURL = "/api/transfer/status/" + execution.TxHash
+		}
+		result.Executions = append(result.Executions, item)
+	}
+	return result, nil
+}
+
+// authorize checks the account and PIN before a schedule is created or changed
+func (s *ScheduledTransferService) authorize(userID int64, pin string) (*models.User, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.New("user not found")
+		}
+		return nil, err
+	}
+	if user.Status != "active" {
+		return nil, errors.New("account is not active")
+	}
+	if !crypto.VerifyPin(pin, user.PinHash) {
+		return nil, errors.New("invalid PIN")
+	}
+	return user, nil
+}
+
+func (s *ScheduledTransferService) getForUser(userID, id int64) (*models.ScheduledTransfer, error) {
+	schedule, err := s.scheduleRepo.GetByID(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrScheduledTransferNotFound
+		}
+		return nil, err
+	}
+	if schedule.UserID != userID {
+		return nil, ErrScheduledTransferNotFound
+	}
+	return schedule, nil
+}
+
+func (s *ScheduledTransferService) getEditable(userID, id int64) (*models.ScheduledTransfer, error) {
+	schedule, err := s.getForUser(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if schedule.Status != models.ScheduleStatusActive && schedule.Status != models.ScheduleStatusPaused {
+		return nil, fmt.Errorf("scheduled transfer is %s", schedule.Status)
+	}
+	return schedule, nil
+}
+
+// parseScheduleAmount accepts a positive whole TLC amount
+func parseScheduleAmount(amount string) (string, error) {
+	amountToken, ok := new(big.Int).SetString(amount, 10)
+	if !ok {
+		return "", errors.New("invalid amount format")
+	}
+	if amountToken.Sign() <= 0 {
+		return "", errors.New("amount must be greater than 0")
+	}
+	return amountToken.String(), nil
+}
+
+func newScheduledTransferResponse(schedule *models.ScheduledTransfer) *response.ScheduledTransferResponse {
+	result := &response.ScheduledTransferResponse{
+		ID:         schedule.ID,
+		ToAddress:  schedule.ToAddress,
+		Amount:     schedule.Amount,
+		Memo:       schedule.Memo,
+		Frequency:  schedule.Frequency,
+		StartAt:    schedule.StartAt,
+		EndAt:      schedule.EndAt,
+		Status:     schedule.Status,
+		RetryCount: schedule.RetryCount,
+		LastRunAt:  schedule.LastRunAt,
+		LastError:  schedule.LastError,
+		CreatedAt:  schedule.CreatedAt,
+	}
+	if schedule.Status == models.ScheduleStatusActive || schedule.Status == models.ScheduleStatusPaused {
+		nextRunAt := schedule.NextRunAt
+		result.NextRunAt = &nextRunAt
+	}
+	return result
+}
@@ -1,11 +1,14 @@
 package service
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"log"
 	"math/big" // 👈 1. DITAMBAHKAN import "strings"
 	"strconv"
 	"strings"
+	"telkom_coin_back_end/config"
 	"telkom_coin_back_end/internal/blockchain"
 	"telkom_coin_back_end/internal/dto/response"
 	"telkom_coin_back_end/internal/models"
@@ -13,11 +16,24 @@ import (
 	"telkom_coin_back_end/internal/web3"
 	"telkom_coin_back_end/pkg/crypto"
 	"time"
+	"unicode/utf8"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/skip2/go-qrcode"
 	"gorm.io/gorm"
 )
 
+// maxMemoLength keeps the paymentTransfer note (stored on chain) small
+const maxMemoLength = 100
+
+// A broadcast transfer's row is retried this often before giving up
+const (
+	transferSaveAttempts = 3
+	transferSaveBackoff  = 500 * time.Millisecond
+)
+
+var ErrInsufficientOnChainBalance = errors.New("insufficient on-chain balance")
+
 type TLCWalletService struct {
 	userRepo          *repository.UserRepository
 	balanceRepo       *repository.BalanceRepository
@@ -25,6 +41,10 @@ type TLCWalletService struct {
 	blockchainService *blockchain.BlockchainService
 	contractService   *web3.ContractService
 	explorerService   *BlockchainExplorerService // 👈 TAMBAHKAN INI
+	tracker           *TransactionTracker
+	recipientResolver *RecipientResolver
+	quoteSigner       *TransferQuoteSigner
+	limitService      *LimitService
 }
 
 func NewTLCWalletService(
@@ -33,6 +53,10 @@ func NewTLCWalletService(
 	txRepo *repository.TransactionRepository,
 	blockchainService *blockchain.BlockchainService,
 	explorerService *BlockchainExplorerService, // 👈 TAMBAHKAN PARAMETER INI
+	tracker *TransactionTracker,
+	recipientResolver *RecipientResolver,
+	quoteSigner *TransferQuoteSigner,
+	limitService *LimitService,
 ) *TLCWalletService {
 	// Initialize contract service if blockchain is enabled
 	var contractService *web3.ContractService
@@ -50,6 +74,10 @@ func NewTLCWalletService(
 		blockchainService: blockchainService,
 		contractService:   contractService,
 		explorerService:   explorerService, // 👈 ASSIGN DI SINI
+		tracker:           tracker,
+		recipientResolver: recipientResolver,
+		quoteSigner:       quoteSigner,
+		limitService:      limitService,
 	}
 }
 
@@ -87,7 +115,7 @@ func (s *TLCWalletService) GetBlockchainBalance(walletAddress string) (*response
 	}, nil
 }
 
-func (s *TLCWalletService) ValidateTransfer(userID int64, toAddress, amount string) (*response.ValidateTransferResponse, error) {
+func (s *TLCWalletService) ValidateTransfer(userID int64, toAddress, amount, memo string) (*response.ValidateTransferResponse, error) {
 	// Validasi user
 	user, err := s.userRepo.GetByID(userID)
 	if err != nil {
@@ -100,13 +128,18 @@ func (s *TLCWalletService) ValidateTransfer(userID int64, toAddress, amount stri
 		return nil, errors.New("account is not active")
 	}
 
-	// Validasi format alamat
-	if !common.IsHexAddress(toAddress) {
-		return nil, errors.New("invalid recipient address format")
+	// Resolve penerima: alamat wallet, @username, email atau nomor HP
+	recipient, err := s.recipientResolver.Resolve(userID, toAddress)
+	if err != nil {
+		return nil, err
+	}
+	if recipient.User == nil {
+		return nil, errors.New("recipient address not found in system")
 	}
-	if toAddress == user.WalletAddress {
+	if strings.EqualFold(recipient.Address, user.WalletAddress) {
 		return nil, errors.New("cannot transfer to yourself")
 	}
+	toAddress = recipient.Address
 
 	// Validasi amount format
 	amountToken := new(big.Int)
@@ -118,6 +151,16 @@ func (s *TLCWalletService) ValidateTransfer(userID int64, toAddress, amount stri
 		return nil, errors.New("amount must be greater than 0")
 	}
 
+	memo, err = normalizeMemo(memo)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cek limit transfer (per transaksi, harian, bulanan)
+	if err := s.limitService.Check(user, config.LimitOperationTransfer, amountToken.String()); err != nil {
+		return nil, err
+	}
+
 	// Cek saldo on-chain
 	multiplier := new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
 	amountWei := new(big.Int).Mul(amountToken, multiplier)
@@ -131,35 +174,175 @@ func (s *TLCWalletService) ValidateTransfer(userID int64, toAddress, amount stri
 		return nil, errors.New("failed to check on-chain balance: " + err.Error())
 	}
 	if senderOnChainWei.Cmp(amountWei) < 0 {
-		return nil, errors.New("insufficient on-chain balance")
-	}
-
-	// 🔑 AMBIL NAMA PENERIMA DARI DATABASE
-	recipientUser, err := s.userRepo.GetByWalletAddress(toAddress)
-	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return nil, errors.New("recipient address not found in system")
-		}
-		return nil, err
+		return nil, ErrInsufficientOnChainBalance
 	}
 
 	// Response untuk popup konfirmasi
-	return &response.ValidateTransferResponse{
+	result := &response.ValidateTransferResponse{
 		FromAddress:   user.WalletAddress,
 		FromUsername:  user.Username,
 		ToAddress:     toAddress,
-		ToUsername:    recipientUser.Username, // 👈 Nama penerima
+		ToDisplayName: recipient.DisplayName,
+		RecipientType: recipient.Type,
 		Amount:        amount,
 		AmountWei:     amountWei.String(),
 		SenderBalance: senderOnChainWei.String(),
 		IsValid:       true,
-	}, nil
+	}
+	// Username lengkap hanya kalau penerima dicari lewat alamat atau @username,
+	// lookup email/HP cukup dapat nama yang disamarkan
+	if recipient.Type == RecipientTypeAddress || recipient.Type == RecipientTypeUsername {
+		result.ToUsername = recipient.User.Username // 👈 Nama penerima
+	}
+
+	// Estimasi biaya jaringan supaya user tahu biaya sebelum konfirmasi (memo membuat gas lebih besar)
+	var fee *web3.FeeEstimate
+	if memo != "" {
+		result.Memo = memo
+		fee, err = s.contractService.EstimatePaymentTransferFee(common.HexToAddress(user.WalletAddress), common.HexToAddress(toAddress), amountWei, memo)
+	} else {
+		fee, err = s.blockchainService.CalculateTransactionFee("transfer", user.WalletAddress, toAddress, amountWei)
+	}
+	if err != nil {
+		log.Printf("[WARN] Failed to estimate transfer fee: %v", err)
+	} else {
+		result.GasLimit = fee.GasLimit
+		result.EstimatedFeeWei = fee.EstimatedFee.String()
+		result.MaxFeeWei = fee.MaxFee.String()
+	}
+
+	// Quote bertanda tangan: execute hanya menerima apa yang ditampilkan di popup ini
+	quote := &TransferQuote{
+		UserID:          userID,
+		FromAddress:     user.WalletAddress,
+		ToAddress:       toAddress,
+		Amount:          amount,
+		Memo:            memo,
+		EstimatedFeeWei: result.EstimatedFeeWei,
+		MaxFeeWei:       result.MaxFeeWei,
+	}
+	result.QuoteToken, err = s.quoteSigner.Sign(quote)
+	if err != nil {
+		return nil, errors.New("failed to sign transfer quote")
+	}
+	result.QuoteExpiresAt = quote.ExpiresAt.Time
+
+	return result, nil
+}
+
+// normalizeMemo trims a transfer memo and checks its length
+func normalizeMemo(memo string) (string, error) {
+	memo = strings.TrimSpace(memo)
+	if utf8.RuneCountInString(memo) > maxMemoLength {
+		return "", fmt.Errorf("memo must be at most %d characters", maxMemoLength)
+	}
+	return memo, nil
+}
+
+// GetReceivePaymentURI builds the EIP-681 URI other wallets (or the kiosk app)
+// scan to pay the user; amount (whole TLC) and memo are optional
+func (s *TLCWalletService) GetReceivePaymentURI(userID int64, amount, memo string) (*response.PaymentURIResponse, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.New("user not found")
+		}
+		return nil, err
+	}
+
+	contractAddress := s.blockchainService.ContractAddress()
+	if contractAddress == "" {
+		return nil, errors.New("blockchain not available")
+	}
+
+	memo, err = normalizeMemo(memo)
+	if err != nil {
+		return nil, err
+	}
+
+	uri := &blockchain.PaymentURI{
+		ContractAddress: contractAddress,
+		ChainID:         s.blockchainService.Network.ChainID,
+		ToAddress:       user.WalletAddress,
+		Memo:            memo,
+	}
+	result := &response.PaymentURIResponse{
+		ContractAddress: contractAddress,
+		ChainID:         uri.ChainID,
+		ToAddress:       user.WalletAddress,
+		Memo:            memo,
+	}
+
+	if amount != "" {
+		amountToken, ok := new(big.Int).SetString(amount, 10)
+		if !ok {
+			return nil, errors.New("invalid amount format")
+		}
+		if amountToken.Sign() <= 0 {
+			return nil, errors.New("amount must be greater than 0")
+		}
+		uri.AmountWei = new(big.Int).Mul(amountToken, new(big.Int).Exp(big.NewInt(10), big.NewInt(blockchain.TokenDecimals), nil))
+		result.Amount = amountToken.String()
+		result.AmountWei = uri.AmountWei.String()
+	}
+
+	result.URI = uri.String()
+	return result, nil
+}
+
+// GetReceiveQRCode returns the receive payment URI as a PNG QR code
+func (s *TLCWalletService) GetReceiveQRCode(userID int64, amount, memo string, size int) ([]byte, error) {
+	paymentURI, err := s.GetReceivePaymentURI(userID, amount, memo)
+	if err != nil {
+		return nil, err
+	}
+
+	png, err := qrcode.Encode(paymentURI.URI, qrcode.Medium, size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate QR code: %v", err)
+	}
+	return png, nil
+}
+
+// ValidatePaymentURI fills in ValidateTransfer from a scanned EIP-681 URI. The
+// URI must be for this network's PaymentToken; amount is only used when the URI
+// has none.
+func (s *TLCWalletService) ValidatePaymentURI(userID int64, rawURI, amount string) (*response.ValidateTransferResponse, error) {
+	uri, err := blockchain.ParsePaymentURI(rawURI)
+	if err != nil {
+		return nil, err
+	}
+
+	contractAddress := s.blockchainService.ContractAddress()
+	if contractAddress == "" {
+		return nil, errors.New("blockchain not available")
+	}
+	if !strings.EqualFold(uri.ContractAddress, contractAddress) {
+		return nil, errors.New("payment URI is not for TLC")
+	}
+	if uri.ChainID != 0 && uri.ChainID != s.blockchainService.Network.ChainID {
+		return nil, errors.New("payment URI is for a different network")
+	}
+
+	if uri.AmountWei != nil {
+		// Transfer hanya mendukung TLC bulat
+		amountToken, remainder := new(big.Int).QuoRem(uri.AmountWei, new(big.Int).Exp(big.NewInt(10), big.NewInt(blockchain.TokenDecimals), nil), new(big.Int))
+		if remainder.Sign() != 0 {
+			return nil, errors.New("amount in payment URI must be a whole number of TLC")
+		}
+		amount = amountToken.String()
+	}
+	if amount == "" {
+		return nil, errors.New("amount is required when the payment URI has none")
+	}
+
+	return s.ValidateTransfer(userID, uri.ToAddress, amount, uri.Memo)
 }
 
 // ============================================================================
 // ENDPOINT 2: TransferTLC - Execute transfer setelah user confirm + input PIN
 // ============================================================================
-func (s *TLCWalletService) TransferTLC(userID int64, toAddress, amount, memo, pin string) (*response.TLCTransferResponse, error) {
+func (s *TLCWalletService) TransferTLC(userID int64, quoteToken, pin, callbackURL string) (*response.TLCTransferResponse, error) {
 	// Step 1: Validasi user
 	user, err := s.userRepo.GetByID(userID)
 	if err != nil {
@@ -177,15 +360,83 @@ func (s *TLCWalletService) TransferTLC(userID int64, toAddress, amount, memo, pi
 		return nil, errors.New("invalid PIN")
 	}
 
-	// Step 3: Validasi format alamat
-	if !common.IsHexAddress(toAddress) {
-		return nil, errors.New("invalid recipient address format")
+	// Step 3: Verifikasi quote dari ValidateTransfer; penerima, amount dan memo
+	// diambil dari quote, bukan dari request
+	quote, err := s.quoteSigner.Verify(quoteToken, userID, user.WalletAddress)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.quoteSigner.Claim(quote); err != nil {
+		return nil, err
+	}
+
+	// Step 4: Validasi callback URL
+	if callbackURL != "" {
+		if err := s.tracker.ValidateCallbackURL(callbackURL); err != nil {
+			s.quoteSigner.Release(quote)
+			return nil, err
+		}
+	}
+
+	metadata := models.TransactionMetadata{}
+	if callbackURL != "" {
+		metadata["callback_url"] = callbackURL
+	}
+
+	// Step 5: Kirim transfer; quote dipakai lagi kalau gagal sebelum terkirim ke jaringan
+	result, err := s.sendTransfer(user, quote.ToAddress, quote.Amount, quote.Memo, metadata)
+	if err != nil {
+		s.quoteSigner.Release(quote)
+		return nil, err
+	}
+	return result, nil
+}
+
+// SendScheduledTransfer runs a transfer the user authorized (with PIN) when the
+// schedule was set up; the scheduled transfer worker calls it at each run
+func (s *TLCWalletService) SendScheduledTransfer(userID, scheduleID int64, toAddress, amount, memo string) (*response.TLCTransferResponse, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.New("user not found")
+		}
+		return nil, err
+	}
+	if user.Status != "active" {
+		return nil, errors.New("account is not active")
 	}
-	if toAddress == user.WalletAddress {
+	if strings.EqualFold(toAddress, user.WalletAddress) {
 		return nil, errors.New("cannot transfer to yourself")
 	}
 
-	// Step 4: Validasi amount
+	return s.sendTransfer(user, toAddress, amount, memo, models.TransactionMetadata{
+		"scheduled_transfer_id": scheduleID,
+	})
+}
+
+// FindSentTransfer returns the hash of a transfer the user saved since the
+// given time with this recipient and amount, or "" when there is none. Used to
+// resolve a scheduled run or payment interrupted around its broadcast.
+func (s *TLCWalletService) FindSentTransfer(userID int64, toAddress, amount string, since time.Time) (string, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return "", err
+	}
+
+	tx, err := s.txRepo.FindTransferSince(user.WalletAddress, toAddress, amount, since)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", nil
+		}
+		return "", err
+	}
+	return tx.TxHash, nil
+}
+
+// sendTransfer checks the balance, broadcasts the transfer and saves it as pending.
+// An error means nothing was broadcast.
+func (s *TLCWalletService) sendTransfer(user *models.User, toAddress, amount, memo string, metadata models.TransactionMetadata) (*response.TLCTransferResponse, error) {
+	// Step 1: Validasi amount
 	amountToken := new(big.Int)
 	amountToken, ok := amountToken.SetString(amount, 10)
 	if !ok {
@@ -198,74 +449,78 @@ func (s *TLCWalletService) TransferTLC(userID int64, toAddress, amount, memo, pi
 	multiplier := new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
 	amountWei := new(big.Int).Mul(amountToken, multiplier)
 
-	// Step 5: Cek blockchain availability
+	// Step 2: Cek limit transfer; ditahan sampai transaksi tersimpan
+	releaseLimit, err := s.limitService.Reserve(user, config.LimitOperationTransfer, amountToken.String())
+	if err != nil {
+		return nil, err
+	}
+	keepReservation := false
+	defer func() {
+		if !keepReservation {
+			releaseLimit()
+		}
+	}()
+
+	// Step 3: Cek blockchain availability
 	if s.contractService == nil || !s.blockchainService.IsWeb3Enabled() {
 		return nil, errors.New("blockchain not available")
 	}
 
-	// Step 6: Double-check saldo on-chain
+	// Step 4: Double-check saldo on-chain
 	senderOnChainWei, err := s.contractService.GetBalance(common.HexToAddress(user.WalletAddress))
 	if err != nil {
 		return nil, errors.New("failed to check on-chain balance: " + err.Error())
 	}
 	if senderOnChainWei.Cmp(amountWei) < 0 {
-		return nil, errors.New("insufficient on-chain balance")
+		return nil, ErrInsufficientOnChainBalance
 	}
 
-	// Step 7: Decrypt private key
+	// Step 5: Decrypt private key
 	privateKey, err := crypto.DecryptPrivateKey(user.PrivateKeyEncrypted)
 	if err != nil {
 		return nil, errors.New("failed to decrypt private key")
 	}
 
-	// Step 8: Debug logging
-	log.Println("====================== [ DEBUG INFO TLCWalletService ] ======================")
-	log.Printf("[DEBUG] User ID: %d", userID)
-	log.Printf("[DEBUG] From Address: %s", user.WalletAddress)
-	log.Printf("[DEBUG] To Address: %s", toAddress)
-	log.Printf("[DEBUG] Amount to send (Wei): %s", amountWei.String())
-	log.Printf("[DEBUG] Memo: %s", memo)
-	log.Println("============================================================================")
-
-	// Step 9: Execute transfer on-chain
-	txHash, err := s.contractService.Transfer(privateKey, common.HexToAddress(toAddress), amountWei)
-	if err != nil {
-		log.Printf("[FATAL] Error from contractService.Transfer: %v", err)
-		return nil, errors.New("blockchain transfer failed: " + err.Error())
+	// Step 6: Execute transfer on-chain; a memo goes through paymentTransfer so it
+	// is stored on chain in the PaymentProcessed event
+	var txHash string
+	if memo != "" {
+		txHash, err = s.contractService.PaymentTransfer(privateKey, common.HexToAddress(toAddress), amountWei, memo)
+	} else {
+		txHash, err = s.contractService.Transfer(privateKey, common.HexToAddress(toAddress), amountWei)
 	}
-
-	// Step 10: Wait for receipt
-	log.Printf("⏳ Waiting for tx %s to be mined...", txHash)
-	receipt, err := s.contractService.WaitForReceipt(txHash, 60)
 	if err != nil {
-		log.Printf("⚠️ Transaction %s sent but not confirmed or failed: %v", txHash, err)
-		return nil, errors.New("transaction not confirmed or failed: " + err.Error())
+		log.Printf("[FATAL] Error from contractService transfer: %v", err)
+		return nil, errors.New("blockchain transfer failed: " + err.Error())
 	}
 
-	log.Printf("✅ Transaction confirmed in block %d", receipt.BlockNumber.Uint64())
-
-	// Step 11: Save transaction record
-	blockNum := int64(receipt.BlockNumber.Uint64())
+	// Step 7: Save as pending, the confirmation tracker fills in block and gas
 	txRecord := &models.Transaction{
 		TxHash:      txHash,
 		FromAddress: user.WalletAddress,
 		ToAddress:   toAddress,
 		Amount:      amount,
 		TxType:      "transfer",
-		Status:      "confirmed",
-		BlockNumber: &blockNum,
+		Status:      "pending",
+		Metadata:    metadata,
 		CreatedAt:   time.Now(),
-		ConfirmedAt: func() *time.Time {
-			t := time.Now()
-			return &t
-		}(),
+	}
+	if memo != "" {
+		txRecord.Metadata["memo"] = memo
 	}
 
-	if err := s.txRepo.Create(txRecord); err != nil {
-		log.Printf("[ERROR] Failed to save transaction record: %v", err)
+	if err := s.saveBroadcastTransfer(txRecord); err != nil {
+		// Transfer sudah terkirim ke jaringan, jangan kembalikan error. Tanpa row
+		// tracker tidak bisa mencatat receipt; reservasi limit tetap ditahan sampai
+		// kedaluwarsa supaya transfer ini tetap terhitung
+		keepReservation = true
+		log.Printf("[ERROR] Transfer %s of user %d was broadcast but its transaction record could not be saved: %v", txHash, user.ID, err)
+	} else {
+		s.tracker.Track(txHash)
+		log.Printf("📤 Transfer %s broadcast, waiting for confirmation", txHash)
 	}
 
-	// Step 12: Return response
+	// Step 8: Return response
 	return &response.TLCTransferResponse{
 		TxHash:      txHash,
 		FromAddress: user.WalletAddress,
@@ -273,11 +528,76 @@ func (s *TLCWalletService) TransferTLC(userID int64, toAddress, amount, memo, pi
 		Amount:      amount,
 		AmountWei:   amountWei.String(),
 		Memo:        memo,
-		Status:      "confirmed",
+		Status:      "pending",
+		StatusURL:   "/api/transfer/status/" + txHash,
 		Timestamp:   time.Now(),
 	}, nil
 }
 
+// saveBroadcastTransfer stores the row of a transfer that is already on the network,
+// retrying a few times because the transfer cannot be taken back
+func (s *TLCWalletService) saveBroadcastTransfer(txRecord *models.Transaction) error {
+	var err error
+	for attempt := 0; attempt < transferSaveAttempts; attempt++ {
+		if attempt > 0 {
+			time.Sleep(transferSaveBackoff)
+		}
+		if err = s.txRepo.Create(txRecord); err == nil {
+			return nil
+		}
+		log.Printf("[WARN] Failed to save transaction record %s (attempt %d/%d): %v", txRecord.TxHash, attempt+1, transferSaveAttempts, err)
+	}
+	return err
+}
+
+// GetTransferStatus returns the status of a transaction the user sent or received.
+// With wait > 0 it blocks until the transaction is final or wait has passed (long polling).
+func (s *TLCWalletService) GetTransferStatus(ctx context.Context, userID int64, txHash string, wait time.Duration) (*response.TransactionStatusResponse, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.New("user not found")
+		}
+		return nil, err
+	}
+
+	tx, err := s.txRepo.GetByHash(txHash)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.New("transaction not found")
+		}
+		return nil, err
+	}
+
+	// Hanya pengirim atau penerima yang boleh melihat status
+	if !strings.EqualFold(tx.FromAddress, user.WalletAddress) && !strings.EqualFold(tx.ToAddress, user.WalletAddress) {
+		return nil, errors.New("transaction not found")
+	}
+
+	if wait <= 0 || (tx.Status != "pending" && tx.Status != "processing") {
+		return NewTransactionStatusResponse(tx), nil
+	}
+
+	final, unsubscribe := s.tracker.Subscribe(txHash)
+	defer unsubscribe()
+
+	// Re-read in case the tracker finalized it before the subscription
+	if latest, err := s.txRepo.GetByHash(txHash); err == nil && latest.Status != tx.Status {
+		return NewTransactionStatusResponse(latest), nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case finalTx := <-final:
+		return NewTransactionStatusResponse(finalTx), nil
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+	return NewTransactionStatusResponse(tx), nil
+}
+
 func (s *TLCWalletService) GetTransactionHistory(userID int64, page, limit int) (*response.TLCTransactionHistoryResponse, error) {
 	// 1. Dapatkan user
 	user, err := s.userRepo.GetByID(userID)
@@ -0,0 +1,404 @@
+package service
+
+import (
+	"encoding/hex"
+	"errors"
+	"log"
+	"strings"
+	"sync"
+	"telkom_coin_back_end/config"
+	"telkom_coin_back_end/internal/blockchain"
+	"telkom_coin_back_end/internal/models"
+	"telkom_coin_back_end/internal/repository"
+	"telkom_coin_back_end/internal/web3"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"gorm.io/gorm"
+)
+
+const (
+	// PaymentToken.processTopup reverts until an hour after requestTopup
+	topupProcessDelay = time.Hour
+
+	topupKeeperBatchSize = 50
+)
+
+// TopupKeeper finishes delayed topups. Once the contract's waiting period of a
+// request is over it signs processTopup with the admin key, stores the signed
+// transaction and then sends it, so a crash in between is recovered by sending
+// the same transaction again. A transaction the node won't take is signed again
+// with a fresh nonce, up to OUTBOX_MAX_ATTEMPTS times. The mint is credited to
+// balances from the receipt, also when another transaction processed the request.
+type TopupKeeper struct {
+	pendingTopupRepo  *repository.PendingTopupRepository
+	orderRepo         *repository.TopupOrderRepository
+	balanceRepo       *repository.BalanceRepository
+	txRepo            *repository.TransactionRepository
+	blockchainService *blockchain.BlockchainService
+	contractService   *web3.ContractService
+	interval          time.Duration
+	maxAttempts       int
+
+	runMu    sync.Mutex
+	stopOnce sync.Once
+	stop     chan struct{}
+}
+
+func NewTopupKeeper(
+	pendingTopupRepo *repository.PendingTopupRepository,
+	orderRepo *repository.TopupOrderRepository,
+	balanceRepo *repository.BalanceRepository,
+	txRepo *repository.TransactionRepository,
+	blockchainService *blockchain.BlockchainService,
+) *TopupKeeper {
+	var contractService *web3.ContractService
+	if blockchainService.IsWeb3Enabled() {
+		web3Client, err := web3.NewWeb3Client()
+		if err == nil {
+			contractService, _ = web3.NewContractService(web3Client)
+		}
+	}
+
+	return &TopupKeeper{
+		pendingTopupRepo:  pendingTopupRepo,
+		orderRepo:         orderRepo,
+		balanceRepo:       balanceRepo,
+		txRepo:            txRepo,
+		blockchainService: blockchainService,
+		contractService:   contractService,
+		interval:          config.AppConfig.TopupKeeperInterval,
+		maxAttempts:       config.AppConfig.OutboxMaxAttempts,
+		stop:              make(chan struct{}),
+	}
+}
+
+// Start processes due topup requests every TOPUP_KEEPER_INTERVAL until Stop is called
+func (k *TopupKeeper) Start() {
+	if k.contractService == nil {
+		log.Println("⚠️  Topup keeper not started, blockchain not available")
+		return
+	}
+
+	go func() {
+		log.Printf("⏳ Topup keeper started (every %s)", k.interval)
+
+		ticker := time.NewTicker(k.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-k.stop:
+				log.Println("Topup keeper stopped")
+				return
+			case <-ticker.C:
+				k.RunOnce()
+			}
+		}
+	}()
+}
+
+// Stop signals the keeper loop to exit
+func (k *TopupKeeper) Stop() {
+	k.stopOnce.Do(func() { close(k.stop) })
+}
+
+// RunOnce settles sent processTopup transactions, then processes every request
+// whose waiting period is over
+func (k *TopupKeeper) RunOnce() {
+	if k.contractService == nil || !k.runMu.TryLock() {
+		return
+	}
+	defer k.runMu.Unlock()
+
+	processing, err := k.pendingTopupRepo.GetByStatus(models.PendingTopupStatusProcessing, topupKeeperBatchSize)
+	if err != nil {
+		log.Printf("[ERROR] Topup keeper failed to load processing topups: %v", err)
+	}
+	for i := range processing {
+		k.settle(&processing[i])
+	}
+
+	due, err := k.pendingTopupRepo.GetDue(time.Now(), topupKeeperBatchSize)
+	if err != nil {
+		log.Printf("[ERROR] Topup keeper failed to load due topups: %v", err)
+		return
+	}
+	for i := range due {
+		if err := k.process(&due[i]); err != nil {
+			log.Printf("[WARN] Topup keeper could not process %s (%s TLC): %v", due[i].RequestID, due[i].Amount, err)
+		}
+	}
+}
+
+// process signs processTopup for a due request, records it and sends it
+func (k *TopupKeeper) process(pending *models.PendingTopup) error {
+	requestID := common.HexToHash(pending.RequestID)
+
+	signedTx, err := k.contractService.SignProcessTopup(requestID)
+	if err != nil {
+		// Sudah diproses di luar keeper (processTopup bisa dipanggil siapa saja)
+		if request, reqErr := k.contractService.GetTopupRequest(requestID); reqErr == nil && request.Processed {
+			k.finishExternally(pending)
+			return nil
+		}
+		k.pendingTopupRepo.UpdateFields(pending.ID, map[string]interface{}{"last_error": err.Error()})
+		return err
+	}
+
+	rawTx, err := signedTx.MarshalBinary()
+	if err != nil {
+		k.contractService.DiscardSigned(signedTx, err)
+		return err
+	}
+
+	txHash := signedTx.Hash().Hex()
+	moved, err := k.pendingTopupRepo.Transition(pending.ID, models.PendingTopupStatusRequested, models.PendingTopupStatusProcessing, map[string]interface{}{
+		"process_tx_hash": txHash,
+		"raw_tx":          hex.EncodeToString(rawTx),
+		"last_error":      "",
+	})
+	if err != nil || !moved {
+		// Tidak boleh broadcast transaksi yang hash-nya tidak tercatat
+		k.contractService.DiscardSigned(signedTx, errors.New("pending topup not saved"))
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+	pending.ProcessTxHash = txHash
+
+	if err := k.contractService.SendSigned(signedTx); err != nil && !isAlreadyKnown(err) {
+		log.Printf("[WARN] Broadcast of processTopup %s failed: %v", txHash, err)
+		k.requeue(pending, signedTx, "broadcast of processTopup "+txHash+" failed: "+err.Error())
+		return nil
+	}
+
+	k.recordBroadcast(pending)
+	log.Printf("⏳ Topup request %s (%s TLC) processed in %s", pending.RequestID, pending.Amount, txHash)
+	return nil
+}
+
+// recordBroadcast writes the transactions row of a sent processTopup and its
+// hash on the topup order
+func (k *TopupKeeper) recordBroadcast(pending *models.PendingTopup) {
+	exists, err := k.txRepo.HashExists(pending.ProcessTxHash)
+	if err == nil && !exists {
+		err = k.txRepo.Create(newPendingTopupTransaction(pending, time.Now()))
+	}
+	if err != nil {
+		log.Printf("[ERROR] Failed to record processTopup %s: %v", pending.ProcessTxHash, err)
+	}
+
+	if order, err := k.orderRepo.GetByReference(pending.Reference); err == nil {
+		k.orderRepo.UpdateFields(order.ID, map[string]interface{}{"tx_hash": pending.ProcessTxHash})
+	}
+}
+
+// settle applies the receipt of a sent processTopup
+func (k *TopupKeeper) settle(pending *models.PendingTopup) {
+	receipt, err := k.blockchainService.GetTransactionReceipt(pending.ProcessTxHash)
+	if err != nil {
+		log.Printf("[WARN] Topup keeper failed to get receipt of %s: %v", pending.ProcessTxHash, err)
+		return
+	}
+
+	switch receipt.Status {
+	case blockchain.TxStatusConfirmed:
+		k.confirm(pending, receipt)
+	case blockchain.TxStatusFailed:
+		k.retry(pending, receipt)
+	case blockchain.TxStatusNotFound:
+		// Diproses transaksi lain (atau oleh kita sebelum node membuangnya)
+		if request, err := k.contractService.GetTopupRequest(common.HexToHash(pending.RequestID)); err == nil && request.Processed {
+			k.finishExternally(pending)
+			return
+		}
+		signedTx, err := decodeRawTx(pending.RawTx)
+		if err != nil {
+			log.Printf("[ERROR] Pending topup %d: %v", pending.ID, err)
+			k.requeue(pending, nil, err.Error())
+			return
+		}
+		if err := k.contractService.SendSigned(signedTx); err != nil && !isAlreadyKnown(err) {
+			log.Printf("[WARN] Rebroadcast of processTopup %s failed: %v", pending.ProcessTxHash, err)
+			k.requeue(pending, signedTx, "rebroadcast of processTopup "+pending.ProcessTxHash+" failed: "+err.Error())
+			return
+		}
+		k.recordBroadcast(pending)
+	}
+}
+
+// confirm credits a mined processTopup to balances, exactly once
+func (k *TopupKeeper) confirm(pending *models.PendingTopup, receipt *blockchain.TransactionReceipt) {
+	if err := k.credit(pending, models.PendingTopupStatusProcessing, receipt); err != nil {
+		// Rollback, dicoba lagi di putaran berikutnya
+		log.Printf("[ERROR] Failed to settle processTopup %s: %v", pending.ProcessTxHash, err)
+		return
+	}
+	log.Printf("⏳ Delayed topup %s (%s TLC) minted in block %d", pending.Reference, pending.Amount, receipt.BlockNumber)
+}
+
+// credit closes the pending topup and adds its amount to balances from the
+// receipt of the transaction that minted it, in one DB transaction. Only the
+// move out of from credits, so a topup is credited once.
+func (k *TopupKeeper) credit(pending *models.PendingTopup, from string, receipt *blockchain.TransactionReceipt) error {
+	var gasPrice int64
+	if receipt.EffectiveGasPrice != nil {
+		gasPrice = receipt.EffectiveGasPrice.Int64()
+	}
+
+	now := time.Now()
+	return k.pendingTopupRepo.Transaction(func(tx *gorm.DB) error {
+		moved, err := k.pendingTopupRepo.WithTx(tx).Transition(pending.ID, from, models.PendingTopupStatusProcessed, map[string]interface{}{
+			"process_tx_hash": receipt.TxHash,
+			"processed_at":    now,
+		})
+		if err != nil || !moved {
+			return err
+		}
+
+		pending.ProcessTxHash = receipt.TxHash
+		txRepo := k.txRepo.WithTx(tx)
+		exists, err := txRepo.HashExists(receipt.TxHash)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			if err := txRepo.Create(newPendingTopupTransaction(pending, now)); err != nil {
+				return err
+			}
+		}
+		// The reconciler or tracker may have finalized the row already
+		if _, err := txRepo.UpdateFinalStatus(receipt.TxHash, receipt.Status, int64(receipt.BlockNumber), int64(receipt.GasUsed), gasPrice); err != nil {
+			return err
+		}
+
+		return k.balanceRepo.WithTx(tx).AddBalance(pending.UserID, pending.Amount)
+	})
+}
+
+// retry puts a request whose processTopup reverted back in the queue, up to
+// OUTBOX_MAX_ATTEMPTS times
+func (k *TopupKeeper) retry(pending *models.PendingTopup, receipt *blockchain.TransactionReceipt) {
+	var gasPrice int64
+	if receipt.EffectiveGasPrice != nil {
+		gasPrice = receipt.EffectiveGasPrice.Int64()
+	}
+	if _, err := k.txRepo.UpdateFinalStatus(pending.ProcessTxHash, receipt.Status, int64(receipt.BlockNumber), int64(receipt.GasUsed), gasPrice); err != nil {
+		log.Printf("[ERROR] Failed to record reverted processTopup %s: %v", pending.ProcessTxHash, err)
+	}
+
+	if request, err := k.contractService.GetTopupRequest(common.HexToHash(pending.RequestID)); err == nil && request.Processed {
+		k.finishExternally(pending)
+		return
+	}
+
+	k.requeue(pending, nil, "processTopup "+pending.ProcessTxHash+" reverted")
+}
+
+// requeue drops the processTopup of a request (reverted, or not taken by the
+// node) and puts the request back, so the next run signs it again with a fresh
+// nonce. After OUTBOX_MAX_ATTEMPTS attempts the topup fails for good and its
+// order keeps the error for support. signedTx, when given, was never accepted
+// and gives its nonce back.
+func (k *TopupKeeper) requeue(pending *models.PendingTopup, signedTx *types.Transaction, cause string) {
+	if signedTx != nil {
+		k.contractService.DiscardSigned(signedTx, errors.New(cause))
+	}
+
+	attempts := pending.Attempts + 1
+	to := models.PendingTopupStatusRequested
+	if attempts >= k.maxAttempts {
+		to = models.PendingTopupStatusFailed
+		log.Printf("[ERROR] Delayed topup %s (%s TLC) failed after %d processTopup attempts: %s", pending.Reference, pending.Amount, attempts, cause)
+	}
+	moved, err := k.pendingTopupRepo.Transition(pending.ID, models.PendingTopupStatusProcessing, to, map[string]interface{}{
+		"attempts":        attempts,
+		"last_error":      cause,
+		"process_tx_hash": "",
+		"raw_tx":          "",
+	})
+	if err != nil {
+		log.Printf("[ERROR] Failed to requeue pending topup %d: %v", pending.ID, err)
+		return
+	}
+	if moved && to == models.PendingTopupStatusFailed {
+		if order, err := k.orderRepo.GetByReference(pending.Reference); err == nil {
+			k.orderRepo.UpdateFields(order.ID, map[string]interface{}{"last_error": "delayed topup failed: " + cause})
+		}
+	}
+}
+
+// finishExternally settles a request that was processed on chain by another
+// transaction (processTopup can be called by anyone). The minting transaction
+// is found from its TokensMinted event and credited like our own, so the
+// credit does not wait for the balance reconciler.
+func (k *TopupKeeper) finishExternally(pending *models.PendingTopup) {
+	log.Printf("[WARN] Topup request %s was processed outside the keeper", pending.RequestID)
+
+	receipt, err := k.findMint(pending)
+	if err == nil {
+		err = k.credit(pending, pending.Status, receipt)
+	}
+	if err != nil {
+		// Tetap di status sekarang, dicoba lagi di putaran berikutnya
+		log.Printf("[ERROR] Failed to credit topup request %s processed outside the keeper: %v", pending.RequestID, err)
+		k.pendingTopupRepo.UpdateFields(pending.ID, map[string]interface{}{"last_error": err.Error()})
+		return
+	}
+	log.Printf("⏳ Delayed topup %s (%s TLC) minted by %s in block %d", pending.Reference, pending.Amount, receipt.TxHash, receipt.BlockNumber)
+}
+
+// findMint returns the receipt of the transaction that minted a request,
+// searching from the block of its requestTopup
+func (k *TopupKeeper) findMint(pending *models.PendingTopup) (*blockchain.TransactionReceipt, error) {
+	var fromBlock uint64
+	if pending.RequestTxHash != "" {
+		if request, err := k.blockchainService.GetTransactionReceipt(pending.RequestTxHash); err == nil {
+			fromBlock = request.BlockNumber
+		}
+	}
+
+	txHash, err := k.contractService.FindTopupMint(common.HexToHash(pending.RequestID), fromBlock)
+	if err != nil {
+		return nil, err
+	}
+	if txHash == "" {
+		return nil, errors.New("no TokensMinted event for request " + pending.RequestID)
+	}
+
+	receipt, err := k.blockchainService.GetTransactionReceipt(txHash)
+	if err != nil {
+		return nil, err
+	}
+	if receipt.Status != blockchain.TxStatusConfirmed {
+		return nil, errors.New("minting transaction " + txHash + " is not confirmed")
+	}
+	return receipt, nil
+}
+
+// isAlreadyKnown reports whether a send failed only because the node already has the transaction
+func isAlreadyKnown(err error) bool {
+	return strings.Contains(strings.ToLower(err.Error()), "already known")
+}
+
+// newPendingTopupTransaction builds the transactions row of a processTopup
+func newPendingTopupTransaction(pending *models.PendingTopup, now time.Time) *models.Transaction {
+	return &models.Transaction{
+		TxHash:      pending.ProcessTxHash,
+		FromAddress: zeroAddress, // dianggap dari sistem
+		ToAddress:   pending.WalletAddress,
+		Amount:      pending.Amount,
+		TxType:      models.OutboxActionTopup,
+		Status:      "pending",
+		CreatedAt:   now,
+		Metadata: models.TransactionMetadata{
+			"payment_method":  pending.PaymentMethod,
+			"topup_reference": pending.Reference,
+			"request_id":      pending.RequestID,
+			"note":            "Delayed topup processed by keeper",
+		},
+	}
+}
@@ -1,27 +1,61 @@
 package service
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"log"
 	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"telkom_coin_back_end/config"
 	"telkom_coin_back_end/internal/blockchain"
 	"telkom_coin_back_end/internal/dto/request"
 	"telkom_coin_back_end/internal/dto/response"
 	"telkom_coin_back_end/internal/models"
+	"telkom_coin_back_end/internal/payment"
 	"telkom_coin_back_end/internal/repository"
 	"telkom_coin_back_end/internal/web3"
 	"telkom_coin_back_end/pkg/crypto"
 	"time"
 
+	"github.com/skip2/go-qrcode"
 	"gorm.io/gorm"
 )
 
+const (
+	topupSweepInterval  = time.Minute
+	topupSweepBatchSize = 100
+)
+
+var (
+	ErrTopupOrderNotFound  = errors.New("topup order not found")
+	ErrTopupAmountMismatch = errors.New("paid amount does not match the topup order")
+
+	errTopupOrderNotPaid = errors.New("topup order is not paid")
+)
+
+// TopupService turns IDR topups into orders at the payment provider. TLC is
+// minted only after the provider confirms the payment with a signed webhook.
 type TopupService struct {
 	userRepo        *repository.UserRepository
 	balanceRepo     *repository.BalanceRepository
 	txRepo          *repository.TransactionRepository
+	outboxRepo      *repository.OutboxRepository
+	orderRepo       *repository.TopupOrderRepository
+	pendingRepo     *repository.PendingTopupRepository
+	relay           *OutboxRelay
+	provider        payment.PaymentProvider
 	contractService *web3.ContractService
+	limitService    *LimitService
+	orderTTL        time.Duration
+	maxMintAttempts int
+	delayedFrom     *big.Int // nil when every topup is minted instantly
+
+	stopOnce sync.Once
+	stop     chan struct{}
 }
 
 func NewTopupService(
@@ -29,6 +63,12 @@ func NewTopupService(
 	balanceRepo *repository.BalanceRepository,
 	txRepo *repository.TransactionRepository,
 	blockchainService *blockchain.BlockchainService,
+	outboxRepo *repository.OutboxRepository,
+	orderRepo *repository.TopupOrderRepository,
+	pendingRepo *repository.PendingTopupRepository,
+	relay *OutboxRelay,
+	provider payment.PaymentProvider,
+	limitService *LimitService,
 ) *TopupService {
 	var contractService *web3.ContractService
 	if blockchainService.IsWeb3Enabled() {
@@ -38,15 +78,57 @@ func NewTopupService(
 		}
 	}
 
+	delayedFrom, ok := new(big.Int).SetString(config.AppConfig.TopupDelayedThreshold, 10)
+	if !ok || delayedFrom.Sign() <= 0 {
+		delayedFrom = nil
+	}
+
 	return &TopupService{
 		userRepo:        userRepo,
 		balanceRepo:     balanceRepo,
 		txRepo:          txRepo,
+		outboxRepo:      outboxRepo,
+		orderRepo:       orderRepo,
+		pendingRepo:     pendingRepo,
+		relay:           relay,
+		provider:        provider,
 		contractService: contractService, // <-- Pastikan ini diisi
+		limitService:    limitService,
+		orderTTL:        config.AppConfig.TopupOrderTTL,
+		maxMintAttempts: config.AppConfig.TopupMintMaxAttempts,
+		delayedFrom:     delayedFrom,
+		stop:            make(chan struct{}),
 	}
 }
 
-// RequestTopup - Pure blockchain topup (no MySQL storage, only blockchain)
+// Start expires unpaid orders and retries paid orders that were not minted,
+// every minute until Stop is called
+func (s *TopupService) Start() {
+	go func() {
+		log.Printf("💳 Topup order sweeper started (provider %s)", s.provider.Name())
+
+		ticker := time.NewTicker(topupSweepInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-s.stop:
+				log.Println("Topup order sweeper stopped")
+				return
+			case <-ticker.C:
+				s.sweep()
+			}
+		}
+	}()
+}
+
+// Stop signals the sweeper loop to exit
+func (s *TopupService) Stop() {
+	s.stopOnce.Do(func() { close(s.stop) })
+}
+
+// RequestTopup creates a topup order and the charge at the payment provider.
+// Nothing is minted until the payment webhook arrives.
 func (s *TopupService) RequestTopup(userID int64, req *request.TopupRequest) (*response.TopupResponse, error) {
 	// === 1️⃣ Ambil user
 	user, err := s.userRepo.GetByID(userID)
@@ -69,94 +151,418 @@ func (s *TopupService) RequestTopup(userID int64, req *request.TopupRequest) (*r
 	// === 3️⃣ Validasi nominal
 	amountBigInt := new(big.Int)
 	amountBigInt.SetString(req.Amount, 10)
-	if amountBigInt.Cmp(big.NewInt(10000)) < 0 {
+	if amountBigInt.Cmp(minTopupAmount) < 0 {
 		return nil, errors.New("minimum topup amount is 10,000")
 	}
 
-	// Konversi ke wei (18 desimal)
-	multiplier := new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
-	amountWei := new(big.Int).Mul(amountBigInt, multiplier)
+	// === 4️⃣ Cek limit topup; ditahan sampai order tersimpan, lalu order ikut terhitung
+	releaseLimit, err := s.limitService.Reserve(user, config.LimitOperationTopup, amountBigInt.String())
+	if err != nil {
+		return nil, err
+	}
+	defer releaseLimit()
+
+	if !s.provider.SupportsMethod(req.PaymentMethod) {
+		return nil, fmt.Errorf("payment method %s is not available", req.PaymentMethod)
+	}
 
-	// === 4️⃣ Siapkan koneksi blockchain
-	blockchainService, err := blockchain.NewBlockchainService("ganache-local")
+	// === 5️⃣ Simpan order sebelum charge dibuat di provider
+	reference, err := newTopupReference(time.Now())
 	if err != nil {
-		return nil, errors.New("blockchain service unavailable")
+		return nil, err
 	}
-	if !blockchainService.IsWeb3Enabled() {
-		return nil, errors.New("blockchain not available")
+	order := &models.TopupOrder{
+		UserID:        user.ID,
+		Reference:     reference,
+		Amount:        amountBigInt.String(),
+		PaymentMethod: req.PaymentMethod,
+		Provider:      s.provider.Name(),
+		Status:        models.TopupOrderStatusCreated,
+		ExpiresAt:     time.Now().Add(s.orderTTL),
 	}
+	if err := s.orderRepo.Create(order); err != nil {
+		log.Printf("[ERROR] Failed to save topup order: %v", err)
+		return nil, errors.New("failed to create topup")
+	}
+	releaseLimit() // Order sudah terhitung di pemakaian
 
-	// === 5️⃣ Dekripsi private key user
-	privateKey, err := crypto.DecryptPrivateKey(user.PrivateKeyEncrypted)
+	// === 6️⃣ Buat charge di payment provider
+	charge, err := s.provider.CreateCharge(payment.ChargeRequest{
+		Reference: order.Reference,
+		Method:    order.PaymentMethod,
+		Amount:    order.Amount,
+		ExpiresAt: order.ExpiresAt,
+	})
 	if err != nil {
-		return nil, errors.New("failed to decrypt private key")
+		log.Printf("[ERROR] Payment provider %s failed to create charge for %s: %v", s.provider.Name(), order.Reference, err)
+		s.orderRepo.Transition(order.ID, models.TopupOrderStatusCreated, models.TopupOrderStatusExpired, map[string]interface{}{
+			"last_error": err.Error(),
+		})
+		return nil, errors.New("payment provider unavailable, try again later")
+	}
+
+	fields := map[string]interface{}{
+		"provider_reference": charge.ProviderReference,
+		"payment_details":    models.TransactionMetadata(charge.Instructions),
+	}
+	if !charge.ExpiresAt.IsZero() {
+		fields["expires_at"] = charge.ExpiresAt
+	}
+	if _, err := s.orderRepo.Transition(order.ID, models.TopupOrderStatusCreated, models.TopupOrderStatusAwaitingPayment, fields); err != nil {
+		log.Printf("[ERROR] Failed to save charge of topup order %s: %v", order.Reference, err)
+		return nil, errors.New("failed to create topup")
 	}
 
-	// === 6️⃣ Eksekusi transaksi ke blockchain
-	web3Client, err := web3.NewWeb3Client()
+	order, err = s.orderRepo.GetByID(order.ID)
 	if err != nil {
-		return nil, errors.New("failed to connect to blockchain")
+		return nil, err
 	}
 
-	contractService, err := web3.NewContractService(web3Client)
+	// === 7️⃣ Return instruksi pembayaran
+	return newTopupOrderResponse(order), nil
+}
+
+// HandlePaymentWebhook verifies a payment provider webhook and moves the
+// order it refers to. A paid order is minted right away; repeated webhooks
+// for an order that is already paid are no-ops.
+func (s *TopupService) HandlePaymentWebhook(header http.Header, body []byte) (*response.TopupResponse, error) {
+	event, err := s.provider.ParseWebhook(header, body)
 	if err != nil {
-		return nil, errors.New("failed to initialize contract service")
+		return nil, err
 	}
 
-	paymentProof := fmt.Sprintf("AUTO_TOPUP_%s_%s", req.PaymentMethod, req.Amount)
-	txHash, err := contractService.InstantTopup(privateKey, amountWei, paymentProof)
+	order, err := s.orderRepo.GetByReference(event.Reference)
 	if err != nil {
-		return nil, fmt.Errorf("blockchain topup failed: %v", err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTopupOrderNotFound
+		}
+		return nil, err
 	}
+	if order.Provider != s.provider.Name() {
+		return nil, ErrTopupOrderNotFound
+	}
+	if event.ProviderReference != "" && event.ProviderReference != order.ProviderReference {
+		return nil, errors.New("provider reference does not match the topup order")
+	}
+
+	switch event.Status {
+	case payment.StatusExpired:
+		if _, err := s.orderRepo.Transition(order.ID, models.TopupOrderStatusAwaitingPayment, models.TopupOrderStatusExpired, nil); err != nil {
+			return nil, err
+		}
+
+	case payment.StatusPaid:
+		paid, ok := new(big.Int).SetString(event.Amount, 10)
+		expected, _ := new(big.Int).SetString(order.Amount, 10)
+		if !ok || expected == nil || paid.Cmp(expected) != 0 {
+			log.Printf("[WARN] Topup order %s paid %s IDR, expected %s", order.Reference, event.Amount, order.Amount)
+			return nil, ErrTopupAmountMismatch
+		}
+
+		paidAt := event.PaidAt
+		if paidAt.IsZero() {
+			paidAt = time.Now()
+		}
+		// Pembayaran yang telat tetap diterima: uangnya sudah masuk
+		for _, from := range []string{models.TopupOrderStatusAwaitingPayment, models.TopupOrderStatusExpired} {
+			moved, err := s.orderRepo.Transition(order.ID, from, models.TopupOrderStatusPaid, map[string]interface{}{
+				"paid_at":    paidAt,
+				"last_error": "",
+			})
+			if err != nil {
+				return nil, err
+			}
+			if moved {
+				log.Printf("💳 Topup order %s paid (%s IDR via %s)", order.Reference, order.Amount, order.PaymentMethod)
+				order.Status = models.TopupOrderStatusPaid
+				break
+			}
+		}
+
+		// The payment is recorded either way; a failed mint is retried by the sweeper
+		if order.Status == models.TopupOrderStatusPaid {
+			if err := s.mint(order); err != nil {
+				log.Printf("[ERROR] Failed to mint topup order %s: %v", order.Reference, err)
+			}
+		}
+	}
+
+	order, err = s.orderRepo.GetByID(order.ID)
+	if err != nil {
+		return nil, err
+	}
+	return newTopupOrderResponse(order), nil
+}
+
+// GetTopupOrder gets one of the user's topup orders
+func (s *TopupService) GetTopupOrder(userID, orderID int64) (*response.TopupResponse, error) {
+	order, err := s.orderRepo.GetByID(orderID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTopupOrderNotFound
+		}
+		return nil, err
+	}
+	if order.UserID != userID {
+		return nil, ErrTopupOrderNotFound
+	}
+	return newTopupOrderResponse(order), nil
+}
+
+// GetTopupQRCode returns the QRIS of an unpaid qris order as a PNG QR code
+func (s *TopupService) GetTopupQRCode(userID, orderID int64, size int) ([]byte, error) {
+	order, err := s.orderRepo.GetByID(orderID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTopupOrderNotFound
+		}
+		return nil, err
+	}
+	if order.UserID != userID {
+		return nil, ErrTopupOrderNotFound
+	}
+	if order.PaymentMethod != payment.MethodQRIS {
+		return nil, errors.New("topup order is not paid with QRIS")
+	}
+	if order.Status != models.TopupOrderStatusAwaitingPayment {
+		return nil, fmt.Errorf("topup order is %s, not awaiting payment", order.Status)
+	}
+
+	qrString, _ := order.PaymentDetails["qr_string"].(string)
+	if qrString == "" {
+		return nil, errors.New("topup order has no QR string")
+	}
+	png, err := qrcode.Encode(qrString, qrcode.Medium, size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate QR code: %v", err)
+	}
+	return png, nil
+}
+
+// DecodeQRIS decodes a scanned QRIS string and checks its checksum
+func (s *TopupService) DecodeQRIS(qrString string) (*payment.QRIS, error) {
+	return payment.ParseQRIS(qrString)
+}
 
-	// === 7️⃣ Update balance user di DB
-	err = s.balanceRepo.AddBalance(user.ID, req.Amount)
+// ListTopupOrders lists the user's topup orders, newest first
+func (s *TopupService) ListTopupOrders(userID int64, page, limit int) (*response.TopupHistoryResponse, error) {
+	orders, totalCount, err := s.orderRepo.GetByUserID(userID, page, limit)
 	if err != nil {
-		log.Printf("⚠️ failed to update balance after topup: %v", err)
+		log.Printf("[ERROR] Failed to get topup orders: %v", err)
+		return nil, errors.New("failed to retrieve topup orders")
 	}
 
-	// === 8️⃣ Simpan transaksi ke DB
-	now := time.Now()
-	tx := &models.Transaction{
-		TxHash:      txHash,
-		FromAddress: "0x0000000000000000000000000000000000000000", // dianggap dari sistem
-		ToAddress:   user.WalletAddress,
-		Amount:      req.Amount,
-		TxType:      "topup",
-		Status:      "confirmed",
-		BlockNumber: nil,
-		GasUsed:     nil,
-		GasPrice:    nil,
-		Nonce:       nil,
-		Metadata: models.TransactionMetadata{
-			"payment_method": req.PaymentMethod,
-			"note":           "Blockchain topup synced to DB",
-			"auto_confirmed": true,
+	topups := make([]response.TopupResponse, 0, len(orders))
+	for i := range orders {
+		topups = append(topups, *newTopupOrderResponse(&orders[i]))
+	}
+
+	return &response.TopupHistoryResponse{
+		Topups:     topups,
+		TotalCount: int(totalCount),
+		Page:       page,
+		Limit:      limit,
+		TotalPages: (int(totalCount) + limit - 1) / limit,
+	}, nil
+}
+
+// MintPaidOrder mints an order that was saved as paid, e.g. a virtual account credit
+func (s *TopupService) MintPaidOrder(order *models.TopupOrder) error {
+	return s.mint(order)
+}
+
+// mint saves the mint intent of a paid order to the outbox and moves the
+// order to minted in the same DB transaction, then relays it. Orders of at
+// least TOPUP_DELAYED_THRESHOLD go through requestTopup and are minted by the
+// topup keeper after the contract's waiting period.
+func (s *TopupService) mint(order *models.TopupOrder) error {
+	user, err := s.userRepo.GetByID(order.UserID)
+	if err != nil {
+		return err
+	}
+
+	action := models.OutboxActionTopup
+	if s.isDelayed(order.Amount) {
+		action = models.OutboxActionTopupRequest
+	}
+
+	intent := &models.ChainOutbox{
+		UserID:        user.ID,
+		Action:        action,
+		WalletAddress: user.WalletAddress,
+		Amount:        order.Amount,
+		Payload: models.TransactionMetadata{
+			"payment_method":  order.PaymentMethod,
+			"payment_proof":   "TOPUP:" + order.Reference,
+			"topup_reference": order.Reference,
 		},
-		CreatedAt:   now,
-		ConfirmedAt: &now,
+		Status: models.OutboxStatusPending,
+	}
+	err = s.orderRepo.Transaction(func(tx *gorm.DB) error {
+		if err := s.outboxRepo.WithTx(tx).Create(intent); err != nil {
+			return err
+		}
+		if action == models.OutboxActionTopupRequest {
+			err := s.pendingRepo.WithTx(tx).Create(&models.PendingTopup{
+				UserID:        user.ID,
+				OutboxID:      intent.ID,
+				Reference:     order.Reference,
+				PaymentMethod: order.PaymentMethod,
+				WalletAddress: user.WalletAddress,
+				Amount:        order.Amount,
+				Status:        models.PendingTopupStatusRequesting,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		moved, err := s.orderRepo.WithTx(tx).Transition(order.ID, models.TopupOrderStatusPaid, models.TopupOrderStatusMinted, map[string]interface{}{
+			"outbox_id":  intent.ID,
+			"minted_at":  time.Now(),
+			"last_error": "",
+		})
+		if err != nil {
+			return err
+		}
+		if !moved {
+			return errTopupOrderNotPaid
+		}
+		return nil
+	})
+	if errors.Is(err, errTopupOrderNotPaid) {
+		return nil // Already minted by a concurrent webhook or the sweeper
+	}
+	if err != nil {
+		return err
+	}
+
+	// Relay menandatangani & broadcast; saldo DB ditambah recorder setelah receipt ada
+	msg, err := s.relay.Dispatch(intent.ID)
+	if msg != nil {
+		s.syncMint(order, msg)
+	}
+	return err
+}
+
+// syncMint copies the tx hash of a broadcast mint to its order, and puts the
+// order back to paid when the outbox gave up on the mint, or to mint_failed
+// after maxMintAttempts of those. Delayed topups get their tx hash from the
+// topup keeper.
+func (s *TopupService) syncMint(order *models.TopupOrder, msg *models.ChainOutbox) {
+	switch msg.Status {
+	case models.OutboxStatusBroadcast, models.OutboxStatusConfirmed:
+		if msg.Action == models.OutboxActionTopupRequest {
+			return
+		}
+		if err := s.orderRepo.UpdateFields(order.ID, map[string]interface{}{"tx_hash": msg.TxHash}); err != nil {
+			log.Printf("[ERROR] Failed to save tx hash of topup order %s: %v", order.Reference, err)
+		}
+	case models.OutboxStatusFailed:
+		// Sweep retries a paid order every minute; one that can never be minted stops here
+		attempts := order.MintAttempts + 1
+		status := models.TopupOrderStatusPaid
+		if attempts >= s.maxMintAttempts {
+			status = models.TopupOrderStatusMintFailed
+		}
+		moved, err := s.orderRepo.Transition(order.ID, models.TopupOrderStatusMinted, status, map[string]interface{}{
+			"outbox_id":     nil,
+			"minted_at":     nil,
+			"mint_attempts": attempts,
+			"last_error":    msg.LastError,
+		})
+		if err != nil {
+			log.Printf("[ERROR] Failed to reset topup order %s: %v", order.Reference, err)
+		} else if moved && status == models.TopupOrderStatusMintFailed {
+			log.Printf("[WARN] Topup order %s failed to mint %d times, needs review: %s", order.Reference, attempts, msg.LastError)
+		}
+		if msg.Action == models.OutboxActionTopupRequest {
+			if pending, err := s.pendingRepo.GetByOutboxID(msg.ID); err == nil {
+				s.pendingRepo.Transition(pending.ID, models.PendingTopupStatusRequesting, models.PendingTopupStatusFailed, map[string]interface{}{
+					"last_error": msg.LastError,
+				})
+			}
+		}
+	}
+}
+
+// isDelayed reports whether an amount goes through requestTopup/processTopup
+func (s *TopupService) isDelayed(amount string) bool {
+	if s.delayedFrom == nil {
+		return false
+	}
+	value, ok := new(big.Int).SetString(amount, 10)
+	return ok && value.Cmp(s.delayedFrom) >= 0
+}
+
+// sweep expires unpaid orders, follows minted orders until their tx hash is
+// known and retries the mint of paid orders
+func (s *TopupService) sweep() {
+	if expired, err := s.orderRepo.ExpireOverdue(time.Now()); err != nil {
+		log.Printf("[ERROR] Failed to expire topup orders: %v", err)
+	} else if expired > 0 {
+		log.Printf("⌛ Expired %d unpaid topup orders", expired)
 	}
 
-	if err := s.txRepo.Create(tx); err != nil {
-		log.Printf("⚠️ failed to save transaction: %v", err)
+	minted, err := s.orderRepo.GetMintedWithoutTxHash(topupSweepBatchSize)
+	if err != nil {
+		log.Printf("[ERROR] Failed to load minted topup orders: %v", err)
+	}
+	for i := range minted {
+		if minted[i].OutboxID == nil {
+			continue
+		}
+		msg, err := s.outboxRepo.GetByID(*minted[i].OutboxID)
+		if err != nil {
+			log.Printf("[ERROR] Failed to load outbox message of topup order %s: %v", minted[i].Reference, err)
+			continue
+		}
+		s.syncMint(&minted[i], msg)
+	}
+
+	paid, err := s.orderRepo.GetByStatus(models.TopupOrderStatusPaid, topupSweepBatchSize)
+	if err != nil {
+		log.Printf("[ERROR] Failed to load paid topup orders: %v", err)
+		return
+	}
+	for i := range paid {
+		if err := s.mint(&paid[i]); err != nil {
+			log.Printf("[ERROR] Failed to mint topup order %s: %v", paid[i].Reference, err)
+		}
+	}
+}
+
+// newTopupReference returns an order reference such as TOP-20260116-9F2C41AB
+func newTopupReference(now time.Time) (string, error) {
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("TOP-%s-%s", now.Format("20060102"), strings.ToUpper(hex.EncodeToString(suffix))), nil
+}
+
+func newTopupOrderResponse(order *models.TopupOrder) *response.TopupResponse {
+	expiresAt := order.ExpiresAt
+	details := map[string]interface{}(order.PaymentDetails)
+	if details == nil {
+		details = map[string]interface{}{}
+	}
+	if order.LastError != "" {
+		details["last_error"] = order.LastError
 	}
 
-	// === 9️⃣ Return response
 	return &response.TopupResponse{
-		ID:            tx.ID,
-		Amount:        req.Amount,
-		Currency:      "TLC",
-		PaymentMethod: req.PaymentMethod,
-		Status:        "confirmed",
-		TxHash:        txHash,
-		PaymentDetails: map[string]interface{}{
-			"method":          req.PaymentMethod,
-			"blockchain_only": false,
-			"synced_to_db":    true,
-			"note":            "Topup confirmed & recorded",
-		},
-		CreatedAt: now,
-	}, nil
+		ID:             order.ID,
+		Reference:      order.Reference,
+		Amount:         order.Amount,
+		Currency:       "IDR",
+		PaymentMethod:  order.PaymentMethod,
+		PaymentDetails: details,
+		Status:         order.Status,
+		TxHash:         order.TxHash,
+		ExpiredAt:      &expiresAt,
+		PaidAt:         order.PaidAt,
+		CreatedAt:      order.CreatedAt,
+	}
 }
 
 func (s *TopupService) GetTopupHistory(userID int64, page, limit int) (*response.TopupHistoryResponse, error) {
@@ -192,11 +598,23 @@ func (s *TopupService) GetTopupHistory(userID int64, page, limit int) (*response
 		topupResponses = append(topupResponses, topup)
 	}
 
-	// 4. Hitung total halaman untuk pagination
+	// 4. Topup tertunda (requestTopup/processTopup) yang belum di-mint, dengan ETA
+	pendingTopups, err := s.pendingRepo.GetWaitingByUserID(userID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to get pending topups from DB: %v", err)
+		return nil, errors.New("failed to retrieve topup history")
+	}
+	var pendingResponses []response.TopupResponse
+	for i := range pendingTopups {
+		pendingResponses = append(pendingResponses, newPendingTopupResponse(&pendingTopups[i]))
+	}
+
+	// 5. Hitung total halaman untuk pagination
 	totalPages := (int(totalCount) + limit - 1) / limit
 
-	// 5. Kembalikan response yang sudah jadi
+	// 6. Kembalikan response yang sudah jadi
 	return &response.TopupHistoryResponse{
+		Pending:    pendingResponses,
 		Topups:     topupResponses,
 		TotalCount: int(totalCount),
 		Page:       page,
@@ -204,3 +622,26 @@ func (s *TopupService) GetTopupHistory(userID int64, page, limit int) (*response
 		TotalPages: totalPages,
 	}, nil
 }
+
+// newPendingTopupResponse shows a delayed topup with the time it should be minted
+func newPendingTopupResponse(pending *models.PendingTopup) response.TopupResponse {
+	eta := pending.CreatedAt.Add(topupProcessDelay)
+	if pending.ProcessableAt != nil {
+		eta = *pending.ProcessableAt
+	}
+
+	return response.TopupResponse{
+		ID:            pending.ID,
+		Reference:     pending.Reference,
+		Amount:        pending.Amount,
+		Currency:      "TLC",
+		PaymentMethod: pending.PaymentMethod,
+		PaymentDetails: map[string]interface{}{
+			"request_id":      pending.RequestID,
+			"request_tx_hash": pending.RequestTxHash,
+		},
+		Status:    "pending",
+		ETA:       &eta,
+		CreatedAt: pending.CreatedAt,
+	}
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"telkom_coin_back_end/internal/models"
+	"telkom_coin_back_end/internal/repository"
+	"testing"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestTopupSyncMintGivesUpAfterMaxAttempts(t *testing.T) {
+	tests := []struct {
+		name         string
+		attempts     int
+		wantStatus   string
+		wantAttempts int
+	}{
+		{"first failure is retried", 0, models.TopupOrderStatusPaid, 1},
+		{"second failure is retried", 1, models.TopupOrderStatusPaid, 2},
+		{"last failure needs review", 2, models.TopupOrderStatusMintFailed, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Discard})
+			if err != nil {
+				t.Fatalf("open test database: %v", err)
+			}
+			sqlDB, _ := db.DB()
+			sqlDB.SetMaxOpenConns(1)
+			defer sqlDB.Close()
+			if err := db.AutoMigrate(&models.TopupOrder{}); err != nil {
+				t.Fatalf("migrate test database: %v", err)
+			}
+
+			orderRepo := repository.NewTopupOrderRepository(db)
+			outboxID := int64(7)
+			mintedAt := time.Now()
+			order := &models.TopupOrder{
+				UserID:        1,
+				Reference:     "TOP-20260116-9F2C41AB",
+				Amount:        "50000",
+				PaymentMethod: "qris",
+				Provider:      "mock",
+				Status:        models.TopupOrderStatusMinted,
+				OutboxID:      &outboxID,
+				MintAttempts:  tt.attempts,
+				ExpiresAt:     time.Now().Add(time.Hour),
+				MintedAt:      &mintedAt,
+			}
+			if err := orderRepo.Create(order); err != nil {
+				t.Fatalf("create: %v", err)
+			}
+
+			s := &TopupService{orderRepo: orderRepo, maxMintAttempts: 3}
+			s.syncMint(order, &models.ChainOutbox{ID: outboxID, Action: models.OutboxActionTopup, Status: models.OutboxStatusFailed, LastError: "execution reverted"})
+
+			got, err := orderRepo.GetByID(order.ID)
+			if err != nil {
+				t.Fatalf("GetByID error = %v", err)
+			}
+			if got.Status != tt.wantStatus || got.MintAttempts != tt.wantAttempts {
+				t.Errorf("order = %s after %d attempts, want %s after %d", got.Status, got.MintAttempts, tt.wantStatus, tt.wantAttempts)
+			}
+			if got.OutboxID != nil || got.LastError != "execution reverted" {
+				t.Errorf("order keeps outbox %v and error %q", got.OutboxID, got.LastError)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"log"
+	"sync"
+	"telkom_coin_back_end/config"
+	"telkom_coin_back_end/internal/blockchain"
+	"telkom_coin_back_end/internal/repository"
+	"telkom_coin_back_end/internal/web3"
+	"time"
+)
+
+// TransactionReconciler regularly re-checks every pending or processing row of
+// transactions against the chain, so rows the tracker never saw (topups,
+// withdrawals, transfers sent before a restart) end up with their receipt and
+// final status. Rows whose hash the chain still does not know after
+// RECONCILER_NOT_FOUND_TIMEOUT are flagged as not_found for support.
+type TransactionReconciler struct {
+	txRepo            *repository.TransactionRepository
+	blockchainService *blockchain.BlockchainService
+	tracker           *TransactionTracker
+	interval          time.Duration
+	batchSize         int
+	notFoundTimeout   time.Duration
+
+	stopOnce sync.Once
+	stop     chan struct{}
+}
+
+// ReconcileResult counts what one reconciliation run did
+type ReconcileResult struct {
+	Checked   int
+	Confirmed int
+	Failed    int
+	NotFound  int
+	Errors    int
+}
+
+func NewTransactionReconciler(txRepo *repository.TransactionRepository, blockchainService *blockchain.BlockchainService, tracker *TransactionTracker) *TransactionReconciler {
+	return &TransactionReconciler{
+		txRepo:            txRepo,
+		blockchainService: blockchainService,
+		tracker:           tracker,
+		interval:          config.AppConfig.ReconcilerInterval,
+		batchSize:         config.AppConfig.ReconcilerBatchSize,
+		notFoundTimeout:   config.AppConfig.ReconcilerNotFoundTimeout,
+		stop:              make(chan struct{}),
+	}
+}
+
+// Start runs the reconciler loop in the background until Stop is called
+func (r *TransactionReconciler) Start() {
+	go func() {
+		log.Printf("🧾 Transaction reconciler started (every %s)", r.interval)
+
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+
+		for {
+			result, err := r.ReconcileOnce()
+			if err != nil {
+				log.Printf("[ERROR] Transaction reconciler: %v", err)
+			} else if result.Confirmed+result.Failed+result.NotFound > 0 {
+				log.Printf("🧾 Reconciled %d transactions: %d confirmed, %d failed, %d not found, %d errors",
+					result.Checked, result.Confirmed, result.Failed, result.NotFound, result.Errors)
+			}
+
+			select {
+			case <-r.stop:
+				log.Println("Transaction reconciler stopped")
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop signals the reconciler loop to exit
+func (r *TransactionReconciler) Stop() {
+	r.stopOnce.Do(func() { close(r.stop) })
+}
+
+// ReconcileOnce checks one batch of non-final rows, oldest first
+func (r *TransactionReconciler) ReconcileOnce() (*ReconcileResult, error) {
+	result := &ReconcileResult{}
+	if !r.blockchainService.IsWeb3Enabled() {
+		return result, nil
+	}
+
+	transactions, err := r.txRepo.GetPending(r.batchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tx := range transactions {
+		result.Checked++
+
+		receipt, err := r.blockchainService.GetTransactionReceipt(tx.TxHash)
+		if err != nil {
+			// Node tidak bisa dihubungi atau hash tidak valid, coba lagi di putaran berikutnya
+			log.Printf("[WARN] Reconciler failed to get receipt of %s: %v", tx.TxHash, err)
+			result.Errors++
+			continue
+		}
+
+		switch receipt.Status {
+		case blockchain.TxStatusConfirmed:
+			r.tracker.RecordReceipt(receipt)
+			result.Confirmed++
+		case blockchain.TxStatusFailed:
+			r.tracker.RecordReceipt(receipt)
+			result.Failed++
+		case blockchain.TxStatusNotFound:
+			if time.Since(tx.CreatedAt) < r.notFoundTimeout {
+				continue
+			}
+			flagged, err := r.txRepo.MarkNotFound(tx.TxHash)
+			if err != nil {
+				log.Printf("[ERROR] Failed to flag %s as not found: %v", tx.TxHash, err)
+				result.Errors++
+				continue
+			}
+			if flagged {
+				log.Printf("[WARN] %s transaction %s not found on chain after %s, flagged as not_found", tx.TxType, tx.TxHash, r.notFoundTimeout)
+				result.NotFound++
+				tx.Status = blockchain.TxStatusNotFound
+				r.tracker.notifyFinal(&tx)
+				// Its nonce was never used on chain, later sends must not wait behind it
+				web3.InvalidateNonces()
+			}
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,343 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"telkom_coin_back_end/config"
+	"telkom_coin_back_end/internal/blockchain"
+	"telkom_coin_back_end/internal/dto/response"
+	"telkom_coin_back_end/internal/models"
+	"telkom_coin_back_end/internal/repository"
+	"time"
+)
+
+const (
+	trackerResumeLimit  = 500
+	callbackMaxAttempts = 3
+)
+
+// TransactionTracker watches broadcast transactions until their receipt is
+// final, then records block number and gas in transactions, wakes up clients
+// waiting on the status endpoint and calls the optional callback URL.
+type TransactionTracker struct {
+	txRepo            *repository.TransactionRepository
+	blockchainService *blockchain.BlockchainService
+	pollInterval      time.Duration
+	maxAge            time.Duration
+	callbackSecret    string
+	callbackHosts     map[string]bool
+	httpClient        *http.Client
+
+	mu        sync.Mutex
+	tracked   map[string]time.Time                  // tx hash -> time tracking started
+	waiters   map[string][]chan *models.Transaction // tx hash -> clients waiting for the final status
+	listeners []func(tx *models.Transaction)        // Called once per row that became final
+
+	stopOnce sync.Once
+	stop     chan struct{}
+}
+
+func NewTransactionTracker(txRepo *repository.TransactionRepository, blockchainService *blockchain.BlockchainService) *TransactionTracker {
+	callbackHosts := make(map[string]bool)
+	for _, host := range config.AppConfig.TransferCallbackHosts {
+		callbackHosts[strings.ToLower(host)] = true
+	}
+
+	return &TransactionTracker{
+		txRepo:            txRepo,
+		blockchainService: blockchainService,
+		pollInterval:      config.AppConfig.TxTrackerPollInterval,
+		maxAge:            config.AppConfig.TxTrackerMaxAge,
+		callbackSecret:    config.AppConfig.TransferCallbackSecret,
+		callbackHosts:     callbackHosts,
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+			// Redirects could lead outside the allowlisted hosts
+			CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+		},
+		tracked: make(map[string]time.Time),
+		waiters: make(map[string][]chan *models.Transaction),
+		stop:    make(chan struct{}),
+	}
+}
+
+// Start resumes tracking of pending transfers saved before a restart and runs
+// the receipt loop in the background until Stop is called
+func (t *TransactionTracker) Start() {
+	pending, err := t.txRepo.GetPending(trackerResumeLimit)
+	if err != nil {
+		log.Printf("[ERROR] Transaction tracker failed to load pending transactions: %v", err)
+	}
+	resumed := 0
+	for _, tx := range pending {
+		if tx.TxType == "transfer" && time.Since(tx.CreatedAt) < t.maxAge {
+			t.Track(tx.TxHash)
+			resumed++
+		}
+	}
+
+	log.Printf("📡 Transaction tracker started (%d pending transfers)", resumed)
+
+	go func() {
+		ticker := time.NewTicker(t.pollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-t.stop:
+				log.Println("Transaction tracker stopped")
+				return
+			case <-ticker.C:
+				t.checkTracked()
+			}
+		}
+	}()
+}
+
+// Stop signals the tracker loop to exit
+func (t *TransactionTracker) Stop() {
+	t.stopOnce.Do(func() { close(t.stop) })
+}
+
+// Track starts watching a broadcast transaction
+func (t *TransactionTracker) Track(txHash string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, ok := t.tracked[txHash]; !ok {
+		t.tracked[txHash] = time.Now()
+	}
+}
+
+// Subscribe returns a channel that receives the transaction once it is final.
+// The returned func must be called when the caller stops waiting.
+func (t *TransactionTracker) Subscribe(txHash string) (<-chan *models.Transaction, func()) {
+	ch := make(chan *models.Transaction, 1)
+
+	t.mu.Lock()
+	t.waiters[txHash] = append(t.waiters[txHash], ch)
+	t.mu.Unlock()
+
+	unsubscribe := func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+
+		waiters := t.waiters[txHash]
+		for i, waiter := range waiters {
+			if waiter == ch {
+				t.waiters[txHash] = append(waiters[:i], waiters[i+1:]...)
+				break
+			}
+		}
+		if len(t.waiters[txHash]) == 0 {
+			delete(t.waiters, txHash)
+		}
+	}
+	return ch, unsubscribe
+}
+
+// OnFinal registers fn to be called with every transaction row that becomes
+// final (confirmed, failed or not_found). fn runs on the tracker's goroutine.
+func (t *TransactionTracker) OnFinal(fn func(tx *models.Transaction)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.listeners = append(t.listeners, fn)
+}
+
+// ValidateCallbackURL only accepts https URLs on hosts listed in TRANSFER_CALLBACK_ALLOWED_HOSTS,
+// so user input can never make the server call internal addresses
+func (t *TransactionTracker) ValidateCallbackURL(rawURL string) error {
+	if len(t.callbackHosts) == 0 {
+		return errors.New("callback_url is not enabled")
+	}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+		return errors.New("callback_url must be an https URL")
+	}
+	if !t.callbackHosts[strings.ToLower(parsed.Hostname())] {
+		return errors.New("callback_url host is not allowed")
+	}
+	return nil
+}
+
+// checkTracked fetches the receipt of every tracked transaction
+func (t *TransactionTracker) checkTracked() {
+	t.mu.Lock()
+	hashes := make([]string, 0, len(t.tracked))
+	for hash := range t.tracked {
+		hashes = append(hashes, hash)
+	}
+	t.mu.Unlock()
+
+	for _, hash := range hashes {
+		receipt, err := t.blockchainService.GetTransactionReceipt(hash)
+		if err != nil {
+			log.Printf("[WARN] Transaction tracker failed to get receipt of %s: %v", hash, err)
+			continue
+		}
+
+		switch receipt.Status {
+		case blockchain.TxStatusConfirmed, blockchain.TxStatusFailed:
+			t.RecordReceipt(receipt)
+		default:
+			t.expireIfStale(hash)
+		}
+	}
+}
+
+// expireIfStale stops watching a transaction that has been pending longer than
+// TX_TRACKER_MAX_AGE; the row stays pending in the database
+func (t *TransactionTracker) expireIfStale(txHash string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if started, ok := t.tracked[txHash]; ok && time.Since(started) > t.maxAge {
+		log.Printf("[WARN] Transaction %s still pending after %s, no longer tracked", txHash, t.maxAge)
+		delete(t.tracked, txHash)
+	}
+}
+
+// RecordReceipt stores a final (confirmed or failed) receipt and notifies waiters
+// and the callback URL. Safe to call for a row that is already final.
+func (t *TransactionTracker) RecordReceipt(receipt *blockchain.TransactionReceipt) {
+	var gasPrice int64
+	if receipt.EffectiveGasPrice != nil {
+		gasPrice = receipt.EffectiveGasPrice.Int64()
+	}
+
+	updated, err := t.txRepo.UpdateFinalStatus(receipt.TxHash, receipt.Status, int64(receipt.BlockNumber), int64(receipt.GasUsed), gasPrice)
+	if err != nil {
+		log.Printf("[ERROR] Failed to record receipt of %s: %v", receipt.TxHash, err)
+		return
+	}
+
+	t.mu.Lock()
+	delete(t.tracked, receipt.TxHash)
+	t.mu.Unlock()
+
+	tx, err := t.txRepo.GetByHash(receipt.TxHash)
+	if err != nil {
+		log.Printf("[ERROR] Failed to load transaction %s: %v", receipt.TxHash, err)
+		return
+	}
+
+	t.notifyWaiters(tx)
+
+	// Another worker may already have finalized the row and sent the callback
+	if !updated {
+		return
+	}
+
+	log.Printf("✅ Transaction %s %s in block %d", tx.TxHash, tx.Status, receipt.BlockNumber)
+	t.notifyFinal(tx)
+	if callbackURL, ok := tx.Metadata["callback_url"].(string); ok && callbackURL != "" {
+		go t.sendCallback(callbackURL, tx)
+	}
+}
+
+// notifyFinal calls the OnFinal listeners for a row that just became final
+func (t *TransactionTracker) notifyFinal(tx *models.Transaction) {
+	t.mu.Lock()
+	listeners := append([]func(tx *models.Transaction){}, t.listeners...)
+	t.mu.Unlock()
+
+	for _, listener := range listeners {
+		listener(tx)
+	}
+}
+
+func (t *TransactionTracker) notifyWaiters(tx *models.Transaction) {
+	t.mu.Lock()
+	waiters := t.waiters[tx.TxHash]
+	delete(t.waiters, tx.TxHash)
+	t.mu.Unlock()
+
+	for _, ch := range waiters {
+		ch <- tx
+	}
+}
+
+// sendCallback POSTs the final status, signed with TRANSFER_CALLBACK_SECRET
+// (X-TLC-Signature: sha256=<hex HMAC of the body>), retrying with backoff
+func (t *TransactionTracker) sendCallback(callbackURL string, tx *models.Transaction) {
+	body, err := json.Marshal(map[string]interface{}{
+		"event":       "transaction." + tx.Status,
+		"transaction": NewTransactionStatusResponse(tx),
+	})
+	if err != nil {
+		log.Printf("[ERROR] Failed to encode callback for %s: %v", tx.TxHash, err)
+		return
+	}
+
+	mac := hmac.New(sha256.New, []byte(t.callbackSecret))
+	mac.Write(body)
+	signature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	backoff := time.Second
+	for attempt := 1; attempt <= callbackMaxAttempts; attempt++ {
+		err = t.postCallback(callbackURL, body, signature)
+		if err == nil {
+			return
+		}
+
+		log.Printf("[WARN] Callback for %s failed (attempt %d/%d): %v", tx.TxHash, attempt, callbackMaxAttempts, err)
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+func (t *TransactionTracker) postCallback(callbackURL string, body []byte, signature string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, callbackURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-TLC-Signature", signature)
+
+	resp, err := t.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("callback returned %s", resp.Status)
+	}
+	return nil
+}
+
+// NewTransactionStatusResponse maps a transactions row to the status endpoint response
+func NewTransactionStatusResponse(tx *models.Transaction) *response.TransactionStatusResponse {
+	memo, _ := tx.Metadata["memo"].(string)
+
+	return &response.TransactionStatusResponse{
+		TxHash:      tx.TxHash,
+		TxType:      tx.TxType,
+		Status:      tx.Status,
+		FromAddress: tx.FromAddress,
+		ToAddress:   tx.ToAddress,
+		Amount:      tx.Amount,
+		Memo:        memo,
+		BlockNumber: tx.BlockNumber,
+		GasUsed:     tx.GasUsed,
+		GasPrice:    tx.GasPrice,
+		CreatedAt:   tx.CreatedAt,
+		ConfirmedAt: tx.ConfirmedAt,
+	}
+}
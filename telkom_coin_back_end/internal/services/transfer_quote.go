@@ -0,0 +1,151 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"telkom_coin_back_end/config"
+	"telkom_coin_back_end/internal/models"
+	"telkom_coin_back_end/internal/repository"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// transferQuoteAudience keeps login tokens (same secret by default) from being
+	// accepted as quotes and the other way round
+	transferQuoteAudience = "tlc-transfer-quote"
+
+	transferQuoteCleanupPeriod = time.Hour
+)
+
+var (
+	ErrTransferQuoteInvalid = errors.New("invalid or expired transfer quote, validate the transfer again")
+	ErrTransferQuoteUsed    = errors.New("transfer quote has already been used")
+)
+
+// TransferQuote is what the user confirmed in the validate step. It is signed
+// into the quote token, so execute sends exactly what the user saw.
+type TransferQuote struct {
+	UserID          int64  `json:"uid"`
+	FromAddress     string `json:"from"`
+	ToAddress       string `json:"to"`
+	Amount          string `json:"amount"` // TLC
+	Memo            string `json:"memo,omitempty"`
+	EstimatedFeeWei string `json:"fee,omitempty"`
+	MaxFeeWei       string `json:"max_fee,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// TransferQuoteSigner signs and verifies transfer quotes (HS256 with
+// TRANSFER_QUOTE_SECRET) and makes sure each quote is executed at most once.
+// Claims are stored in transfer_quote_claims and removed after the quote expired.
+type TransferQuoteSigner struct {
+	claimRepo *repository.TransferQuoteClaimRepository
+	secret    []byte
+	ttl       time.Duration
+
+	stopOnce sync.Once
+	stop     chan struct{}
+}
+
+func NewTransferQuoteSigner(claimRepo *repository.TransferQuoteClaimRepository) *TransferQuoteSigner {
+	return &TransferQuoteSigner{
+		claimRepo: claimRepo,
+		secret:    []byte(config.AppConfig.TransferQuoteSecret),
+		ttl:       config.AppConfig.TransferQuoteTTL,
+		stop:      make(chan struct{}),
+	}
+}
+
+// Start removes claims of expired quotes every hour until Stop is called
+func (s *TransferQuoteSigner) Start() {
+	go func() {
+		ticker := time.NewTicker(transferQuoteCleanupPeriod)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-s.stop:
+				return
+			case <-ticker.C:
+				deleted, err := s.claimRepo.DeleteExpired(time.Now())
+				if err != nil {
+					log.Printf("[ERROR] Failed to delete expired transfer quote claims: %v", err)
+				} else if deleted > 0 {
+					log.Printf("🧹 Deleted %d expired transfer quote claims", deleted)
+				}
+			}
+		}
+	}()
+}
+
+// Stop signals the cleanup loop to exit
+func (s *TransferQuoteSigner) Stop() {
+	s.stopOnce.Do(func() { close(s.stop) })
+}
+
+// Sign fills in the quote ID and expiry and returns the quote token
+func (s *TransferQuoteSigner) Sign(quote *TransferQuote) (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	quote.RegisteredClaims = jwt.RegisteredClaims{
+		ID:        hex.EncodeToString(id),
+		Subject:   strconv.FormatInt(quote.UserID, 10),
+		Audience:  jwt.ClaimStrings{transferQuoteAudience},
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(s.ttl)),
+	}
+
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, quote).SignedString(s.secret)
+}
+
+// Verify checks the signature and expiry of a quote token and that it was
+// issued to userID from fromAddress
+func (s *TransferQuoteSigner) Verify(token string, userID int64, fromAddress string) (*TransferQuote, error) {
+	quote := &TransferQuote{}
+	_, err := jwt.ParseWithClaims(token, quote, func(*jwt.Token) (interface{}, error) {
+		return s.secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithAudience(transferQuoteAudience), jwt.WithExpirationRequired())
+	if err != nil || quote.ID == "" {
+		return nil, ErrTransferQuoteInvalid
+	}
+	if quote.UserID != userID || !strings.EqualFold(quote.FromAddress, fromAddress) {
+		return nil, ErrTransferQuoteInvalid
+	}
+	return quote, nil
+}
+
+// Claim marks a quote as used; it fails when the quote was already claimed
+func (s *TransferQuoteSigner) Claim(quote *TransferQuote) error {
+	claimed, err := s.claimRepo.Claim(&models.TransferQuoteClaim{
+		QuoteID:   quote.ID,
+		UserID:    quote.UserID,
+		ExpiresAt: quote.ExpiresAt.Time,
+	})
+	if err != nil {
+		log.Printf("[ERROR] Failed to claim transfer quote %s: %v", quote.ID, err)
+		return errors.New("failed to claim transfer quote")
+	}
+	if !claimed {
+		return ErrTransferQuoteUsed
+	}
+	return nil
+}
+
+// Release makes a claimed quote usable again, for transfers that failed
+// before anything was broadcast
+func (s *TransferQuoteSigner) Release(quote *TransferQuote) {
+	if err := s.claimRepo.Delete(quote.ID); err != nil {
+		log.Printf("[ERROR] Failed to release transfer quote %s: %v", quote.ID, err)
+	}
+}
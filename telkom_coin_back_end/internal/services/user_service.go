@@ -41,6 +41,8 @@ func (s *UserService) GetProfile(userID int64) (*response.UserResponse, error) {
 		KYCStatus:     user.KYCStatus,
 		Status:        user.Status,
 		CreatedAt:     user.CreatedAt,
+
+		PhoneDiscoverable: &user.PhoneDiscoverable,
 	}, nil
 }
 
@@ -70,13 +72,14 @@ func (s *UserService) GetDetailProfile(userID int64) (*response.UserDetailRespon
 	availableBalance := new(big.Int).Sub(currentBalance, lockedBalance)
 
 	return &response.UserDetailResponse{
-		ID:            user.ID,
-		Username:      user.Username,
-		Email:         user.Email,
-		Phone:         user.Phone,
-		WalletAddress: user.WalletAddress,
-		KYCStatus:     user.KYCStatus,
-		Status:        user.Status,
+		ID:                user.ID,
+		Username:          user.Username,
+		Email:             user.Email,
+		Phone:             user.Phone,
+		PhoneDiscoverable: user.PhoneDiscoverable,
+		WalletAddress:     user.WalletAddress,
+		KYCStatus:         user.KYCStatus,
+		Status:            user.Status,
 		Balance: response.BalanceResponse{
 			Balance:       balance.Balance,
 			LockedBalance: balance.LockedBalance,
@@ -125,6 +128,9 @@ func (s *UserService) UpdateProfile(userID int64, req *request.UpdateProfileRequ
 	if req.Phone != "" {
 		user.Phone = req.Phone
 	}
+	if req.PhoneDiscoverable != nil {
+		user.PhoneDiscoverable = *req.PhoneDiscoverable
+	}
 
 	// Save changes
 	if err := s.userRepo.Update(user); err != nil {
@@ -140,6 +146,8 @@ func (s *UserService) UpdateProfile(userID int64, req *request.UpdateProfileRequ
 		KYCStatus:     user.KYCStatus,
 		Status:        user.Status,
 		CreatedAt:     user.CreatedAt,
+
+		PhoneDiscoverable: &user.PhoneDiscoverable,
 	}, nil
 }
 
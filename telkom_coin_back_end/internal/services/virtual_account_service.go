@@ -0,0 +1,343 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"math/big"
+	"net/http"
+	"telkom_coin_back_end/config"
+	"telkom_coin_back_end/internal/dto/response"
+	"telkom_coin_back_end/internal/models"
+	"telkom_coin_back_end/internal/payment"
+	"telkom_coin_back_end/internal/repository"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Same minimum as POST /api/topup and the contract's minMintAmount
+var minTopupAmount = big.NewInt(10000)
+
+var (
+	ErrVACreditNotFound = errors.New("virtual account credit not found")
+	ErrVACreditReviewed = errors.New("virtual account credit is not waiting for review")
+)
+
+// VirtualAccountService gives every user a stable virtual account number per
+// bank. Transfers to a number become paid topup orders without the user
+// creating one; transfers that match no user wait in a manual review queue.
+type VirtualAccountService struct {
+	vaRepo       *repository.VirtualAccountRepository
+	orderRepo    *repository.TopupOrderRepository
+	userRepo     *repository.UserRepository
+	topupService *TopupService
+	limitService *LimitService
+	provider     payment.PaymentProvider
+}
+
+func NewVirtualAccountService(
+	vaRepo *repository.VirtualAccountRepository,
+	orderRepo *repository.TopupOrderRepository,
+	userRepo *repository.UserRepository,
+	topupService *TopupService,
+	limitService *LimitService,
+	provider payment.PaymentProvider,
+) *VirtualAccountService {
+	return &VirtualAccountService{
+		vaRepo:       vaRepo,
+		orderRepo:    orderRepo,
+		userRepo:     userRepo,
+		topupService: topupService,
+		limitService: limitService,
+		provider:     provider,
+	}
+}
+
+// GetVirtualAccounts returns the user's virtual account at every bank,
+// assigning the ones the user does not have yet
+func (s *VirtualAccountService) GetVirtualAccounts(userID int64) ([]response.VirtualAccountResponse, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+
+	accounts, err := s.vaRepo.GetByUserID(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	assigned := make(map[string]bool, len(accounts))
+	for _, account := range accounts {
+		assigned[account.Bank] = true
+	}
+
+	for _, bank := range payment.VirtualAccountBankNames() {
+		if assigned[bank] {
+			continue
+		}
+		number, err := payment.NewVANumber(bank, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		// A concurrent request may have assigned it first; the list is read again below
+		if err := s.vaRepo.Create(&models.VirtualAccount{UserID: user.ID, Bank: bank, Number: number}); err != nil {
+			log.Printf("[WARN] Failed to assign %s virtual account to user %d: %v", bank, user.ID, err)
+		}
+	}
+
+	accounts, err = s.vaRepo.GetByUserID(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]response.VirtualAccountResponse, 0, len(accounts))
+	for _, account := range accounts {
+		result = append(result, response.VirtualAccountResponse{
+			Bank:        account.Bank,
+			Number:      account.Number,
+			AccountName: "TLC " + user.Username,
+			MinAmount:   minTopupAmount.String(),
+		})
+	}
+	return result, nil
+}
+
+// HandleCreditNotification verifies an incoming transfer to a virtual account.
+// A transfer to a user's number becomes a paid topup order and is minted; any
+// other goes to the review queue. Repeated notifications return the first result.
+func (s *VirtualAccountService) HandleCreditNotification(header http.Header, body []byte) (*models.VirtualAccountCredit, error) {
+	notification, err := s.provider.ParseCreditNotification(header, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing, err := s.vaRepo.GetCreditByTransactionID(s.provider.Name(), notification.TransactionID); err == nil {
+		return existing, nil
+	}
+
+	paidAt := notification.PaidAt
+	if paidAt.IsZero() {
+		paidAt = time.Now()
+	}
+	credit := &models.VirtualAccountCredit{
+		Provider:      s.provider.Name(),
+		TransactionID: notification.TransactionID,
+		Bank:          notification.Bank,
+		VANumber:      notification.VANumber,
+		Amount:        notification.Amount,
+		PaidAt:        paidAt,
+	}
+
+	user, reason := s.match(notification)
+	if reason != "" {
+		credit.Status = models.VACreditStatusNeedsReview
+		credit.Reason = reason
+		if err := s.vaRepo.CreateCredit(credit); err != nil {
+			return s.existingCredit(credit, err)
+		}
+		log.Printf("[WARN] Virtual account credit %s (%s IDR to %s) needs review: %s", credit.TransactionID, credit.Amount, credit.VANumber, reason)
+		return credit, nil
+	}
+
+	order, err := s.creditUser(credit, user, func(vaRepo *repository.VirtualAccountRepository, order *models.TopupOrder) error {
+		credit.Status = models.VACreditStatusMatched
+		credit.UserID = &user.ID
+		credit.OrderID = &order.ID
+		return vaRepo.CreateCredit(credit)
+	})
+	if err != nil {
+		return s.existingCredit(credit, err)
+	}
+	log.Printf("🏦 Virtual account credit %s (%s IDR) matched to user %d as topup %s", credit.TransactionID, credit.Amount, user.ID, order.Reference)
+
+	s.mint(order)
+	return credit, nil
+}
+
+// ListCredits lists incoming credits in a status (the review queue by default)
+func (s *VirtualAccountService) ListCredits(status string, page, limit int) (*response.VirtualAccountCreditListResponse, error) {
+	credits, totalCount, err := s.vaRepo.GetCreditsByStatus(status, page, limit)
+	if err != nil {
+		log.Printf("[ERROR] Failed to get virtual account credits: %v", err)
+		return nil, errors.New("failed to retrieve virtual account credits")
+	}
+
+	return &response.VirtualAccountCreditListResponse{
+		Credits:    credits,
+		TotalCount: int(totalCount),
+		Page:       page,
+		Limit:      limit,
+		TotalPages: (int(totalCount) + limit - 1) / limit,
+	}, nil
+}
+
+// AssignCredit credits a reviewed transfer to a user as a paid topup order.
+// Limits are not checked again: the admin decided after review.
+func (s *VirtualAccountService) AssignCredit(creditID, userID int64, note string) (*models.VirtualAccountCredit, error) {
+	credit, err := s.getReviewable(creditID)
+	if err != nil {
+		return nil, err
+	}
+
+	if amount, ok := new(big.Int).SetString(credit.Amount, 10); !ok || amount.Cmp(minTopupAmount) < 0 {
+		return nil, errors.New("amount is below the minimum topup and cannot be minted, reject it instead")
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+	if user.Status != "active" {
+		return nil, errors.New("account is not active")
+	}
+
+	now := time.Now()
+	order, err := s.creditUser(credit, user, func(vaRepo *repository.VirtualAccountRepository, order *models.TopupOrder) error {
+		moved, err := vaRepo.TransitionCredit(credit.ID, models.VACreditStatusNeedsReview, models.VACreditStatusResolved, map[string]interface{}{
+			"user_id":     user.ID,
+			"order_id":    order.ID,
+			"note":        note,
+			"reviewed_at": now,
+		})
+		if err != nil {
+			return err
+		}
+		if !moved {
+			return ErrVACreditReviewed
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("🏦 Virtual account credit %s (%s IDR) assigned to user %d as topup %s", credit.TransactionID, credit.Amount, user.ID, order.Reference)
+
+	s.mint(order)
+	return s.vaRepo.GetCreditByID(credit.ID)
+}
+
+// RejectCredit closes a reviewed transfer without minting (e.g. refunded to the sender)
+func (s *VirtualAccountService) RejectCredit(creditID int64, note string) (*models.VirtualAccountCredit, error) {
+	credit, err := s.getReviewable(creditID)
+	if err != nil {
+		return nil, err
+	}
+
+	moved, err := s.vaRepo.TransitionCredit(credit.ID, models.VACreditStatusNeedsReview, models.VACreditStatusRejected, map[string]interface{}{
+		"note":        note,
+		"reviewed_at": time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !moved {
+		return nil, ErrVACreditReviewed
+	}
+	return s.vaRepo.GetCreditByID(credit.ID)
+}
+
+// match finds the user a transfer was meant for; the reason is set when the
+// transfer has to be reviewed instead
+func (s *VirtualAccountService) match(notification *payment.CreditNotification) (*models.User, string) {
+	amount, ok := new(big.Int).SetString(notification.Amount, 10)
+	if !ok || amount.Cmp(minTopupAmount) < 0 {
+		return nil, "amount is not a whole IDR amount of at least " + minTopupAmount.String()
+	}
+
+	bank, err := payment.ValidateVANumber(notification.VANumber)
+	if err != nil {
+		return nil, err.Error()
+	}
+	if notification.Bank != "" && notification.Bank != bank {
+		return nil, "bank does not match the virtual account number"
+	}
+
+	account, err := s.vaRepo.GetByNumber(notification.VANumber)
+	if err != nil {
+		return nil, "virtual account is not assigned to a user"
+	}
+	user, err := s.userRepo.GetByID(account.UserID)
+	if err != nil {
+		return nil, "virtual account user not found"
+	}
+	if user.Status != "active" {
+		return nil, "account is not active"
+	}
+
+	if err := s.limitService.Check(user, config.LimitOperationTopup, amount.String()); err != nil {
+		return nil, err.Error()
+	}
+	return user, ""
+}
+
+// creditUser saves a paid topup order for a transfer together with the credit
+// change made by save, in one DB transaction
+func (s *VirtualAccountService) creditUser(
+	credit *models.VirtualAccountCredit,
+	user *models.User,
+	save func(vaRepo *repository.VirtualAccountRepository, order *models.TopupOrder) error,
+) (*models.TopupOrder, error) {
+	reference, err := newTopupReference(time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	paidAt := credit.PaidAt
+	order := &models.TopupOrder{
+		UserID:            user.ID,
+		Reference:         reference,
+		Amount:            credit.Amount,
+		PaymentMethod:     payment.MethodVA,
+		Provider:          credit.Provider,
+		ProviderReference: credit.TransactionID,
+		PaymentDetails: models.TransactionMetadata{
+			"bank":      credit.Bank,
+			"va_number": credit.VANumber,
+			"source":    "virtual_account",
+		},
+		Status:    models.TopupOrderStatusPaid,
+		ExpiresAt: paidAt,
+		PaidAt:    &paidAt,
+	}
+
+	err = s.orderRepo.Transaction(func(tx *gorm.DB) error {
+		if err := s.orderRepo.WithTx(tx).Create(order); err != nil {
+			return err
+		}
+		return save(s.vaRepo.WithTx(tx), order)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// mint starts the mint of a credited order; a failure is retried by the topup sweeper
+func (s *VirtualAccountService) mint(order *models.TopupOrder) {
+	if err := s.topupService.MintPaidOrder(order); err != nil {
+		log.Printf("[ERROR] Failed to mint topup order %s: %v", order.Reference, err)
+	}
+}
+
+func (s *VirtualAccountService) getReviewable(creditID int64) (*models.VirtualAccountCredit, error) {
+	credit, err := s.vaRepo.GetCreditByID(creditID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrVACreditNotFound
+		}
+		return nil, err
+	}
+	if credit.Status != models.VACreditStatusNeedsReview {
+		return nil, ErrVACreditReviewed
+	}
+	return credit, nil
+}
+
+// existingCredit returns the credit saved by a concurrent notification for
+// the same transfer, or the save error
+func (s *VirtualAccountService) existingCredit(credit *models.VirtualAccountCredit, saveErr error) (*models.VirtualAccountCredit, error) {
+	if existing, err := s.vaRepo.GetCreditByTransactionID(credit.Provider, credit.TransactionID); err == nil {
+		return existing, nil
+	}
+	log.Printf("[ERROR] Failed to save virtual account credit %s: %v", credit.TransactionID, saveErr)
+	return nil, errors.New("failed to record credit")
+}
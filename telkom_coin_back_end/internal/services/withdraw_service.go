@@ -6,6 +6,7 @@ import (
 	"math/big"
 
 	// "telkom_coin_back_end/internal/dto/request" // Tidak dipakai lagi di service ini
+	"telkom_coin_back_end/config"
 	"telkom_coin_back_end/internal/blockchain"
 	"telkom_coin_back_end/internal/dto/response"
 	"telkom_coin_back_end/internal/models"
@@ -23,7 +24,10 @@ type WithdrawService struct {
 	userRepo        *repository.UserRepository
 	balanceRepo     *repository.BalanceRepository
 	txRepo          *repository.TransactionRepository
+	outboxRepo      *repository.OutboxRepository
+	relay           *OutboxRelay
 	contractService *web3.ContractService
+	limitService    *LimitService
 	// Hapus authService jika hanya untuk verifikasi PIN, karena kita bisa lakukan di sini
 }
 
@@ -33,6 +37,9 @@ func NewWithdrawService(
 	balanceRepo *repository.BalanceRepository,
 	txRepo *repository.TransactionRepository,
 	blockchainService *blockchain.BlockchainService, // Diperlukan untuk contractService
+	outboxRepo *repository.OutboxRepository,
+	relay *OutboxRelay,
+	limitService *LimitService,
 ) *WithdrawService {
 	var contractService *web3.ContractService
 	if blockchainService.IsWeb3Enabled() {
@@ -46,7 +53,10 @@ func NewWithdrawService(
 		userRepo:        userRepo,
 		balanceRepo:     balanceRepo,
 		txRepo:          txRepo,
+		outboxRepo:      outboxRepo,
+		relay:           relay,
 		contractService: contractService,
+		limitService:    limitService,
 	}
 }
 
@@ -105,6 +115,13 @@ func (s *WithdrawService) CreateWithdrawal(userID int64, amount, bankAccount, pi
 		return nil, errors.New("minimum withdrawal amount is 1,000 TLC")
 	}
 
+	// Cek limit withdraw; ditahan sampai intent tersimpan di outbox, lalu intent ikut terhitung
+	releaseLimit, err := s.limitService.Reserve(user, config.LimitOperationWithdraw, amountBigInt.String())
+	if err != nil {
+		return nil, err
+	}
+	defer releaseLimit()
+
 	// 5. Check blockchain availability
 	if s.contractService == nil {
 		return nil, errors.New("blockchain service not available")
@@ -124,36 +141,42 @@ func (s *WithdrawService) CreateWithdrawal(userID int64, amount, bankAccount, pi
 		return nil, errors.New("insufficient on-chain balance")
 	}
 
-	// 8. Decrypt private key
-	privateKey, err := crypto.DecryptPrivateKey(user.PrivateKeyEncrypted)
+	// 8. Lock jumlah withdraw & simpan intent ke outbox dalam satu DB transaction,
+	// supaya withdraw yang berjalan bersamaan tidak melebihi saldo on-chain
+	intent := &models.ChainOutbox{
+		UserID:        user.ID,
+		Action:        models.OutboxActionWithdraw,
+		WalletAddress: user.WalletAddress,
+		Amount:        amount,
+		Payload: models.TransactionMetadata{
+			"bank_account": bankAccount,
+		},
+		Status: models.OutboxStatusPending,
+	}
+	err = s.outboxRepo.Transaction(func(tx *gorm.DB) error {
+		if err := s.balanceRepo.WithTx(tx).LockBalanceWithin(user.ID, amount, wholeTokens(currentBalance)); err != nil {
+			return err
+		}
+		return s.outboxRepo.WithTx(tx).Create(intent)
+	})
 	if err != nil {
-		return nil, errors.New("failed to decrypt private key")
+		if err.Error() == "insufficient available balance" {
+			return nil, errors.New("insufficient on-chain balance, including pending withdrawals")
+		}
+		log.Printf("[ERROR] Failed to save withdraw intent: %v", err)
+		return nil, errors.New("failed to create withdrawal")
 	}
+	releaseLimit()
 
-	// 9. Kirim transaksi ke blockchain (burn tokens)
-	txHash, err := s.contractService.RequestWithdraw(privateKey, amountWei, bankAccount)
+	// 9. Relay menandatangani & broadcast (burn tokens); recorder melepas lock setelah receipt ada
+	msg, err := s.relay.Dispatch(intent.ID)
 	if err != nil {
 		return nil, errors.New("blockchain withdrawal request failed: " + err.Error())
 	}
 
-	txRecord := &models.Transaction{
-		TxHash:      txHash,
-		FromAddress: user.WalletAddress,
-		ToAddress:   "", // kosong karena withdraw gak kirim ke address lain
-		Amount:      amount,
-		TxType:      "withdraw",
-		Status:      "processing",
-		CreatedAt:   time.Now(),
-	}
-
-	if err := s.txRepo.Create(txRecord); err != nil {
-		log.Printf("[ERROR] Failed to save withdraw record: %v", err)
-		// Bisa return error kalau mau strict
-	}
-
 	// 10. Return response sukses
 	return &response.TLCWithdrawResponse{
-		TxHash:        txHash, // ID unik dari transaksi blockchain
+		TxHash:        msg.TxHash, // ID unik dari transaksi blockchain
 		Amount:        amount,
 		AmountWei:     amountWei.String(),
 		BankAccount:   bankAccount,
@@ -162,3 +185,35 @@ func (s *WithdrawService) CreateWithdrawal(userID int64, amount, bankAccount, pi
 		CreatedAt:     time.Now(),
 	}, nil
 }
+
+// GetWithdrawLimits returns the user's withdrawal limits and what is left of them
+func (s *WithdrawService) GetWithdrawLimits(userID int64) (*response.WithdrawLimitsResponse, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.New("user not found")
+		}
+		return nil, err
+	}
+
+	limits, err := s.limitService.GetOperationLimits(user, config.LimitOperationWithdraw)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.WithdrawLimitsResponse{
+		OperationLimitResponse: *limits,
+		KYCStatus:              user.KYCStatus,
+		FiatWithdrawal:         user.KYCStatus == config.TierVerified,
+	}, nil
+}
+
+// tokenUnit is 1 TLC in wei
+func tokenUnit() *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(blockchain.TokenDecimals), nil)
+}
+
+// wholeTokens converts a wei amount to whole TLC, rounded down
+func wholeTokens(amountWei *big.Int) string {
+	return new(big.Int).Div(amountWei, tokenUnit()).String()
+}
@@ -0,0 +1,24 @@
+package web3
+
+import (
+	"github.com/ethereum/go-ethereum"
+)
+
+// Backend is the chain access used by the app. It is satisfied by a dialed
+// *ethclient.Client as well as by the in-process simulated chain.
+type Backend interface {
+	ethereum.BlockNumberReader
+	ethereum.ChainReader
+	ethereum.ChainStateReader
+	ethereum.ContractCaller
+	ethereum.GasEstimator
+	ethereum.GasPricer
+	ethereum.GasPricer1559
+	ethereum.FeeHistoryReader
+	ethereum.LogFilterer
+	ethereum.PendingStateReader
+	ethereum.PendingContractCaller
+	ethereum.TransactionReader
+	ethereum.TransactionSender
+	ethereum.ChainIDReader
+}
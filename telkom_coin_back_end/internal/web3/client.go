@@ -3,69 +3,105 @@ package web3
 import (
 	"context"
 	"crypto/ecdsa"
+	"encoding/hex"
+	"errors"
 	"fmt"
-	"log"
 	"math/big"
-	"os"
+
+	"telkom_coin_back_end/config"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/ethereum/go-ethereum/ethclient"
 )
 
 // Web3Client handles blockchain interactions
 type Web3Client struct {
-	client          *ethclient.Client
+	client          Backend
+	network         config.Network
 	contractAddress common.Address
 	privateKey      *ecdsa.PrivateKey
 	chainID         *big.Int
+	simulated       *simulatedChain // nil unless the network is simulated
+	fees            feeConfig
 }
 
-// NewWeb3Client creates a new web3 client
+// NewWeb3Client creates a new web3 client for the active network
 func NewWeb3Client() (*Web3Client, error) {
-	// Connect to Ganache
-	rpcURL := os.Getenv("BLOCKCHAIN_RPC_URL")
-	if rpcURL == "" {
-		rpcURL = "http://127.0.0.1:8545"
+	return NewWeb3ClientForNetwork(config.ActiveNetwork())
+}
+
+// NewWeb3ClientForNetwork creates a web3 client for a network from the registry
+func NewWeb3ClientForNetwork(network config.Network) (*Web3Client, error) {
+	// In-process chain, shared by every client
+	if network.Simulated {
+		chain, err := getSimulatedChain()
+		if err != nil {
+			return nil, err
+		}
+
+		return &Web3Client{
+			client:          chain.client,
+			network:         network,
+			contractAddress: chain.contractAddress,
+			privateKey:      chain.adminKey,
+			chainID:         chain.chainID,
+			simulated:       chain,
+			fees:            loadFeeConfig(),
+		}, nil
 	}
 
-	client, err := ethclient.Dial(rpcURL)
-	if err != nil {
-		return nil, err
+	// Contract and admin key are required to sign anything on a real chain
+	if network.ContractAddress == "" {
+		return nil, fmt.Errorf("CONTRACT_ADDRESS must be set for network %s", network.Key)
 	}
 
-	// Get chain ID
-	chainID, err := client.NetworkID(context.Background())
-	if err != nil {
-		return nil, err
+	privateKeyHex := config.AppConfig.AdminPrivateKey
+	if privateKeyHex == "" {
+		return nil, errors.New("ADMIN_PRIVATE_KEY must be set in environment")
 	}
 
-	// Load contract address
-	contractAddr := os.Getenv("CONTRACT_ADDRESS")
-	if contractAddr == "" {
-		log.Fatal("CONTRACT_ADDRESS must be set in environment")
+	privateKey, err := crypto.HexToECDSA(privateKeyHex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ADMIN_PRIVATE_KEY: %v", err)
 	}
 
-	// Load private key for transactions
-	privateKeyHex := os.Getenv("ADMIN_PRIVATE_KEY")
-	if privateKeyHex == "" {
-		log.Fatal("ADMIN_PRIVATE_KEY must be set in environment")
+	// RPC endpoints with retry and failover, shared by every client of the network
+	client, err := getFailoverClient(network)
+	if err != nil {
+		return nil, err
 	}
 
-	privateKey, err := crypto.HexToECDSA(privateKeyHex)
+	// Chain ID from the registry (EIP-155 signing uses the chain ID, not the network ID);
+	// endpoints reporting another chain are marked unhealthy by the health check
+	chainID, err := client.ChainID(context.Background())
 	if err != nil {
 		return nil, err
 	}
 
 	return &Web3Client{
 		client:          client,
-		contractAddress: common.HexToAddress(contractAddr),
+		network:         network,
+		contractAddress: common.HexToAddress(network.ContractAddress),
 		privateKey:      privateKey,
 		chainID:         chainID,
+		fees:            loadFeeConfig(),
 	}, nil
 }
 
+// Nonces returns the nonce manager shared by every client
+func (w *Web3Client) Nonces() *NonceManager {
+	return sharedNonceManager
+}
+
+// SendFailed gives the nonce of a transactor back after its transaction was rejected
+func (w *Web3Client) SendFailed(auth *bind.TransactOpts, err error) {
+	if auth == nil || auth.Nonce == nil {
+		return
+	}
+	w.Nonces().SendFailed(context.Background(), w.client, auth.From, auth.Nonce.Uint64(), err)
+}
+
 // GetTransactor creates a transactor for contract interactions
 func (w *Web3Client) GetTransactor() (*bind.TransactOpts, error) {
 	auth, err := bind.NewKeyedTransactorWithChainID(w.privateKey, w.chainID)
@@ -73,22 +109,20 @@ func (w *Web3Client) GetTransactor() (*bind.TransactOpts, error) {
 		return nil, err
 	}
 
-	// Get nonce
-	nonce, err := w.client.PendingNonceAt(context.Background(), auth.From)
-	if err != nil {
+	// Dynamic fees, capped by GAS_FEE_CAP_GWEI
+	if err := w.applyFees(context.Background(), auth); err != nil {
 		return nil, err
 	}
 
-	// Get gas price
-	gasPrice, err := w.client.SuggestGasPrice(context.Background())
+	// Reserve nonce (release it with SendFailed if the transaction is not sent)
+	nonce, err := w.Nonces().Acquire(context.Background(), w.client, auth.From)
 	if err != nil {
 		return nil, err
 	}
 
 	auth.Nonce = big.NewInt(int64(nonce))
 	auth.Value = big.NewInt(0)
-	auth.GasLimit = uint64(300000) // Default gas limit
-	auth.GasPrice = gasPrice
+	auth.GasLimit = 0 // Estimated per call by bind; set GasLimitWithMargin for a safety margin
 
 	return auth, nil
 }
@@ -102,41 +136,65 @@ func (w *Web3Client) GetCallOpts() *bind.CallOpts {
 }
 
 // GetClient returns the ethereum client
-func (w *Web3Client) GetClient() *ethclient.Client {
+func (w *Web3Client) GetClient() Backend {
 	return w.client
 }
 
+// IsSimulated reports whether the client is connected to the in-process chain
+func (w *Web3Client) IsSimulated() bool {
+	return w.simulated != nil
+}
+
+// EnsureGasFunds makes sure an account can pay for gas. Only the simulated chain
+// has a faucet; on a real node this is a no-op.
+func (w *Web3Client) EnsureGasFunds(ctx context.Context, account common.Address) error {
+	if w.simulated == nil {
+		return nil
+	}
+	return w.simulated.fundGas(ctx, account)
+}
+
+// GetNetwork returns the registry entry the client is connected to
+func (w *Web3Client) GetNetwork() config.Network {
+	return w.network
+}
+
 // GetContractAddress returns the contract address
 func (w *Web3Client) GetContractAddress() common.Address {
 	return w.contractAddress
 }
 
-// Close closes the client connection
-func (w *Web3Client) Close() {
-	w.client.Close()
+// GetChainID returns the chain ID reported by the node
+func (w *Web3Client) GetChainID() int64 {
+	return w.chainID.Int64()
 }
 
-// GetTransactorFromPrivateKey creates transactor dari private key
-func (wc *Web3Client) GetTransactorFromPrivateKey(privateKeyStr string) (*bind.TransactOpts, error) {
-	fmt.Printf("[DEBUG] Creating transactor from private key\n")
-
-	privateKey, err := crypto.HexToECDSA(privateKeyStr)
-	if err != nil {
-		fmt.Printf("[ERROR] HexToECDSA failed: %v\n", err)
-		return nil, err
+// ChainStatus reports the connectivity of every RPC endpoint of the network
+func (w *Web3Client) ChainStatus() ChainStatus {
+	if client, ok := w.client.(*FailoverClient); ok {
+		return client.Status()
 	}
 
-	auth, err := bind.NewKeyedTransactorWithChainID(privateKey, big.NewInt(1337))
-	if err != nil {
-		fmt.Printf("[ERROR] Failed to create transactor: %v\n", err)
-		return nil, err
+	// The simulated chain runs in-process and is always reachable
+	return ChainStatus{
+		Network:          w.network.Key,
+		ChainID:          w.chainID.Int64(),
+		Status:           ChainStatusHealthy,
+		HealthyEndpoints: 1,
+		TotalEndpoints:   1,
+		Endpoints: []EndpointStatus{
+			{URL: "in-process", Active: true, Healthy: true},
+		},
 	}
-	// Set gas price dan limit
-	auth.GasPrice = big.NewInt(1000000000) // 1 Gwei
-	auth.GasLimit = uint64(300000)
+}
 
-	fmt.Printf("[DEBUG] Transactor created for: %s\n", auth.From.Hex())
-	return auth, nil
+// Close releases the client. RPC connections are shared per network and the
+// simulated chain lives for the whole process, so nothing is closed here.
+func (w *Web3Client) Close() {}
+
+// adminPrivateKeyHex returns the admin key in the hex form the transactor helpers take
+func (w *Web3Client) adminPrivateKeyHex() string {
+	return hex.EncodeToString(crypto.FromECDSA(w.privateKey))
 }
 
 var web3ClientInstance *Web3Client
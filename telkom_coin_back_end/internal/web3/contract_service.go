@@ -5,38 +5,34 @@ import (
 	"errors"
 	"log"
 	"math/big"
-	"os"
-	"strings"
 	"time"
 
-	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
-// ContractService handles smart contract interactions
+//go:generate go run gen_bindings.go
+
+// ContractService handles smart contract interactions through the typed
+// PaymentToken bindings in payment_token.go
 type ContractService struct {
-	client   *Web3Client
-	contract *bind.BoundContract
-	abi      abi.ABI
+	client *Web3Client
+	token  *PaymentToken
 }
 
 // NewContractService creates a new contract service
 func NewContractService(client *Web3Client) (*ContractService, error) {
-	contractABI, err := abi.JSON(strings.NewReader(PaymentTokenABI))
+	// GetClient() mengembalikan Backend (ethclient atau simulated chain)
+	token, err := NewPaymentToken(client.GetContractAddress(), client.GetClient())
 	if err != nil {
 		return nil, err
 	}
 
-	// Asumsi dari kode Anda, GetClient() mengembalikan *ethclient.Client
-	boundContract := bind.NewBoundContract(client.GetContractAddress(), contractABI, client.GetClient(), client.GetClient(), client.GetClient())
-
 	return &ContractService{
-		client:   client,
-		contract: boundContract,
-		abi:      contractABI,
+		client: client,
+		token:  token,
 	}, nil
 }
 
@@ -65,7 +61,7 @@ func (cs *ContractService) createManualTransactor(privateKeyHex string) (*bind.T
 		return nil, errors.New("failed to convert private key: " + err.Error())
 	}
 
-	// 2. Ambil Chain ID. Asumsi GetClient() mengembalikan *ethclient.Client
+	// 2. Ambil Chain ID
 	chainID, err := cs.client.GetClient().ChainID(context.Background())
 	if err != nil {
 		return nil, errors.New("failed to get chain ID: " + err.Error())
@@ -77,36 +73,161 @@ func (cs *ContractService) createManualTransactor(privateKeyHex string) (*bind.T
 		return nil, errors.New("failed to create transactor: " + err.Error())
 	}
 
-	// 4. Atur Nonce
+	// Wallet baru di simulated chain belum punya ETH untuk gas
 	fromAddress := auth.From
-	nonce, err := cs.client.GetClient().PendingNonceAt(context.Background(), fromAddress)
-	if err != nil {
-		return nil, errors.New("failed to get nonce: " + err.Error())
+	if err := cs.client.EnsureGasFunds(context.Background(), fromAddress); err != nil {
+		return nil, errors.New("failed to fund gas: " + err.Error())
 	}
-	auth.Nonce = big.NewInt(int64(nonce))
 
-	// 5. Atur Gas Price
-	gasPrice, err := cs.client.GetClient().SuggestGasPrice(context.Background())
+	// 4. Atur fee EIP-1559 (tip + fee cap, dibatasi GAS_FEE_CAP_GWEI)
+	if err := cs.client.applyFees(context.Background(), auth); err != nil {
+		return nil, errors.New("failed to set transaction fees: " + err.Error())
+	}
+
+	// 5. Atur Nonce lewat nonce manager (dikembalikan oleh transact kalau gagal kirim)
+	nonce, err := cs.client.Nonces().Acquire(context.Background(), cs.client.GetClient(), fromAddress)
 	if err != nil {
-		return nil, errors.New("failed to suggest gas price: " + err.Error())
+		return nil, errors.New("failed to get nonce: " + err.Error())
 	}
-	auth.GasPrice = gasPrice
+	auth.Nonce = new(big.Int).SetUint64(nonce)
 
-	// 6. ATUR GAS LIMIT MANUAL (INI KUNCINYA!)
-	auth.GasLimit = uint64(300000) // Nilai aman untuk fungsi standar
+	// 6. Gas limit diestimasi per call di transact()
 
 	// 7. Log semua detail untuk debugging
 	log.Println("====================== [ DEBUG: New Transactor Created ] ======================")
 	log.Printf("[DEBUG] Signer Address: %s", auth.From.Hex())
 	log.Printf("[DEBUG] Chain ID: %s", chainID.String())
 	log.Printf("[DEBUG] Nonce: %s", auth.Nonce.String())
-	log.Printf("[DEBUG] Gas Price: %s wei", auth.GasPrice.String())
-	log.Printf("[DEBUG] Gas Limit (MANUALLY SET): %d", auth.GasLimit)
+	if auth.GasFeeCap != nil {
+		log.Printf("[DEBUG] Max Fee: %s wei, Tip: %s wei", auth.GasFeeCap.String(), auth.GasTipCap.String())
+	} else {
+		log.Printf("[DEBUG] Gas Price: %s wei", auth.GasPrice.String())
+	}
 	log.Println("==============================================================================")
 
 	return auth, nil
 }
 
+// transactFunc is a typed binding call, e.g. cs.token.Transfer with its arguments bound
+type transactFunc func(opts *bind.TransactOpts) (*types.Transaction, error)
+
+// transact signs a typed contract call with a transactor from createManualTransactor
+// and sends it; the nonce goes back to the nonce manager when the send fails.
+func (cs *ContractService) transact(auth *bind.TransactOpts, call transactFunc) (*types.Transaction, error) {
+	tx, err := cs.sign(auth, call)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cs.client.GetClient().SendTransaction(context.Background(), tx); err != nil {
+		cs.client.SendFailed(auth, err)
+		return nil, err
+	}
+	return tx, nil
+}
+
+// sign builds and signs a typed contract call without sending it. The gas limit
+// is the estimate of a dry run plus the configured margin. On error the nonce
+// goes back to the nonce manager; on success it stays reserved for the caller.
+func (cs *ContractService) sign(auth *bind.TransactOpts, call transactFunc) (*types.Transaction, error) {
+	auth.NoSend = true
+	auth.GasLimit = 0
+	dryRun, err := call(auth)
+	if err != nil {
+		cs.client.SendFailed(auth, err)
+		return nil, err
+	}
+
+	auth.GasLimit = cs.client.GasLimitWithMargin(dryRun.Gas())
+
+	tx, err := call(auth)
+	if err != nil {
+		cs.client.SendFailed(auth, err)
+		return nil, err
+	}
+	return tx, nil
+}
+
+// SendSigned broadcasts a transaction signed earlier with one of the Sign* methods.
+// The nonce stays reserved when sending fails, so the same transaction can be retried.
+func (cs *ContractService) SendSigned(tx *types.Transaction) error {
+	return cs.client.GetClient().SendTransaction(context.Background(), tx)
+}
+
+// DiscardSigned gives the nonce of a signed transaction back once it will
+// never be sent again
+func (cs *ContractService) DiscardSigned(tx *types.Transaction, sendErr error) {
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		log.Printf("[ERROR] Failed to recover sender of %s: %v", tx.Hash().Hex(), err)
+		return
+	}
+	cs.client.Nonces().SendFailed(context.Background(), cs.client.GetClient(), from, tx.Nonce(), sendErr)
+}
+
+// ResyncSigned reloads the nonce of the sender of a signed transaction from the
+// chain, once the transaction is given up after it may have been broadcast
+func (cs *ContractService) ResyncSigned(tx *types.Transaction) {
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		log.Printf("[ERROR] Failed to recover sender of %s: %v", tx.Hash().Hex(), err)
+		return
+	}
+	if err := cs.client.Nonces().Resync(context.Background(), cs.client.GetClient(), from); err != nil {
+		log.Printf("[ERROR] Failed to resync nonce for %s: %v", from.Hex(), err)
+	}
+}
+
+// estimateOpts builds transact options that only estimate gas for from: the
+// transaction is never signed with a real key nor sent
+func (cs *ContractService) estimateOpts(from common.Address) *bind.TransactOpts {
+	return &bind.TransactOpts{
+		From:    from,
+		NoSend:  true,
+		Context: context.Background(),
+		Signer: func(_ common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			return tx, nil
+		},
+	}
+}
+
+// estimateFee estimates the network fee of a typed contract call sent by from
+func (cs *ContractService) estimateFee(from common.Address, call transactFunc) (*FeeEstimate, error) {
+	tx, err := call(cs.estimateOpts(from))
+	if err != nil {
+		return nil, err
+	}
+	return cs.client.FeeForGas(context.Background(), tx.Gas())
+}
+
+// EstimateTransferFee estimates the fee of an ERC20 transfer
+func (cs *ContractService) EstimateTransferFee(from, to common.Address, amount *big.Int) (*FeeEstimate, error) {
+	return cs.estimateFee(from, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return cs.token.Transfer(opts, to, amount)
+	})
+}
+
+// EstimatePaymentTransferFee estimates the fee of a transfer with a note
+func (cs *ContractService) EstimatePaymentTransferFee(from, to common.Address, amount *big.Int, note string) (*FeeEstimate, error) {
+	return cs.estimateFee(from, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return cs.token.PaymentTransfer(opts, to, amount, note)
+	})
+}
+
+// EstimateTopupFee estimates the fee of an instant top-up
+func (cs *ContractService) EstimateTopupFee(from common.Address, amount *big.Int, paymentProof string) (*FeeEstimate, error) {
+	return cs.estimateFee(from, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return cs.token.InstantTopup(opts, amount, paymentProof)
+	})
+}
+
+// EstimateWithdrawFee estimates the fee of a withdraw request
+func (cs *ContractService) EstimateWithdrawFee(from common.Address, amount *big.Int, bankAccount string) (*FeeEstimate, error) {
+	return cs.estimateFee(from, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return cs.token.RequestWithdraw(opts, amount, bankAccount)
+	})
+}
+
 func (cs *ContractService) InstantTopup(userPrivateKey string, amount *big.Int, paymentProof string) (string, error) {
 	// Ganti cs.client.GetTransactor() dengan helper baru kita
 	auth, err := cs.createManualTransactor(userPrivateKey)
@@ -114,33 +235,111 @@ func (cs *ContractService) InstantTopup(userPrivateKey string, amount *big.Int,
 		return "", err
 	}
 
-	tx, err := cs.contract.Transact(auth, "instantTopup", amount, paymentProof)
+	tx, err := cs.transact(auth, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return cs.token.InstantTopup(opts, amount, paymentProof)
+	})
 	if err != nil {
 		return "", err
 	}
 	return tx.Hash().Hex(), nil
 }
 
+// SignInstantTopup signs an instant top-up without sending it
+func (cs *ContractService) SignInstantTopup(userPrivateKey string, amount *big.Int, paymentProof string) (*types.Transaction, error) {
+	auth, err := cs.createManualTransactor(userPrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return cs.sign(auth, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return cs.token.InstantTopup(opts, amount, paymentProof)
+	})
+}
+
 // ProcessTopup processes a pending top-up request
 func (cs *ContractService) ProcessTopup(requestId [32]byte) (string, error) {
-	// Untuk fungsi admin, kita pakai private key dari environment variable
-	adminPrivateKey := os.Getenv("ADMIN_PRIVATE_KEY")
-	if adminPrivateKey == "" {
+	// Untuk fungsi admin, kita pakai private key admin dari Web3Client (ADMIN_PRIVATE_KEY)
+	if cs.client.privateKey == nil {
 		return "", errors.New("ADMIN_PRIVATE_KEY environment variable not set")
 	}
 
-	auth, err := cs.createManualTransactor(adminPrivateKey)
+	auth, err := cs.createManualTransactor(cs.client.adminPrivateKeyHex())
 	if err != nil {
 		return "", err
 	}
 
-	tx, err := cs.contract.Transact(auth, "processTopup", requestId)
+	tx, err := cs.transact(auth, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return cs.token.ProcessTopup(opts, requestId)
+	})
 	if err != nil {
 		return "", err
 	}
 	return tx.Hash().Hex(), nil
 }
 
+// SignRequestTopup signs a delayed top-up request without sending it; the
+// tokens are minted by processTopup once the contract's waiting period is over
+func (cs *ContractService) SignRequestTopup(userPrivateKey string, amount *big.Int, paymentProof string) (*types.Transaction, error) {
+	auth, err := cs.createManualTransactor(userPrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return cs.sign(auth, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return cs.token.RequestTopup(opts, amount, paymentProof)
+	})
+}
+
+// SignProcessTopup signs processTopup for a pending top-up request with the
+// admin key, without sending it
+func (cs *ContractService) SignProcessTopup(requestId [32]byte) (*types.Transaction, error) {
+	if cs.client.privateKey == nil {
+		return nil, errors.New("ADMIN_PRIVATE_KEY environment variable not set")
+	}
+
+	auth, err := cs.createManualTransactor(cs.client.adminPrivateKeyHex())
+	if err != nil {
+		return nil, err
+	}
+
+	return cs.sign(auth, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return cs.token.ProcessTopup(opts, requestId)
+	})
+}
+
+// GetTopupRequestID reads the request ID from the TopupRequested event of a mined requestTopup
+func (cs *ContractService) GetTopupRequestID(txHash string) ([32]byte, error) {
+	receipt, err := cs.GetTransactionReceipt(txHash)
+	if err != nil {
+		return [32]byte{}, err
+	}
+
+	for _, vLog := range receipt.Logs {
+		if vLog.Address != cs.client.GetContractAddress() {
+			continue
+		}
+		if event, err := cs.token.ParseTopupRequested(*vLog); err == nil {
+			return event.RequestId, nil
+		}
+	}
+	return [32]byte{}, errors.New("no TopupRequested event in transaction " + txHash)
+}
+
+// FindTopupMint returns the hash of the transaction that minted a top-up
+// request (its TokensMinted event), searching from fromBlock; "" when there is none
+func (cs *ContractService) FindTopupMint(requestId [32]byte, fromBlock uint64) (string, error) {
+	iter, err := cs.token.FilterTokensMinted(&bind.FilterOpts{Start: fromBlock, Context: context.Background()}, nil, [][32]byte{requestId})
+	if err != nil {
+		return "", err
+	}
+	defer iter.Close()
+
+	if iter.Next() {
+		return iter.Event.Raw.TxHash.Hex(), nil
+	}
+	return "", iter.Error()
+}
+
 // RequestWithdraw creates a withdraw request and burns tokens
 func (cs *ContractService) RequestWithdraw(userPrivateKey string, amount *big.Int, bankAccount string) (string, error) {
 	auth, err := cs.createManualTransactor(userPrivateKey)
@@ -148,13 +347,27 @@ func (cs *ContractService) RequestWithdraw(userPrivateKey string, amount *big.In
 		return "", err
 	}
 
-	tx, err := cs.contract.Transact(auth, "requestWithdraw", amount, bankAccount)
+	tx, err := cs.transact(auth, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return cs.token.RequestWithdraw(opts, amount, bankAccount)
+	})
 	if err != nil {
 		return "", err
 	}
 	return tx.Hash().Hex(), nil
 }
 
+// SignRequestWithdraw signs a withdraw request (token burn) without sending it
+func (cs *ContractService) SignRequestWithdraw(userPrivateKey string, amount *big.Int, bankAccount string) (*types.Transaction, error) {
+	auth, err := cs.createManualTransactor(userPrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return cs.sign(auth, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return cs.token.RequestWithdraw(opts, amount, bankAccount)
+	})
+}
+
 // PaymentTransfer transfers tokens with a note
 func (cs *ContractService) PaymentTransfer(userPrivateKey string, to common.Address, amount *big.Int, note string) (string, error) {
 	auth, err := cs.createManualTransactor(userPrivateKey)
@@ -162,7 +375,9 @@ func (cs *ContractService) PaymentTransfer(userPrivateKey string, to common.Addr
 		return "", err
 	}
 
-	tx, err := cs.contract.Transact(auth, "paymentTransfer", to, amount, note)
+	tx, err := cs.transact(auth, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return cs.token.PaymentTransfer(opts, to, amount, note)
+	})
 	if err != nil {
 		return "", err
 	}
@@ -177,7 +392,9 @@ func (cs *ContractService) Transfer(userPrivateKey string, to common.Address, am
 		return "", err
 	}
 
-	tx, err := cs.contract.Transact(auth, "transfer", to, amount)
+	tx, err := cs.transact(auth, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return cs.token.Transfer(opts, to, amount)
+	})
 	if err != nil {
 		return "", err
 	}
@@ -186,57 +403,50 @@ func (cs *ContractService) Transfer(userPrivateKey string, to common.Address, am
 
 // GetBalance gets token balance for an address
 func (cs *ContractService) GetBalance(address common.Address) (*big.Int, error) {
-	var result []interface{}
-	err := cs.contract.Call(cs.client.GetCallOpts(), &result, "balanceOf", address)
-	if err != nil {
-		return nil, err
-	}
-	return result[0].(*big.Int), nil
+	return cs.token.BalanceOf(cs.client.GetCallOpts(), address)
+}
+
+// GetBalanceAt gets token balance for an address at a given block
+func (cs *ContractService) GetBalanceAt(address common.Address, blockNumber *big.Int) (*big.Int, error) {
+	return cs.token.BalanceOf(&bind.CallOpts{Context: context.Background(), BlockNumber: blockNumber}, address)
 }
 
 // GetTotalSupply gets total token supply
 func (cs *ContractService) GetTotalSupply() (*big.Int, error) {
-	var result []interface{}
-	err := cs.contract.Call(cs.client.GetCallOpts(), &result, "totalSupply")
-	if err != nil {
-		return nil, err
-	}
-	return result[0].(*big.Int), nil
+	return cs.token.TotalSupply(cs.client.GetCallOpts())
 }
 
 // ProcessTopup processes a pending top-up request
 
 // GetTopupRequest gets details of a top-up request
 func (cs *ContractService) GetTopupRequest(requestId [32]byte) (*TopupRequest, error) {
-	var result []interface{}
-	err := cs.contract.Call(cs.client.GetCallOpts(), &result, "getTopupRequest", requestId)
+	result, err := cs.token.GetTopupRequest(cs.client.GetCallOpts(), requestId)
 	if err != nil {
 		return nil, err
 	}
 
 	return &TopupRequest{
-		User:         result[0].(common.Address),
-		Amount:       result[1].(*big.Int),
-		PaymentProof: result[2].(string),
-		Timestamp:    result[3].(*big.Int),
-		Processed:    result[4].(bool),
+		User:         result.User,
+		Amount:       result.Amount,
+		PaymentProof: result.PaymentProof,
+		Timestamp:    result.Timestamp,
+		Processed:    result.Processed,
 	}, nil
 }
 
 // GetWithdrawRequest gets details of a withdraw request
 func (cs *ContractService) GetWithdrawRequest(requestId [32]byte) (*WithdrawRequest, error) {
-	var result []interface{}
-	err := cs.contract.Call(cs.client.GetCallOpts(), &result, "getWithdrawRequest", requestId)
+	result, err := cs.token.GetWithdrawRequest(cs.client.GetCallOpts(), requestId)
 	if err != nil {
 		return nil, err
 	}
 
 	return &WithdrawRequest{
-		User:        result[0].(common.Address),
-		Amount:      result[1].(*big.Int),
-		BankAccount: result[2].(string),
-		Timestamp:   result[3].(*big.Int),
-		Processed:   result[4].(bool),
+		User:        result.User,
+		Amount:      result.Amount,
+		BankAccount: result.BankAccount,
+		Timestamp:   result.Timestamp,
+		Processed:   result.Processed,
 	}, nil
 }
 
@@ -248,32 +458,17 @@ func (cs *ContractService) GetTransactionReceipt(txHash string) (*types.Receipt,
 
 // GetMinMintAmount gets minimum mint amount
 func (cs *ContractService) GetMinMintAmount() (*big.Int, error) {
-	var result []interface{}
-	err := cs.contract.Call(cs.client.GetCallOpts(), &result, "minMintAmount")
-	if err != nil {
-		return nil, err
-	}
-	return result[0].(*big.Int), nil
+	return cs.token.MinMintAmount(cs.client.GetCallOpts())
 }
 
 // GetMinBurnAmount gets minimum burn amount
 func (cs *ContractService) GetMinBurnAmount() (*big.Int, error) {
-	var result []interface{}
-	err := cs.contract.Call(cs.client.GetCallOpts(), &result, "minBurnAmount")
-	if err != nil {
-		return nil, err
-	}
-	return result[0].(*big.Int), nil
+	return cs.token.MinBurnAmount(cs.client.GetCallOpts())
 }
 
 // GetExchangeRate gets current exchange rate
 func (cs *ContractService) GetExchangeRate() (*big.Int, error) {
-	var result []interface{}
-	err := cs.contract.Call(cs.client.GetCallOpts(), &result, "exchangeRate")
-	if err != nil {
-		return nil, err
-	}
-	return result[0].(*big.Int), nil
+	return cs.token.ExchangeRate(cs.client.GetCallOpts())
 }
 
 func (cs *ContractService) WaitForReceipt(txHash string, timeoutSeconds int) (*types.Receipt, error) {
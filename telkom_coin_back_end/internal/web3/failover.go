@@ -0,0 +1,753 @@
+package web3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"telkom_coin_back_end/config"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Connectivity states reported by ChainStatus
+const (
+	ChainStatusHealthy  = "healthy"  // every endpoint is up
+	ChainStatusDegraded = "degraded" // some endpoints are down, calls still succeed
+	ChainStatusDown     = "down"     // no endpoint is reachable
+)
+
+var (
+	failoverMu      sync.Mutex
+	failoverClients = make(map[string]*FailoverClient)
+)
+
+// FailoverClient is a Backend over several RPC endpoints of the same chain.
+// Reads are retried with backoff and move to the next endpoint when a node
+// fails; a background health check keeps the endpoint state up to date.
+type FailoverClient struct {
+	network   config.Network
+	chainID   *big.Int
+	endpoints []*rpcEndpoint
+
+	maxRetries     int
+	retryBackoff   time.Duration
+	requestTimeout time.Duration
+	healthInterval time.Duration
+	maxBlockLag    uint64
+
+	mu      sync.Mutex
+	current int // index of the preferred endpoint
+}
+
+// rpcEndpoint is one node of a FailoverClient
+type rpcEndpoint struct {
+	url    string
+	client *ethclient.Client
+
+	mu                  sync.Mutex
+	healthy             bool
+	wrongChain          bool // never used, even as a last resort
+	latestBlock         uint64
+	latency             time.Duration
+	lastError           string
+	lastChecked         time.Time
+	consecutiveFailures int
+}
+
+// EndpointStatus is the reported state of one RPC endpoint
+type EndpointStatus struct {
+	URL                 string     `json:"url"`
+	Active              bool       `json:"active"`
+	Healthy             bool       `json:"healthy"`
+	LatestBlock         uint64     `json:"latest_block"`
+	LatencyMs           int64      `json:"latency_ms"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	LastError           string     `json:"last_error,omitempty"`
+	LastChecked         *time.Time `json:"last_checked,omitempty"`
+}
+
+// ChainStatus is the connectivity of a network, as shown by /health and /explorer/stats
+type ChainStatus struct {
+	Network          string           `json:"network"`
+	ChainID          int64            `json:"chain_id"`
+	Status           string           `json:"status"`
+	HealthyEndpoints int              `json:"healthy_endpoints"`
+	TotalEndpoints   int              `json:"total_endpoints"`
+	Endpoints        []EndpointStatus `json:"endpoints"`
+}
+
+// getFailoverClient returns the client of a network, dialing it on first use.
+// Every Web3Client of a network shares it, so there is one health check loop.
+func getFailoverClient(network config.Network) (*FailoverClient, error) {
+	failoverMu.Lock()
+	defer failoverMu.Unlock()
+
+	if client, ok := failoverClients[network.Key]; ok {
+		return client, nil
+	}
+
+	client, err := newFailoverClient(network)
+	if err != nil {
+		return nil, err
+	}
+	failoverClients[network.Key] = client
+	client.checkAll()
+	go client.healthLoop()
+
+	return client, nil
+}
+
+func newFailoverClient(network config.Network) (*FailoverClient, error) {
+	if len(network.RPCURLs) == 0 {
+		return nil, fmt.Errorf("network %s has no RPC URL", network.Key)
+	}
+
+	client := &FailoverClient{
+		network:        network,
+		chainID:        big.NewInt(network.ChainID),
+		maxRetries:     config.AppConfig.RPCMaxRetries,
+		retryBackoff:   config.AppConfig.RPCRetryBackoff,
+		requestTimeout: config.AppConfig.RPCRequestTimeout,
+		healthInterval: config.AppConfig.RPCHealthInterval,
+		maxBlockLag:    config.AppConfig.RPCMaxBlockLag,
+	}
+
+	for _, rawURL := range network.RPCURLs {
+		// Dial over HTTP does not connect yet, an unreachable node shows up in the health check
+		ethClient, err := ethclient.Dial(rawURL)
+		if err != nil {
+			log.Printf("[WARN] Skipping RPC endpoint %s: %v", redactURL(rawURL), err)
+			continue
+		}
+		client.endpoints = append(client.endpoints, &rpcEndpoint{url: rawURL, client: ethClient})
+	}
+	if len(client.endpoints) == 0 {
+		return nil, fmt.Errorf("no usable RPC endpoint for network %s", network.Key)
+	}
+
+	return client, nil
+}
+
+// healthLoop re-checks every endpoint for the lifetime of the process
+func (f *FailoverClient) healthLoop() {
+	ticker := time.NewTicker(f.healthInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		f.checkAll()
+	}
+}
+
+// checkAll probes every endpoint and marks the ones that are down, on another
+// chain or too far behind the best block as unhealthy
+func (f *FailoverClient) checkAll() {
+	var wg sync.WaitGroup
+	for _, endpoint := range f.endpoints {
+		wg.Add(1)
+		go func(endpoint *rpcEndpoint) {
+			defer wg.Done()
+			f.checkEndpoint(endpoint)
+		}(endpoint)
+	}
+	wg.Wait()
+
+	var bestBlock uint64
+	for _, endpoint := range f.endpoints {
+		endpoint.mu.Lock()
+		if endpoint.healthy && endpoint.latestBlock > bestBlock {
+			bestBlock = endpoint.latestBlock
+		}
+		endpoint.mu.Unlock()
+	}
+
+	for _, endpoint := range f.endpoints {
+		endpoint.mu.Lock()
+		if endpoint.healthy && endpoint.latestBlock+f.maxBlockLag < bestBlock {
+			endpoint.healthy = false
+			endpoint.lastError = fmt.Sprintf("lagging %d blocks behind", bestBlock-endpoint.latestBlock)
+		}
+		endpoint.mu.Unlock()
+	}
+
+	f.selectEndpoint()
+}
+
+func (f *FailoverClient) checkEndpoint(endpoint *rpcEndpoint) {
+	ctx, cancel := context.WithTimeout(context.Background(), f.requestTimeout)
+	defer cancel()
+
+	started := time.Now()
+	chainID, err := endpoint.client.ChainID(ctx)
+	var block uint64
+	if err == nil {
+		block, err = endpoint.client.BlockNumber(ctx)
+	}
+	latency := time.Since(started)
+
+	endpoint.mu.Lock()
+	defer endpoint.mu.Unlock()
+
+	firstCheck := endpoint.lastChecked.IsZero()
+	wasHealthy := endpoint.healthy
+	endpoint.lastChecked = time.Now()
+	endpoint.latency = latency
+
+	switch {
+	case err != nil:
+		endpoint.healthy = false
+		endpoint.lastError = endpoint.redactError(err)
+		endpoint.consecutiveFailures++
+	case f.network.ChainID != 0 && chainID.Int64() != f.network.ChainID:
+		// Jangan pernah kirim transaksi ke chain lain
+		endpoint.healthy = false
+		endpoint.wrongChain = true
+		endpoint.lastError = fmt.Sprintf("chain ID %s does not match %d", chainID.String(), f.network.ChainID)
+		endpoint.consecutiveFailures++
+	default:
+		endpoint.healthy = true
+		endpoint.wrongChain = false
+		endpoint.latestBlock = block
+		endpoint.lastError = ""
+		endpoint.consecutiveFailures = 0
+	}
+
+	if (firstCheck || wasHealthy) && !endpoint.healthy {
+		log.Printf("[WARN] RPC endpoint %s is down: %s", redactURL(endpoint.url), endpoint.lastError)
+	} else if !firstCheck && !wasHealthy && endpoint.healthy {
+		log.Printf("✅ RPC endpoint %s is back up (block %d)", redactURL(endpoint.url), block)
+	}
+}
+
+// selectEndpoint keeps the current endpoint while it is healthy, otherwise
+// moves to the first healthy one in configured order
+func (f *FailoverClient) selectEndpoint() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.endpoints[f.current].isHealthy() {
+		return
+	}
+	for i, endpoint := range f.endpoints {
+		if endpoint.isHealthy() {
+			log.Printf("🔀 Failing over RPC from %s to %s", redactURL(f.endpoints[f.current].url), redactURL(endpoint.url))
+			f.current = i
+			return
+		}
+	}
+}
+
+func (e *rpcEndpoint) isHealthy() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.healthy
+}
+
+// markFailed records a failed call so the next call starts on another endpoint
+func (f *FailoverClient) markFailed(endpoint *rpcEndpoint, err error) {
+	endpoint.mu.Lock()
+	endpoint.healthy = false
+	endpoint.lastError = endpoint.redactError(err)
+	endpoint.consecutiveFailures++
+	endpoint.mu.Unlock()
+
+	f.selectEndpoint()
+}
+
+// candidates returns the endpoints in the order calls should try them:
+// the current one, the other healthy ones, then the unhealthy ones.
+// Endpoints on another chain are left out.
+func (f *FailoverClient) candidates() []*rpcEndpoint {
+	f.mu.Lock()
+	current := f.current
+	f.mu.Unlock()
+
+	var healthy, unhealthy []*rpcEndpoint
+	for i, endpoint := range f.endpoints {
+		endpoint.mu.Lock()
+		isHealthy, wrongChain := endpoint.healthy, endpoint.wrongChain
+		endpoint.mu.Unlock()
+
+		switch {
+		case wrongChain:
+			continue
+		case i == current:
+			healthy = append([]*rpcEndpoint{endpoint}, healthy...)
+		case isHealthy:
+			healthy = append(healthy, endpoint)
+		default:
+			unhealthy = append(unhealthy, endpoint)
+		}
+	}
+	return append(healthy, unhealthy...)
+}
+
+// read runs an idempotent call, retrying transport errors with exponential
+// backoff and moving to the next endpoint after every failure
+func (f *FailoverClient) read(ctx context.Context, method string, call func(ctx context.Context, client *ethclient.Client) error) error {
+	endpoints := f.candidates()
+	if len(endpoints) == 0 {
+		return fmt.Errorf("%s: no RPC endpoint on chain %d", method, f.network.ChainID)
+	}
+	backoff := f.retryBackoff
+
+	var lastErr error
+	for attempt := 0; attempt <= f.maxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+
+		endpoint := endpoints[attempt%len(endpoints)]
+		attemptCtx, cancel := context.WithTimeout(ctx, f.requestTimeout)
+		err := call(attemptCtx, endpoint.client)
+		cancel()
+
+		if err == nil || !isRetryableRPCError(ctx, err) {
+			return err
+		}
+
+		lastErr = errors.New(endpoint.redactError(err))
+		f.markFailed(endpoint, err)
+		log.Printf("[WARN] RPC %s failed on %s (attempt %d/%d): %s", method, redactURL(endpoint.url), attempt+1, f.maxRetries+1, endpoint.redactError(err))
+	}
+
+	return fmt.Errorf("%s failed on every RPC endpoint: %v", method, lastErr)
+}
+
+// isRetryableRPCError reports whether err is a transport problem worth retrying
+// on another node, as opposed to an answer from the node (revert, not found)
+func isRetryableRPCError(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if errors.Is(err, ethereum.NotFound) {
+		return false
+	}
+
+	var httpErr rpc.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode == 429 || httpErr.StatusCode >= 500
+	}
+
+	var rpcErr rpc.Error
+	return !errors.As(err, &rpcErr)
+}
+
+// redactURL keeps only scheme and host, RPC URLs often carry an API key
+func redactURL(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Host == "" {
+		return "invalid-url"
+	}
+	return parsed.Scheme + "://" + parsed.Host
+}
+
+// redactError removes the full endpoint URL from a transport error message
+func (e *rpcEndpoint) redactError(err error) string {
+	return strings.ReplaceAll(err.Error(), e.url, redactURL(e.url))
+}
+
+// Status returns the connectivity of the network and of every endpoint
+func (f *FailoverClient) Status() ChainStatus {
+	f.mu.Lock()
+	current := f.current
+	f.mu.Unlock()
+
+	status := ChainStatus{
+		Network:        f.network.Key,
+		ChainID:        f.network.ChainID,
+		TotalEndpoints: len(f.endpoints),
+	}
+
+	for i, endpoint := range f.endpoints {
+		endpoint.mu.Lock()
+		endpointStatus := EndpointStatus{
+			URL:                 redactURL(endpoint.url),
+			Active:              i == current,
+			Healthy:             endpoint.healthy,
+			LatestBlock:         endpoint.latestBlock,
+			LatencyMs:           endpoint.latency.Milliseconds(),
+			ConsecutiveFailures: endpoint.consecutiveFailures,
+			LastError:           endpoint.lastError,
+		}
+		if !endpoint.lastChecked.IsZero() {
+			lastChecked := endpoint.lastChecked
+			endpointStatus.LastChecked = &lastChecked
+		}
+		endpoint.mu.Unlock()
+
+		if endpointStatus.Healthy {
+			status.HealthyEndpoints++
+		}
+		status.Endpoints = append(status.Endpoints, endpointStatus)
+	}
+
+	switch {
+	case status.HealthyEndpoints == 0:
+		status.Status = ChainStatusDown
+	case status.HealthyEndpoints < status.TotalEndpoints:
+		status.Status = ChainStatusDegraded
+	default:
+		status.Status = ChainStatusHealthy
+	}
+	return status
+}
+
+// Close closes every endpoint connection
+func (f *FailoverClient) Close() {
+	for _, endpoint := range f.endpoints {
+		endpoint.client.Close()
+	}
+}
+
+// SendTransaction broadcasts a signed transaction. Unlike reads it is not
+// retried blindly: a transport error may hide a send that arrived, so the next
+// endpoint first checks whether it knows the transaction and is only sent to
+// when it does not. "already known", or "nonce too low" from a node that has
+// this very transaction, counts as success; any other answer is returned.
+func (f *FailoverClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	endpoints := f.candidates()
+	if len(endpoints) == 0 {
+		return fmt.Errorf("SendTransaction: no RPC endpoint on chain %d", f.network.ChainID)
+	}
+	if len(endpoints) > f.maxRetries+1 {
+		endpoints = endpoints[:f.maxRetries+1]
+	}
+
+	var lastErr error
+	for i, endpoint := range endpoints {
+		if lastErr != nil && f.knowsTransaction(ctx, endpoint, tx.Hash()) {
+			return nil
+		}
+
+		attemptCtx, cancel := context.WithTimeout(ctx, f.requestTimeout)
+		err := endpoint.client.SendTransaction(attemptCtx, tx)
+		cancel()
+
+		switch {
+		case err == nil:
+			return nil
+		case strings.Contains(strings.ToLower(err.Error()), "already known"):
+			return nil
+		case isRetryableRPCError(ctx, err):
+			lastErr = errors.New(endpoint.redactError(err))
+			f.markFailed(endpoint, err)
+			log.Printf("[WARN] RPC SendTransaction of %s failed on %s (endpoint %d/%d): %s", tx.Hash().Hex(), redactURL(endpoint.url), i+1, len(endpoints), endpoint.redactError(err))
+		case strings.Contains(strings.ToLower(err.Error()), "nonce too low") && f.knowsTransaction(ctx, endpoint, tx.Hash()):
+			// Our own earlier send of the same transaction was mined or is pooled
+			return nil
+		default:
+			return err
+		}
+	}
+
+	return fmt.Errorf("SendTransaction failed on every RPC endpoint: %v", lastErr)
+}
+
+// knowsTransaction reports whether an endpoint has the transaction, pooled or mined
+func (f *FailoverClient) knowsTransaction(ctx context.Context, endpoint *rpcEndpoint, hash common.Hash) bool {
+	attemptCtx, cancel := context.WithTimeout(ctx, f.requestTimeout)
+	defer cancel()
+
+	_, _, err := endpoint.client.TransactionByHash(attemptCtx, hash)
+	return err == nil
+}
+
+// ChainID returns the chain ID from the registry; endpoints on another chain are never used
+func (f *FailoverClient) ChainID(ctx context.Context) (*big.Int, error) {
+	if f.network.ChainID != 0 {
+		return new(big.Int).Set(f.chainID), nil
+	}
+
+	var chainID *big.Int
+	err := f.read(ctx, "ChainID", func(ctx context.Context, client *ethclient.Client) error {
+		var err error
+		chainID, err = client.ChainID(ctx)
+		return err
+	})
+	return chainID, err
+}
+
+func (f *FailoverClient) BlockNumber(ctx context.Context) (uint64, error) {
+	var number uint64
+	err := f.read(ctx, "BlockNumber", func(ctx context.Context, client *ethclient.Client) error {
+		var err error
+		number, err = client.BlockNumber(ctx)
+		return err
+	})
+	return number, err
+}
+
+func (f *FailoverClient) BlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error) {
+	var block *types.Block
+	err := f.read(ctx, "BlockByHash", func(ctx context.Context, client *ethclient.Client) error {
+		var err error
+		block, err = client.BlockByHash(ctx, hash)
+		return err
+	})
+	return block, err
+}
+
+func (f *FailoverClient) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
+	var block *types.Block
+	err := f.read(ctx, "BlockByNumber", func(ctx context.Context, client *ethclient.Client) error {
+		var err error
+		block, err = client.BlockByNumber(ctx, number)
+		return err
+	})
+	return block, err
+}
+
+func (f *FailoverClient) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	var header *types.Header
+	err := f.read(ctx, "HeaderByHash", func(ctx context.Context, client *ethclient.Client) error {
+		var err error
+		header, err = client.HeaderByHash(ctx, hash)
+		return err
+	})
+	return header, err
+}
+
+func (f *FailoverClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	var header *types.Header
+	err := f.read(ctx, "HeaderByNumber", func(ctx context.Context, client *ethclient.Client) error {
+		var err error
+		header, err = client.HeaderByNumber(ctx, number)
+		return err
+	})
+	return header, err
+}
+
+func (f *FailoverClient) TransactionCount(ctx context.Context, blockHash common.Hash) (uint, error) {
+	var count uint
+	err := f.read(ctx, "TransactionCount", func(ctx context.Context, client *ethclient.Client) error {
+		var err error
+		count, err = client.TransactionCount(ctx, blockHash)
+		return err
+	})
+	return count, err
+}
+
+func (f *FailoverClient) TransactionInBlock(ctx context.Context, blockHash common.Hash, index uint) (*types.Transaction, error) {
+	var tx *types.Transaction
+	err := f.read(ctx, "TransactionInBlock", func(ctx context.Context, client *ethclient.Client) error {
+		var err error
+		tx, err = client.TransactionInBlock(ctx, blockHash, index)
+		return err
+	})
+	return tx, err
+}
+
+// SubscribeNewHead subscribes on the current endpoint; subscriptions are not retried
+func (f *FailoverClient) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	endpoints := f.candidates()
+	if len(endpoints) == 0 {
+		return nil, fmt.Errorf("SubscribeNewHead: no RPC endpoint on chain %d", f.network.ChainID)
+	}
+	return endpoints[0].client.SubscribeNewHead(ctx, ch)
+}
+
+func (f *FailoverClient) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	var balance *big.Int
+	err := f.read(ctx, "BalanceAt", func(ctx context.Context, client *ethclient.Client) error {
+		var err error
+		balance, err = client.BalanceAt(ctx, account, blockNumber)
+		return err
+	})
+	return balance, err
+}
+
+func (f *FailoverClient) StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error) {
+	var storage []byte
+	err := f.read(ctx, "StorageAt", func(ctx context.Context, client *ethclient.Client) error {
+		var err error
+		storage, err = client.StorageAt(ctx, account, key, blockNumber)
+		return err
+	})
+	return storage, err
+}
+
+func (f *FailoverClient) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	var code []byte
+	err := f.read(ctx, "CodeAt", func(ctx context.Context, client *ethclient.Client) error {
+		var err error
+		code, err = client.CodeAt(ctx, account, blockNumber)
+		return err
+	})
+	return code, err
+}
+
+func (f *FailoverClient) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	var nonce uint64
+	err := f.read(ctx, "NonceAt", func(ctx context.Context, client *ethclient.Client) error {
+		var err error
+		nonce, err = client.NonceAt(ctx, account, blockNumber)
+		return err
+	})
+	return nonce, err
+}
+
+func (f *FailoverClient) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	var result []byte
+	err := f.read(ctx, "CallContract", func(ctx context.Context, client *ethclient.Client) error {
+		var err error
+		result, err = client.CallContract(ctx, call, blockNumber)
+		return err
+	})
+	return result, err
+}
+
+func (f *FailoverClient) EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+	var gas uint64
+	err := f.read(ctx, "EstimateGas", func(ctx context.Context, client *ethclient.Client) error {
+		var err error
+		gas, err = client.EstimateGas(ctx, call)
+		return err
+	})
+	return gas, err
+}
+
+func (f *FailoverClient) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	var price *big.Int
+	err := f.read(ctx, "SuggestGasPrice", func(ctx context.Context, client *ethclient.Client) error {
+		var err error
+		price, err = client.SuggestGasPrice(ctx)
+		return err
+	})
+	return price, err
+}
+
+func (f *FailoverClient) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	var tip *big.Int
+	err := f.read(ctx, "SuggestGasTipCap", func(ctx context.Context, client *ethclient.Client) error {
+		var err error
+		tip, err = client.SuggestGasTipCap(ctx)
+		return err
+	})
+	return tip, err
+}
+
+func (f *FailoverClient) FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error) {
+	var history *ethereum.FeeHistory
+	err := f.read(ctx, "FeeHistory", func(ctx context.Context, client *ethclient.Client) error {
+		var err error
+		history, err = client.FeeHistory(ctx, blockCount, lastBlock, rewardPercentiles)
+		return err
+	})
+	return history, err
+}
+
+func (f *FailoverClient) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	var logs []types.Log
+	err := f.read(ctx, "FilterLogs", func(ctx context.Context, client *ethclient.Client) error {
+		var err error
+		logs, err = client.FilterLogs(ctx, q)
+		return err
+	})
+	return logs, err
+}
+
+// SubscribeFilterLogs subscribes on the current endpoint; subscriptions are not retried
+func (f *FailoverClient) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	endpoints := f.candidates()
+	if len(endpoints) == 0 {
+		return nil, fmt.Errorf("SubscribeFilterLogs: no RPC endpoint on chain %d", f.network.ChainID)
+	}
+	return endpoints[0].client.SubscribeFilterLogs(ctx, q, ch)
+}
+
+func (f *FailoverClient) PendingBalanceAt(ctx context.Context, account common.Address) (*big.Int, error) {
+	var balance *big.Int
+	err := f.read(ctx, "PendingBalanceAt", func(ctx context.Context, client *ethclient.Client) error {
+		var err error
+		balance, err = client.PendingBalanceAt(ctx, account)
+		return err
+	})
+	return balance, err
+}
+
+func (f *FailoverClient) PendingStorageAt(ctx context.Context, account common.Address, key common.Hash) ([]byte, error) {
+	var storage []byte
+	err := f.read(ctx, "PendingStorageAt", func(ctx context.Context, client *ethclient.Client) error {
+		var err error
+		storage, err = client.PendingStorageAt(ctx, account, key)
+		return err
+	})
+	return storage, err
+}
+
+func (f *FailoverClient) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	var code []byte
+	err := f.read(ctx, "PendingCodeAt", func(ctx context.Context, client *ethclient.Client) error {
+		var err error
+		code, err = client.PendingCodeAt(ctx, account)
+		return err
+	})
+	return code, err
+}
+
+func (f *FailoverClient) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	var nonce uint64
+	err := f.read(ctx, "PendingNonceAt", func(ctx context.Context, client *ethclient.Client) error {
+		var err error
+		nonce, err = client.PendingNonceAt(ctx, account)
+		return err
+	})
+	return nonce, err
+}
+
+func (f *FailoverClient) PendingTransactionCount(ctx context.Context) (uint, error) {
+	var count uint
+	err := f.read(ctx, "PendingTransactionCount", func(ctx context.Context, client *ethclient.Client) error {
+		var err error
+		count, err = client.PendingTransactionCount(ctx)
+		return err
+	})
+	return count, err
+}
+
+func (f *FailoverClient) PendingCallContract(ctx context.Context, call ethereum.CallMsg) ([]byte, error) {
+	var result []byte
+	err := f.read(ctx, "PendingCallContract", func(ctx context.Context, client *ethclient.Client) error {
+		var err error
+		result, err = client.PendingCallContract(ctx, call)
+		return err
+	})
+	return result, err
+}
+
+func (f *FailoverClient) TransactionByHash(ctx context.Context, txHash common.Hash) (*types.Transaction, bool, error) {
+	var tx *types.Transaction
+	var isPending bool
+	err := f.read(ctx, "TransactionByHash", func(ctx context.Context, client *ethclient.Client) error {
+		var err error
+		tx, isPending, err = client.TransactionByHash(ctx, txHash)
+		return err
+	})
+	return tx, isPending, err
+}
+
+func (f *FailoverClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	var receipt *types.Receipt
+	err := f.read(ctx, "TransactionReceipt", func(ctx context.Context, client *ethclient.Client) error {
+		var err error
+		receipt, err = client.TransactionReceipt(ctx, txHash)
+		return err
+	})
+	return receipt, err
+}
@@ -0,0 +1,133 @@
+package web3
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"telkom_coin_back_end/config"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// feeConfig holds the configurable limits for transaction fees
+type feeConfig struct {
+	maxFeePerGas   *big.Int // GAS_FEE_CAP_GWEI, never pay more than this per gas
+	maxTipPerGas   *big.Int // GAS_TIP_CAP_GWEI, upper bound for the priority fee
+	gasLimitMargin uint64   // GAS_LIMIT_MARGIN_PERCENT added on top of EstimateGas
+}
+
+// FeeEstimate is the network cost of a single contract call
+type FeeEstimate struct {
+	GasLimit     uint64
+	BaseFee      *big.Int // nil on chains without EIP-1559
+	GasTipCap    *big.Int // nil on chains without EIP-1559
+	GasFeeCap    *big.Int // max fee per gas, or the gas price on legacy chains
+	EstimatedFee *big.Int // (base fee + tip) * estimated gas, what is normally paid
+	MaxFee       *big.Int // gas fee cap * gas limit, the most that can be paid
+}
+
+func loadFeeConfig() feeConfig {
+	return feeConfig{
+		maxFeePerGas:   gwei(config.AppConfig.GasFeeCapGwei),
+		maxTipPerGas:   gwei(config.AppConfig.GasTipCapGwei),
+		gasLimitMargin: config.AppConfig.GasLimitMarginPercent,
+	}
+}
+
+// gwei converts a whole-gwei amount to wei
+func gwei(amount uint64) *big.Int {
+	return new(big.Int).Mul(new(big.Int).SetUint64(amount), big.NewInt(params.GWei))
+}
+
+// suggestFees returns the tip and fee cap for a dynamic-fee transaction, capped
+// by the configured limits. On chains without a base fee tipCap is nil and
+// feeCap is the legacy gas price.
+func (w *Web3Client) suggestFees(ctx context.Context) (tipCap, feeCap, baseFee *big.Int, err error) {
+	header, err := w.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to get latest header: %v", err)
+	}
+
+	if header.BaseFee == nil {
+		gasPrice, err := w.client.SuggestGasPrice(ctx)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to suggest gas price: %v", err)
+		}
+		if gasPrice.Cmp(w.fees.maxFeePerGas) > 0 {
+			return nil, nil, nil, fmt.Errorf("network gas price %s wei exceeds configured fee cap %s wei", gasPrice, w.fees.maxFeePerGas)
+		}
+		return nil, gasPrice, nil, nil
+	}
+
+	tipCap, err = w.client.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to suggest gas tip: %v", err)
+	}
+	if tipCap.Cmp(w.fees.maxTipPerGas) > 0 {
+		tipCap = new(big.Int).Set(w.fees.maxTipPerGas)
+	}
+
+	// Minimum the transaction needs to be included in the next block
+	required := new(big.Int).Add(header.BaseFee, tipCap)
+	if required.Cmp(w.fees.maxFeePerGas) > 0 {
+		return nil, nil, nil, fmt.Errorf("network fee %s wei exceeds configured fee cap %s wei", required, w.fees.maxFeePerGas)
+	}
+
+	// Double the base fee so the transaction survives a few full blocks
+	feeCap = new(big.Int).Add(new(big.Int).Mul(header.BaseFee, big.NewInt(2)), tipCap)
+	if feeCap.Cmp(w.fees.maxFeePerGas) > 0 {
+		feeCap = new(big.Int).Set(w.fees.maxFeePerGas)
+	}
+
+	return tipCap, feeCap, header.BaseFee, nil
+}
+
+// applyFees sets the fee fields of a transactor (type 2 when the chain supports it)
+func (w *Web3Client) applyFees(ctx context.Context, auth *bind.TransactOpts) error {
+	tipCap, feeCap, _, err := w.suggestFees(ctx)
+	if err != nil {
+		return err
+	}
+
+	if tipCap == nil {
+		auth.GasPrice = feeCap
+		return nil
+	}
+
+	auth.GasPrice = nil
+	auth.GasTipCap = tipCap
+	auth.GasFeeCap = feeCap
+	return nil
+}
+
+// GasLimitWithMargin adds the configured safety margin to an estimated gas amount
+func (w *Web3Client) GasLimitWithMargin(gas uint64) uint64 {
+	return gas + gas*w.fees.gasLimitMargin/100
+}
+
+// FeeForGas prices an estimated gas amount at the current network fees
+func (w *Web3Client) FeeForGas(ctx context.Context, gasUsed uint64) (*FeeEstimate, error) {
+	gasLimit := w.GasLimitWithMargin(gasUsed)
+
+	tipCap, feeCap, baseFee, err := w.suggestFees(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Legacy chains pay the full gas price
+	effectivePrice := feeCap
+	if baseFee != nil {
+		effectivePrice = new(big.Int).Add(baseFee, tipCap)
+	}
+
+	return &FeeEstimate{
+		GasLimit:     gasLimit,
+		BaseFee:      baseFee,
+		GasTipCap:    tipCap,
+		GasFeeCap:    feeCap,
+		EstimatedFee: new(big.Int).Mul(effectivePrice, new(big.Int).SetUint64(gasUsed)),
+		MaxFee:       new(big.Int).Mul(feeCap, new(big.Int).SetUint64(gasLimit)),
+	}, nil
+}
@@ -0,0 +1,48 @@
+//go:build ignore
+
+// gen_bindings generates payment_token.go from the Hardhat artifact of
+// PaymentToken. Run it with `go generate ./internal/web3` after the contract
+// is recompiled and redeployed with Hardhat Ignition.
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/abigen"
+)
+
+const (
+	artifactPath = "../../../smart-contract/ignition/deployments/chain-1337/artifacts/PaymentTokenModule#PaymentToken.json"
+	outputPath   = "payment_token.go"
+)
+
+func main() {
+	data, err := os.ReadFile(artifactPath)
+	if err != nil {
+		log.Fatalf("failed to read artifact: %v", err)
+	}
+
+	var artifact struct {
+		ABI      json.RawMessage `json:"abi"`
+		Bytecode string          `json:"bytecode"`
+	}
+	if err := json.Unmarshal(data, &artifact); err != nil {
+		log.Fatalf("invalid artifact: %v", err)
+	}
+
+	code, err := abigen.Bind(
+		[]string{"PaymentToken"},
+		[]string{string(artifact.ABI)},
+		[]string{artifact.Bytecode},
+		nil, "web3", nil, nil,
+	)
+	if err != nil {
+		log.Fatalf("failed to generate bindings: %v", err)
+	}
+
+	if err := os.WriteFile(outputPath, []byte(code), 0o644); err != nil {
+		log.Fatalf("failed to write %s: %v", outputPath, err)
+	}
+}
@@ -0,0 +1,234 @@
+package web3
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// An account without Acquire for this long has nothing in flight here; its next
+// Acquire takes the chain's pending nonce as is
+const nonceIdleResync = 2 * time.Minute
+
+// NonceSource reads the next nonce of an account from the chain (pending state)
+type NonceSource interface {
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+}
+
+// HeldNonces returns the highest nonce of address held by signed transactions
+// that are stored to be (re)broadcast later and so may be missing from the
+// node's pending state; ok is false when there is none
+type HeldNonces func(address common.Address) (nonce uint64, ok bool, err error)
+
+// NonceManager hands out nonces per signer address so concurrent transactions
+// from the same account never share a nonce. State lives in memory only; after a
+// restart every account is resynced from the chain on first use. It is also
+// resynced after a nonce error, when a transaction is given up or missing on
+// chain (Invalidate), and on the first Acquire after nonceIdleResync: nonces of
+// transactions the node dropped are then handed out again instead of leaving a gap.
+// A resync never goes below the nonces still held by stored signed transactions
+// (see SetHeldNonces), which are broadcast again later.
+type NonceManager struct {
+	mu       sync.Mutex
+	accounts map[common.Address]*accountNonces
+	held     HeldNonces
+}
+
+// accountNonces tracks one signer. next is the lowest nonce never handed out,
+// released holds nonces given back after a failed send (reused lowest first).
+type accountNonces struct {
+	mu       sync.Mutex
+	synced   bool
+	next     uint64
+	released []uint64
+	lastUsed time.Time
+}
+
+// sharedNonceManager is used by every Web3Client, since all of them sign for the same chain
+var sharedNonceManager = NewNonceManager()
+
+func NewNonceManager() *NonceManager {
+	return &NonceManager{accounts: make(map[common.Address]*accountNonces)}
+}
+
+// SetHeldNonces sets where the nonces of stored signed transactions are read from
+func (m *NonceManager) SetHeldNonces(held HeldNonces) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.held = held
+}
+
+// SetHeldNonces sets the held nonces of the nonce state shared by every Web3Client
+func SetHeldNonces(held HeldNonces) {
+	sharedNonceManager.SetHeldNonces(held)
+}
+
+// floor is the lowest nonce address may be given next: the chain's pending
+// nonce, or the one after the highest held nonce when that is higher
+func (m *NonceManager) floor(address common.Address, pending uint64) (uint64, error) {
+	m.mu.Lock()
+	held := m.held
+	m.mu.Unlock()
+	if held == nil {
+		return pending, nil
+	}
+
+	nonce, ok, err := held(address)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read held nonces: %w", err)
+	}
+	if ok && nonce+1 > pending {
+		return nonce + 1, nil
+	}
+	return pending, nil
+}
+
+func (m *NonceManager) account(address common.Address) *accountNonces {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	state, ok := m.accounts[address]
+	if !ok {
+		state = &accountNonces{}
+		m.accounts[address] = state
+	}
+	return state
+}
+
+// Acquire reserves the next nonce for address. The caller must Release it if
+// the transaction is never accepted by the node.
+func (m *NonceManager) Acquire(ctx context.Context, source NonceSource, address common.Address) (uint64, error) {
+	state := m.account(address)
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	var floor uint64
+	pending, err := source.PendingNonceAt(ctx, address)
+	if err == nil {
+		floor, err = m.floor(address, pending)
+	}
+	if err != nil {
+		if !state.synced {
+			return 0, err
+		}
+		// Node sementara tidak bisa dihubungi, lanjut dari state di memori
+		log.Printf("[WARN] Failed to read pending nonce for %s, using local state: %v", address.Hex(), err)
+		pending, floor = 0, 0
+	} else if state.synced && time.Since(state.lastUsed) >= nonceIdleResync && floor != state.next {
+		log.Printf("🔄 Nonce for %s resynced from chain after idle: %d (was %d)", address.Hex(), floor, state.next)
+		state.synced = false
+		state.released = nil
+	}
+	state.lastUsed = time.Now()
+
+	// Nonces below the chain's pending nonce were used elsewhere (another process, a wallet UI)
+	for len(state.released) > 0 && state.released[0] < pending {
+		state.released = state.released[1:]
+	}
+	if len(state.released) > 0 {
+		nonce := state.released[0]
+		state.released = state.released[1:]
+		return nonce, nil
+	}
+
+	if !state.synced || floor > state.next {
+		state.next = floor
+		state.synced = true
+	}
+
+	nonce := state.next
+	state.next++
+	return nonce, nil
+}
+
+// Release gives back a nonce whose transaction was never sent, so it is reused
+// and does not leave a gap that would block later transactions.
+func (m *NonceManager) Release(address common.Address, nonce uint64) {
+	state := m.account(address)
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	if nonce >= state.next {
+		return
+	}
+
+	state.released = append(state.released, nonce)
+	sort.Slice(state.released, func(i, j int) bool { return state.released[i] < state.released[j] })
+
+	// Released nonces at the top of the range simply shrink it
+	for len(state.released) > 0 && state.released[len(state.released)-1] == state.next-1 {
+		state.released = state.released[:len(state.released)-1]
+		state.next--
+	}
+}
+
+// Resync drops the local state of address and reloads it from the chain
+func (m *NonceManager) Resync(ctx context.Context, source NonceSource, address common.Address) error {
+	state := m.account(address)
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	pending, err := source.PendingNonceAt(ctx, address)
+	if err == nil {
+		pending, err = m.floor(address, pending)
+	}
+	if err != nil {
+		state.synced = false
+		return err
+	}
+
+	state.next = pending
+	state.released = nil
+	state.synced = true
+	log.Printf("🔄 Nonce for %s resynced from chain: %d", address.Hex(), pending)
+	return nil
+}
+
+// Invalidate makes every account read its nonce from the chain again on its
+// next Acquire, e.g. after one of our transactions was found missing on chain
+func (m *NonceManager) Invalidate() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, state := range m.accounts {
+		state.mu.Lock()
+		state.synced = false
+		state.released = nil
+		state.mu.Unlock()
+	}
+}
+
+// InvalidateNonces invalidates the nonce state shared by every Web3Client
+func InvalidateNonces() {
+	sharedNonceManager.Invalidate()
+}
+
+// SendFailed handles a nonce whose transaction was rejected: nonce errors mean
+// the local state is out of date and trigger a resync, anything else releases it.
+func (m *NonceManager) SendFailed(ctx context.Context, source NonceSource, address common.Address, nonce uint64, sendErr error) {
+	if isNonceError(sendErr) {
+		if err := m.Resync(ctx, source, address); err != nil {
+			log.Printf("[ERROR] Failed to resync nonce for %s: %v", address.Hex(), err)
+		}
+		return
+	}
+	m.Release(address, nonce)
+}
+
+// isNonceError reports whether the node rejected a transaction because of its nonce
+func isNonceError(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "nonce too low") ||
+		strings.Contains(msg, "nonce too high") ||
+		strings.Contains(msg, "replacement transaction underpriced") ||
+		strings.Contains(msg, "already known")
+}
@@ -0,0 +1,110 @@
+package web3
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type fakeNonceSource struct {
+	pending uint64
+}
+
+func (s *fakeNonceSource) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return s.pending, nil
+}
+
+var testSigner = common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+
+// heldNonce is a HeldNonces that holds nonce for testSigner
+func heldNonce(nonce uint64) HeldNonces {
+	return func(address common.Address) (uint64, bool, error) {
+		return nonce, address == testSigner, nil
+	}
+}
+
+// idle makes the next Acquire of address come after the idle window
+func idle(m *NonceManager, address common.Address) {
+	state := m.account(address)
+	state.mu.Lock()
+	state.lastUsed = time.Now().Add(-nonceIdleResync - time.Second)
+	state.mu.Unlock()
+}
+
+func TestNonceManagerIdleResyncKeepsHeldNonces(t *testing.T) {
+	tests := []struct {
+		name string
+		held HeldNonces
+		want uint64
+	}{
+		// The node dropped nonce 5 and nothing holds it: reuse it instead of leaving a gap
+		{name: "nothing held", want: 5},
+		// A signed outbox message still holds nonce 5 while its broadcast backs off
+		{name: "signed message held past the idle window", held: heldNonce(5), want: 6},
+		{name: "held nonce already mined", held: heldNonce(3), want: 5},
+		{name: "held by another signer", held: func(common.Address) (uint64, bool, error) { return 0, false, nil }, want: 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := &fakeNonceSource{pending: 5}
+			m := NewNonceManager()
+
+			first, err := m.Acquire(context.Background(), source, testSigner)
+			if err != nil || first != 5 {
+				t.Fatalf("first Acquire = %d, %v, want 5", first, err)
+			}
+			// Its signed transaction is stored to be broadcast later
+			m.SetHeldNonces(tt.held)
+
+			// The transaction never reached the node, so the pending nonce stays 5
+			idle(m, testSigner)
+			got, err := m.Acquire(context.Background(), source, testSigner)
+			if err != nil {
+				t.Fatalf("Acquire after idle error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Acquire after idle = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNonceManagerRestartKeepsHeldNonces(t *testing.T) {
+	source := &fakeNonceSource{pending: 5}
+
+	// A fresh manager, as after a restart, with signed messages holding 5 and 6
+	m := NewNonceManager()
+	m.SetHeldNonces(heldNonce(6))
+	got, err := m.Acquire(context.Background(), source, testSigner)
+	if err != nil || got != 7 {
+		t.Fatalf("Acquire after restart = %d, %v, want 7", got, err)
+	}
+
+	if err := m.Resync(context.Background(), source, testSigner); err != nil {
+		t.Fatalf("Resync error = %v", err)
+	}
+	got, err = m.Acquire(context.Background(), source, testSigner)
+	if err != nil || got != 7 {
+		t.Errorf("Acquire after Resync = %d, %v, want 7", got, err)
+	}
+}
+
+func TestNonceManagerReleaseReusesNonce(t *testing.T) {
+	source := &fakeNonceSource{pending: 2}
+	m := NewNonceManager()
+
+	for want := uint64(2); want <= 4; want++ {
+		if got, _ := m.Acquire(context.Background(), source, testSigner); got != want {
+			t.Fatalf("Acquire = %d, want %d", got, want)
+		}
+	}
+	m.Release(testSigner, 3)
+	if got, _ := m.Acquire(context.Background(), source, testSigner); got != 3 {
+		t.Errorf("Acquire after Release(3) = %d, want 3", got)
+	}
+	if got, _ := m.Acquire(context.Background(), source, testSigner); got != 5 {
+		t.Errorf("Acquire = %d, want 5", got)
+	}
+}
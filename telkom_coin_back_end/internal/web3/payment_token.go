@@ -0,0 +1,2218 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package web3
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// PaymentTokenMetaData contains all meta data concerning the PaymentToken contract.
+var PaymentTokenMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"initialSupply\",\"type\":\"uint256\"}],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"allowance\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"needed\",\"type\":\"uint256\"}],\"name\":\"ERC20InsufficientAllowance\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"balance\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"needed\",\"type\":\"uint256\"}],\"name\":\"ERC20InsufficientBalance\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"approver\",\"type\":\"address\"}],\"name\":\"ERC20InvalidApprover\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"receiver\",\"type\":\"address\"}],\"name\":\"ERC20InvalidReceiver\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"}],\"name\":\"ERC20InvalidSender\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"}],\"name\":\"ERC20InvalidSpender\",\"type\":\"error\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"Approval\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"oldRate\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"newRate\",\"type\":\"uint256\"}],\"name\":\"ExchangeRateUpdated\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"note\",\"type\":\"string\"}],\"name\":\"PaymentProcessed\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"requestId\",\"type\":\"bytes32\"}],\"name\":\"TokensBurned\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"requestId\",\"type\":\"bytes32\"}],\"name\":\"TokensMinted\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"user\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"requestId\",\"type\":\"bytes32\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"paymentProof\",\"type\":\"string\"}],\"name\":\"TopupRequested\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"Transfer\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"user\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"requestId\",\"type\":\"bytes32\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"bankAccount\",\"type\":\"string\"}],\"name\":\"WithdrawRequested\",\"type\":\"event\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"}],\"name\":\"allowance\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"approve\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"balanceOf\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"burn\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"decimals\",\"outputs\":[{\"internalType\":\"uint8\",\"name\":\"\",\"type\":\"uint8\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"exchangeRate\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"getBalance\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"requestId\",\"type\":\"bytes32\"}],\"name\":\"getTopupRequest\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"user\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"internalType\":\"string\",\"name\":\"paymentProof\",\"type\":\"string\"},{\"internalType\":\"uint256\",\"name\":\"timestamp\",\"type\":\"uint256\"},{\"internalType\":\"bool\",\"name\":\"processed\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"requestId\",\"type\":\"bytes32\"}],\"name\":\"getWithdrawRequest\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"user\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"internalType\":\"string\",\"name\":\"bankAccount\",\"type\":\"string\"},{\"internalType\":\"uint256\",\"name\":\"timestamp\",\"type\":\"uint256\"},{\"internalType\":\"bool\",\"name\":\"processed\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"internalType\":\"string\",\"name\":\"paymentProof\",\"type\":\"string\"}],\"name\":\"instantTopup\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"minBurnAmount\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"minMintAmount\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"name\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"internalType\":\"string\",\"name\":\"note\",\"type\":\"string\"}],\"name\":\"paymentTransfer\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"requestId\",\"type\":\"bytes32\"}],\"name\":\"processTopup\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"internalType\":\"string\",\"name\":\"paymentProof\",\"type\":\"string\"}],\"name\":\"requestTopup\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"internalType\":\"string\",\"name\":\"bankAccount\",\"type\":\"string\"}],\"name\":\"requestWithdraw\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"symbol\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"name\":\"topupRequests\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"user\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"internalType\":\"string\",\"name\":\"paymentProof\",\"type\":\"string\"},{\"internalType\":\"uint256\",\"name\":\"timestamp\",\"type\":\"uint256\"},{\"internalType\":\"bool\",\"name\":\"processed\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"totalSupply\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"transfer\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"transferFrom\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"newRate\",\"type\":\"uint256\"}],\"name\":\"updateExchangeRate\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"userNonces\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"name\":\"withdrawRequests\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"user\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"internalType\":\"string\",\"name\":\"bankAccount\",\"type\":\"string\"},{\"internalType\":\"uint256\",\"name\":\"timestamp\",\"type\":\"uint256\"},{\"internalType\":\"bool\",\"name\":\"processed\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
+	Bin: "0x60806040526005805460ff19166012179055670de0b6b3a764000060065569021e19e0c9bab2400000600755683635c9adc5dea00000600855348015610043575f5ffd5b50604051611c96380380611c96833981016040819052610062916102b8565b6040518060400160405280600c81526020016b2a32b635b7b6902a37b5b2b760a11b8152506040518060400160405280600681526020016554454c4b4f4d60d01b81525081600390816100b59190610367565b5060046100c28282610367565b5050506100f1336100d761014c60201b60201c565b6100e290600a61051a565b6100ec908461052f565b610155565b5f337f7369d801113c713520ca653010d32c6bcd6778f0ffb3df0bc9efb8d85b687e4461012060055460ff1690565b61012b90600a61051a565b610135908561052f565b60405190815260200160405180910390a350610559565b60055460ff1690565b6001600160a01b0382166101835760405163ec442f0560e01b81525f60048201526024015b60405180910390fd5b61018e5f8383610192565b5050565b6001600160a01b0383166101bc578060025f8282546101b19190610546565b9091555061022c9050565b6001600160a01b0383165f908152602081905260409020548181101561020e5760405163391434e360e21b81526001600160a01b0385166004820152602481018290526044810183905260640161017a565b6001600160a01b0384165f9081526020819052604090209082900390555b6001600160a01b03821661024857600280548290039055610266565b6001600160a01b0382165f9081526020819052604090208054820190555b816001600160a01b0316836001600160a01b03167fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef836040516102ab91815260200190565b60405180910390a3505050565b5f602082840312156102c8575f5ffd5b5051919050565b634e487b7160e01b5f52604160045260245ffd5b600181811c908216806102f757607f821691505b60208210810361031557634e487b7160e01b5f52602260045260245ffd5b50919050565b601f82111561036257805f5260205f20601f840160051c810160208510156103405750805b601f840160051c820191505b8181101561035f575f815560010161034c565b50505b505050565b81516001600160401b03811115610380576103806102cf565b6103948161038e84546102e3565b8461031b565b6020601f8211600181146103c6575f83156103af5750848201515b5f19600385901b1c1916600184901b17845561035f565b5f84815260208120601f198516915b828110156103f557878501518255602094850194600190920191016103d5565b508482101561041257868401515f19600387901b60f8161c191681555b50505050600190811b01905550565b634e487b7160e01b5f52601160045260245ffd5b6001815b60018411156104705780850481111561045457610454610421565b600184161561046257908102905b60019390931c928002610439565b935093915050565b5f8261048657506001610514565b8161049257505f610514565b81600181146104a857600281146104b2576104ce565b6001915050610514565b60ff8411156104c3576104c3610421565b50506001821b610514565b5060208310610133831016604e8410600b84101617156104f1575081810a610514565b6104fd5f198484610435565b805f190482111561051057610510610421565b0290505b92915050565b5f61052860ff841683610478565b9392505050565b808202811582820484141761051457610514610421565b8082018082111561051457610514610421565b611730806105665f395ff3fe608060405234801561000f575f5ffd5b506004361061016d575f3560e01c806357d9a752116100d9578063a9059cbb11610093578063df3fec9e1161006e578063df3fec9e1461034c578063e0f2f64a1461035f578063e869554d14610372578063f8b2cb4f14610385575f5ffd5b8063a9059cbb146102ee578063b9e205ae14610301578063dd62ed3e14610314575f5ffd5b806357d9a7521461027c57806370a082311461028f57806375ce2dbf146102b7578063839975bb146102ca5780638d46c9a2146102d357806395d89b41146102e6575f5ffd5b806323b872dd1161012a57806323b872dd146102045780632716df0c146102175780632f7801f41461022a578063313ce567146102495780633ba0b9a91461025e57806342966c6814610267575f5ffd5b806301e9d75714610171578063026508d11461018d57806306fdde03146101b1578063095ea7b3146101c65780630d57afa6146101e957806318160ddd146101fc575b5f5ffd5b61017a60075481565b6040519081526020015b60405180910390f35b6101a061019b366004611252565b610398565b604051610184959493929190611297565b6101b961045a565b60405161018491906112d6565b6101d96101d436600461130a565b6104ea565b6040519015158152602001610184565b61017a6101f73660046113d1565b610503565b60025461017a565b6101d9610212366004611415565b61073c565b61017a6102253660046113d1565b61075f565b61017a61023836600461144f565b600b6020525f908152604090205481565b60055460405160ff9091168152602001610184565b61017a60065481565b61027a610275366004611252565b6108e0565b005b61027a61028a366004611252565b610924565b61017a61029d36600461144f565b6001600160a01b03165f9081526020819052604090205490565b6101a06102c5366004611252565b610aa6565b61017a60085481565b6101d96102e1366004611468565b610ad7565b6101b9610b3a565b6101d96102fc36600461130a565b610b49565b61027a61030f366004611252565b610b56565b61017a6103223660046114bb565b6001600160a01b039182165f90815260016020908152604080832093909416825291909152205490565b61017a61035a3660046113d1565b610be2565b6101a061036d366004611252565b610d81565b6101a0610380366004611252565b610e96565b61017a61039336600461144f565b610ee6565b600a6020525f90815260409020805460018201546002830180546001600160a01b039093169391926103c9906114ec565b80601f01602080910402602001604051908101604052809291908181526020018280546103f5906114ec565b80156104405780601f1061041757610100808354040283529160200191610440565b820191905f5260205f20905b81548152906001019060200180831161042357829003601f168201915b50505050600383015460049093015491929160ff16905085565b606060038054610469906114ec565b80601f0160208091040260200160405190810160405280929190818152602001828054610495906114ec565b80156104e05780601f106104b7576101008083540402835291602001916104e0565b820191905f5260205f20905b8154815290600101906020018083116104c357829003601f168201915b5050505050905090565b5f336104f7818585610f03565b60019150505b92915050565b5f60085483101561052f5760405162461bcd60e51b815260040161052690611524565b60405180910390fd5b335f908152602081905260409020548311156105845760405162461bcd60e51b8152602060048201526014602482015273496e73756666696369656e742062616c616e636560601b6044820152606401610526565b5f8251116105cc5760405162461bcd60e51b815260206004820152601560248201527410985b9ac81858d8dbdd5b9d081c995c5d5a5c9959605a1b6044820152606401610526565b335f818152600b602052604081208054919291869186914291866105ef83611566565b9190505560405160200161060795949392919061157e565b6040516020818303038152906040528051906020012090506106293385610f15565b6040805160a08101825233815260208082018781528284018781524260608501525f60808501819052868152600a90935293909120825181546001600160a01b0319166001600160a01b039091161781559051600182015591519091906002820190610695908261160c565b50606082015160038201556080909101516004909101805460ff1916911515919091179055604051848152819033907f52916471973ae53f679d702015168c0a34628d9d95a48de6bd2093661e39a7c39060200160405180910390a380336001600160a01b03167f344657df2d4ba47218fc30cdc36c214eff825ab95e0f5b1064d7ab0eb56b9426868660405161072d9291906116c7565b60405180910390a39392505050565b5f33610749858285610f4d565b610754858585610fc9565b506001949350505050565b5f6007548310156107825760405162461bcd60e51b815260040161052690611524565b5f8251116107cb5760405162461bcd60e51b815260206004820152601660248201527514185e5b595b9d081c1c9bdbd9881c995c5d5a5c995960521b6044820152606401610526565b335f818152600b602052604081208054919291869186914291866107ee83611566565b9190505560405160200161080695949392919061157e565b60408051808303601f19018152828252805160209182012060a0840183523384528184018881528484018881524260608701525f60808701819052838152600990945293909220845181546001600160a01b0319166001600160a01b03909116178155915160018301559151919350906002820190610885908261160c565b50606082015160038201556080909101516004909101805460ff1916911515919091179055604051819033907fb800527edbac2daac0aedefbae480e2bbe821c07864940cc8ab39fd4e42d2b149061072d90889088906116c7565b6108ea3382610f15565b6040518181525f9033907f52916471973ae53f679d702015168c0a34628d9d95a48de6bd2093661e39a7c39060200160405180910390a350565b5f81815260096020526040902080546001600160a01b031661097c5760405162461bcd60e51b815260206004820152601160248201527014995c5d595cdd081b9bdd08199bdd5b99607a1b6044820152606401610526565b600481015460ff16156109d15760405162461bcd60e51b815260206004820152601960248201527f5265717565737420616c72656164792070726f636573736564000000000000006044820152606401610526565b60038101546109e290610e106116e7565b421015610a315760405162461bcd60e51b815260206004820152601d60248201527f57616974203120686f7572206265666f72652070726f63657373696e670000006044820152606401610526565b60048101805460ff19166001908117909155815490820154610a5c916001600160a01b031690611026565b8054600182015460405190815283916001600160a01b0316907f7369d801113c713520ca653010d32c6bcd6778f0ffb3df0bc9efb8d85b687e449060200160405180910390a35050565b60096020525f90815260409020805460018201546002830180546001600160a01b039093169391926103c9906114ec565b5f610ae3338585610fc9565b836001600160a01b0316336001600160a01b03167fb5e51a9058e00792fede50f44125bb8bc3f068a8f877644c8a3b3d02c74f56e08585604051610b289291906116c7565b60405180910390a35060019392505050565b606060048054610469906114ec565b5f336104f7818585610fc9565b5f8111610b9d5760405162461bcd60e51b815260206004820152601560248201527452617465206d75737420626520706f73697469766560581b6044820152606401610526565b600680549082905560408051828152602081018490527fc8d1043f24843c0a1c9251fdc30017d84e87498fbcf232af9f86816b5e182bde910160405180910390a15050565b5f600754831015610c055760405162461bcd60e51b815260040161052690611524565b335f818152600b602052604081208054919291869142919085610c2783611566565b9091555060405160609490941b6bffffffffffffffffffffffff1916602085015260348401929092526054830152607482015260940160408051808303601f19018152828252805160209182012060a0840183523384528184018881528484018881524260608701526001608087018190525f848152600990955294909320855181546001600160a01b0319166001600160a01b0390911617815590519381019390935590519093506002820190610cdf908261160c565b50606082015160038201556080909101516004909101805460ff1916911515919091179055610d0e3385611026565b604051848152819033907f7369d801113c713520ca653010d32c6bcd6778f0ffb3df0bc9efb8d85b687e449060200160405180910390a380336001600160a01b03167fb800527edbac2daac0aedefbae480e2bbe821c07864940cc8ab39fd4e42d2b14868660405161072d9291906116c7565b5f818152600960209081526040808320815160a08101835281546001600160a01b0316815260018201549381019390935260028101805485946060948694859485949192840191610dd1906114ec565b80601f0160208091040260200160405190810160405280929190818152602001828054610dfd906114ec565b8015610e485780601f10610e1f57610100808354040283529160200191610e48565b820191905f5260205f20905b815481529060010190602001808311610e2b57829003601f168201915b5050509183525050600382015460208083019190915260049092015460ff161515604091820152825191830151908301516060840151608090940151929b919a509850919650945092505050565b5f818152600a60209081526040808320815160a08101835281546001600160a01b0316815260018201549381019390935260028101805485946060948694859485949192840191610dd1906114ec565b6001600160a01b0381165f908152602081905260408120546104fd565b610f10838383600161105a565b505050565b6001600160a01b038216610f3e57604051634b637e8f60e11b81525f6004820152602401610526565b610f49825f8361112c565b5050565b6001600160a01b038381165f908152600160209081526040808320938616835292905220545f19811015610fc35781811015610fb557604051637dc7a0d960e11b81526001600160a01b03841660048201526024810182905260448101839052606401610526565b610fc384848484035f61105a565b50505050565b6001600160a01b038316610ff257604051634b637e8f60e11b81525f6004820152602401610526565b6001600160a01b03821661101b5760405163ec442f0560e01b81525f6004820152602401610526565b610f1083838361112c565b6001600160a01b03821661104f5760405163ec442f0560e01b81525f6004820152602401610526565b610f495f838361112c565b6001600160a01b0384166110835760405163e602df0560e01b81525f6004820152602401610526565b6001600160a01b0383166110ac57604051634a1406b160e11b81525f6004820152602401610526565b6001600160a01b038085165f9081526001602090815260408083209387168352929052208290558015610fc357826001600160a01b0316846001600160a01b03167f8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b9258460405161111e91815260200190565b60405180910390a350505050565b6001600160a01b038316611156578060025f82825461114b91906116e7565b909155506111c69050565b6001600160a01b0383165f90815260208190526040902054818110156111a85760405163391434e360e21b81526001600160a01b03851660048201526024810182905260448101839052606401610526565b6001600160a01b0384165f9081526020819052604090209082900390555b6001600160a01b0382166111e257600280548290039055611200565b6001600160a01b0382165f9081526020819052604090208054820190555b816001600160a01b0316836001600160a01b03167fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef8360405161124591815260200190565b60405180910390a3505050565b5f60208284031215611262575f5ffd5b5035919050565b5f81518084528060208401602086015e5f602082860101526020601f19601f83011685010191505092915050565b60018060a01b038616815284602082015260a060408201525f6112bd60a0830186611269565b6060830194909452509015156080909101529392505050565b602081525f6112e86020830184611269565b9392505050565b80356001600160a01b0381168114611305575f5ffd5b919050565b5f5f6040838503121561131b575f5ffd5b611324836112ef565b946020939093013593505050565b634e487b7160e01b5f52604160045260245ffd5b5f82601f830112611355575f5ffd5b813567ffffffffffffffff81111561136f5761136f611332565b604051601f8201601f19908116603f0116810167ffffffffffffffff8111828210171561139e5761139e611332565b6040528181528382016020018510156113b5575f5ffd5b816020850160208301375f918101602001919091529392505050565b5f5f604083850312156113e2575f5ffd5b82359150602083013567ffffffffffffffff8111156113ff575f5ffd5b61140b85828601611346565b9150509250929050565b5f5f5f60608486031215611427575f5ffd5b611430846112ef565b925061143e602085016112ef565b929592945050506040919091013590565b5f6020828403121561145f575f5ffd5b6112e8826112ef565b5f5f5f6060848603121561147a575f5ffd5b611483846112ef565b925060208401359150604084013567ffffffffffffffff8111156114a5575f5ffd5b6114b186828701611346565b9150509250925092565b5f5f604083850312156114cc575f5ffd5b6114d5836112ef565b91506114e3602084016112ef565b90509250929050565b600181811c9082168061150057607f821691505b60208210810361151e57634e487b7160e01b5f52602260045260245ffd5b50919050565b602080825260149082015273416d6f756e742062656c6f77206d696e696d756d60601b604082015260600190565b634e487b7160e01b5f52601160045260245ffd5b5f6001820161157757611577611552565b5060010190565b6bffffffffffffffffffffffff198660601b1681528460148201525f84518060208701603485015e90910160348101939093525060548201526074019392505050565b601f821115610f1057805f5260205f20601f840160051c810160208510156115e65750805b601f840160051c820191505b81811015611605575f81556001016115f2565b5050505050565b815167ffffffffffffffff81111561162657611626611332565b61163a8161163484546114ec565b846115c1565b6020601f82116001811461166c575f83156116555750848201515b5f19600385901b1c1916600184901b178455611605565b5f84815260208120601f198516915b8281101561169b578785015182556020948501946001909201910161167b565b50848210156116b857868401515f19600387901b60f8161c191681555b50505050600190811b01905550565b828152604060208201525f6116df6040830184611269565b949350505050565b808201808211156104fd576104fd61155256fea2646970667358221220dbad456805febea99ef5f726b083f21f5d13ee8b676417f535281a21d444f09664736f6c634300081c0033",
+}
+
+// PaymentTokenABI is the input ABI used to generate the binding from.
+// Deprecated: Use PaymentTokenMetaData.ABI instead.
+var PaymentTokenABI = PaymentTokenMetaData.ABI
+
+// PaymentTokenBin is the compiled bytecode used for deploying new contracts.
+// Deprecated: Use PaymentTokenMetaData.Bin instead.
+var PaymentTokenBin = PaymentTokenMetaData.Bin
+
+// DeployPaymentToken deploys a new Ethereum contract, binding an instance of PaymentToken to it.
+func DeployPaymentToken(auth *bind.TransactOpts, backend bind.ContractBackend, initialSupply *big.Int) (common.Address, *types.Transaction, *PaymentToken, error) {
+	parsed, err := PaymentTokenMetaData.GetAbi()
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	if parsed == nil {
+		return common.Address{}, nil, nil, errors.New("GetABI returned nil")
+	}
+
+	address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(PaymentTokenBin), backend, initialSupply)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &PaymentToken{PaymentTokenCaller: PaymentTokenCaller{contract: contract}, PaymentTokenTransactor: PaymentTokenTransactor{contract: contract}, PaymentTokenFilterer: PaymentTokenFilterer{contract: contract}}, nil
+}
+
+// PaymentToken is an auto generated Go binding around an Ethereum contract.
+type PaymentToken struct {
+	PaymentTokenCaller     // Read-only binding to the contract
+	PaymentTokenTransactor // Write-only binding to the contract
+	PaymentTokenFilterer   // Log filterer for contract events
+}
+
+// PaymentTokenCaller is an auto generated read-only Go binding around an Ethereum contract.
+type PaymentTokenCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// PaymentTokenTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type PaymentTokenTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// PaymentTokenFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type PaymentTokenFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// PaymentTokenSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type PaymentTokenSession struct {
+	Contract     *PaymentToken     // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// PaymentTokenCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type PaymentTokenCallerSession struct {
+	Contract *PaymentTokenCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts       // Call options to use throughout this session
+}
+
+// PaymentTokenTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type PaymentTokenTransactorSession struct {
+	Contract     *PaymentTokenTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts       // Transaction auth options to use throughout this session
+}
+
+// PaymentTokenRaw is an auto generated low-level Go binding around an Ethereum contract.
+type PaymentTokenRaw struct {
+	Contract *PaymentToken // Generic contract binding to access the raw methods on
+}
+
+// PaymentTokenCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type PaymentTokenCallerRaw struct {
+	Contract *PaymentTokenCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// PaymentTokenTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type PaymentTokenTransactorRaw struct {
+	Contract *PaymentTokenTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewPaymentToken creates a new instance of PaymentToken, bound to a specific deployed contract.
+func NewPaymentToken(address common.Address, backend bind.ContractBackend) (*PaymentToken, error) {
+	contract, err := bindPaymentToken(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &PaymentToken{PaymentTokenCaller: PaymentTokenCaller{contract: contract}, PaymentTokenTransactor: PaymentTokenTransactor{contract: contract}, PaymentTokenFilterer: PaymentTokenFilterer{contract: contract}}, nil
+}
+
+// NewPaymentTokenCaller creates a new read-only instance of PaymentToken, bound to a specific deployed contract.
+func NewPaymentTokenCaller(address common.Address, caller bind.ContractCaller) (*PaymentTokenCaller, error) {
+	contract, err := bindPaymentToken(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &PaymentTokenCaller{contract: contract}, nil
+}
+
+// NewPaymentTokenTransactor creates a new write-only instance of PaymentToken, bound to a specific deployed contract.
+func NewPaymentTokenTransactor(address common.Address, transactor bind.ContractTransactor) (*PaymentTokenTransactor, error) {
+	contract, err := bindPaymentToken(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &PaymentTokenTransactor{contract: contract}, nil
+}
+
+// NewPaymentTokenFilterer creates a new log filterer instance of PaymentToken, bound to a specific deployed contract.
+func NewPaymentTokenFilterer(address common.Address, filterer bind.ContractFilterer) (*PaymentTokenFilterer, error) {
+	contract, err := bindPaymentToken(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &PaymentTokenFilterer{contract: contract}, nil
+}
+
+// bindPaymentToken binds a generic wrapper to an already deployed contract.
+func bindPaymentToken(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := PaymentTokenMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_PaymentToken *PaymentTokenRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _PaymentToken.Contract.PaymentTokenCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_PaymentToken *PaymentTokenRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _PaymentToken.Contract.PaymentTokenTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_PaymentToken *PaymentTokenRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _PaymentToken.Contract.PaymentTokenTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_PaymentToken *PaymentTokenCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _PaymentToken.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_PaymentToken *PaymentTokenTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _PaymentToken.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_PaymentToken *PaymentTokenTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _PaymentToken.Contract.contract.Transact(opts, method, params...)
+}
+
+// Allowance is a free data retrieval call binding the contract method 0xdd62ed3e.
+//
+// Solidity: function allowance(address owner, address spender) view returns(uint256)
+func (_PaymentToken *PaymentTokenCaller) Allowance(opts *bind.CallOpts, owner common.Address, spender common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _PaymentToken.contract.Call(opts, &out, "allowance", owner, spender)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// Allowance is a free data retrieval call binding the contract method 0xdd62ed3e.
+//
+// Solidity: function allowance(address owner, address spender) view returns(uint256)
+func (_PaymentToken *PaymentTokenSession) Allowance(owner common.Address, spender common.Address) (*big.Int, error) {
+	return _PaymentToken.Contract.Allowance(&_PaymentToken.CallOpts, owner, spender)
+}
+
+// Allowance is a free data retrieval call binding the contract method 0xdd62ed3e.
+//
+// Solidity: function allowance(address owner, address spender) view returns(uint256)
+func (_PaymentToken *PaymentTokenCallerSession) Allowance(owner common.Address, spender common.Address) (*big.Int, error) {
+	return _PaymentToken.Contract.Allowance(&_PaymentToken.CallOpts, owner, spender)
+}
+
+// BalanceOf is a free data retrieval call binding the contract method 0x70a08231.
+//
+// Solidity: function balanceOf(address account) view returns(uint256)
+func (_PaymentToken *PaymentTokenCaller) BalanceOf(opts *bind.CallOpts, account common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _PaymentToken.contract.Call(opts, &out, "balanceOf", account)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// BalanceOf is a free data retrieval call binding the contract method 0x70a08231.
+//
+// Solidity: function balanceOf(address account) view returns(uint256)
+func (_PaymentToken *PaymentTokenSession) BalanceOf(account common.Address) (*big.Int, error) {
+	return _PaymentToken.Contract.BalanceOf(&_PaymentToken.CallOpts, account)
+}
+
+// BalanceOf is a free data retrieval call binding the contract method 0x70a08231.
+//
+// Solidity: function balanceOf(address account) view returns(uint256)
+func (_PaymentToken *PaymentTokenCallerSession) BalanceOf(account common.Address) (*big.Int, error) {
+	return _PaymentToken.Contract.BalanceOf(&_PaymentToken.CallOpts, account)
+}
+
+// Decimals is a free data retrieval call binding the contract method 0x313ce567.
+//
+// Solidity: function decimals() view returns(uint8)
+func (_PaymentToken *PaymentTokenCaller) Decimals(opts *bind.CallOpts) (uint8, error) {
+	var out []interface{}
+	err := _PaymentToken.contract.Call(opts, &out, "decimals")
+
+	if err != nil {
+		return *new(uint8), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint8)).(*uint8)
+
+	return out0, err
+
+}
+
+// Decimals is a free data retrieval call binding the contract method 0x313ce567.
+//
+// Solidity: function decimals() view returns(uint8)
+func (_PaymentToken *PaymentTokenSession) Decimals() (uint8, error) {
+	return _PaymentToken.Contract.Decimals(&_PaymentToken.CallOpts)
+}
+
+// Decimals is a free data retrieval call binding the contract method 0x313ce567.
+//
+// Solidity: function decimals() view returns(uint8)
+func (_PaymentToken *PaymentTokenCallerSession) Decimals() (uint8, error) {
+	return _PaymentToken.Contract.Decimals(&_PaymentToken.CallOpts)
+}
+
+// ExchangeRate is a free data retrieval call binding the contract method 0x3ba0b9a9.
+//
+// Solidity: function exchangeRate() view returns(uint256)
+func (_PaymentToken *PaymentTokenCaller) ExchangeRate(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _PaymentToken.contract.Call(opts, &out, "exchangeRate")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// ExchangeRate is a free data retrieval call binding the contract method 0x3ba0b9a9.
+//
+// Solidity: function exchangeRate() view returns(uint256)
+func (_PaymentToken *PaymentTokenSession) ExchangeRate() (*big.Int, error) {
+	return _PaymentToken.Contract.ExchangeRate(&_PaymentToken.CallOpts)
+}
+
+// ExchangeRate is a free data retrieval call binding the contract method 0x3ba0b9a9.
+//
+// Solidity: function exchangeRate() view returns(uint256)
+func (_PaymentToken *PaymentTokenCallerSession) ExchangeRate() (*big.Int, error) {
+	return _PaymentToken.Contract.ExchangeRate(&_PaymentToken.CallOpts)
+}
+
+// GetBalance is a free data retrieval call binding the contract method 0xf8b2cb4f.
+//
+// Solidity: function getBalance(address account) view returns(uint256)
+func (_PaymentToken *PaymentTokenCaller) GetBalance(opts *bind.CallOpts, account common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _PaymentToken.contract.Call(opts, &out, "getBalance", account)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// GetBalance is a free data retrieval call binding the contract method 0xf8b2cb4f.
+//
+// Solidity: function getBalance(address account) view returns(uint256)
+func (_PaymentToken *PaymentTokenSession) GetBalance(account common.Address) (*big.Int, error) {
+	return _PaymentToken.Contract.GetBalance(&_PaymentToken.CallOpts, account)
+}
+
+// GetBalance is a free data retrieval call binding the contract method 0xf8b2cb4f.
+//
+// Solidity: function getBalance(address account) view returns(uint256)
+func (_PaymentToken *PaymentTokenCallerSession) GetBalance(account common.Address) (*big.Int, error) {
+	return _PaymentToken.Contract.GetBalance(&_PaymentToken.CallOpts, account)
+}
+
+// GetTopupRequest is a free data retrieval call binding the contract method 0xe0f2f64a.
+//
+// Solidity: function getTopupRequest(bytes32 requestId) view returns(address user, uint256 amount, string paymentProof, uint256 timestamp, bool processed)
+func (_PaymentToken *PaymentTokenCaller) GetTopupRequest(opts *bind.CallOpts, requestId [32]byte) (struct {
+	User         common.Address
+	Amount       *big.Int
+	PaymentProof string
+	Timestamp    *big.Int
+	Processed    bool
+}, error) {
+	var out []interface{}
+	err := _PaymentToken.contract.Call(opts, &out, "getTopupRequest", requestId)
+
+	outstruct := new(struct {
+		User         common.Address
+		Amount       *big.Int
+		PaymentProof string
+		Timestamp    *big.Int
+		Processed    bool
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.User = *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+	outstruct.Amount = *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+	outstruct.PaymentProof = *abi.ConvertType(out[2], new(string)).(*string)
+	outstruct.Timestamp = *abi.ConvertType(out[3], new(*big.Int)).(**big.Int)
+	outstruct.Processed = *abi.ConvertType(out[4], new(bool)).(*bool)
+
+	return *outstruct, err
+
+}
+
+// GetTopupRequest is a free data retrieval call binding the contract method 0xe0f2f64a.
+//
+// Solidity: function getTopupRequest(bytes32 requestId) view returns(address user, uint256 amount, string paymentProof, uint256 timestamp, bool processed)
+func (_PaymentToken *PaymentTokenSession) GetTopupRequest(requestId [32]byte) (struct {
+	User         common.Address
+	Amount       *big.Int
+	PaymentProof string
+	Timestamp    *big.Int
+	Processed    bool
+}, error) {
+	return _PaymentToken.Contract.GetTopupRequest(&_PaymentToken.CallOpts, requestId)
+}
+
+// GetTopupRequest is a free data retrieval call binding the contract method 0xe0f2f64a.
+//
+// Solidity: function getTopupRequest(bytes32 requestId) view returns(address user, uint256 amount, string paymentProof, uint256 timestamp, bool processed)
+func (_PaymentToken *PaymentTokenCallerSession) GetTopupRequest(requestId [32]byte) (struct {
+	User         common.Address
+	Amount       *big.Int
+	PaymentProof string
+	Timestamp    *big.Int
+	Processed    bool
+}, error) {
+	return _PaymentToken.Contract.GetTopupRequest(&_PaymentToken.CallOpts, requestId)
+}
+
+// GetWithdrawRequest is a free data retrieval call binding the contract method 0xe869554d.
+//
+// Solidity: function getWithdrawRequest(bytes32 requestId) view returns(address user, uint256 amount, string bankAccount, uint256 timestamp, bool processed)
+func (_PaymentToken *PaymentTokenCaller) GetWithdrawRequest(opts *bind.CallOpts, requestId [32]byte) (struct {
+	User        common.Address
+	Amount      *big.Int
+	BankAccount string
+	Timestamp   *big.Int
+	Processed   bool
+}, error) {
+	var out []interface{}
+	err := _PaymentToken.contract.Call(opts, &out, "getWithdrawRequest", requestId)
+
+	outstruct := new(struct {
+		User        common.Address
+		Amount      *big.Int
+		BankAccount string
+		Timestamp   *big.Int
+		Processed   bool
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.User = *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+	outstruct.Amount = *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+	outstruct.BankAccount = *abi.ConvertType(out[2], new(string)).(*string)
+	outstruct.Timestamp = *abi.ConvertType(out[3], new(*big.Int)).(**big.Int)
+	outstruct.Processed = *abi.ConvertType(out[4], new(bool)).(*bool)
+
+	return *outstruct, err
+
+}
+
+// GetWithdrawRequest is a free data retrieval call binding the contract method 0xe869554d.
+//
+// Solidity: function getWithdrawRequest(bytes32 requestId) view returns(address user, uint256 amount, string bankAccount, uint256 timestamp, bool processed)
+func (_PaymentToken *PaymentTokenSession) GetWithdrawRequest(requestId [32]byte) (struct {
+	User        common.Address
+	Amount      *big.Int
+	BankAccount string
+	Timestamp   *big.Int
+	Processed   bool
+}, error) {
+	return _PaymentToken.Contract.GetWithdrawRequest(&_PaymentToken.CallOpts, requestId)
+}
+
+// GetWithdrawRequest is a free data retrieval call binding the contract method 0xe869554d.
+//
+// Solidity: function getWithdrawRequest(bytes32 requestId) view returns(address user, uint256 amount, string bankAccount, uint256 timestamp, bool processed)
+func (_PaymentToken *PaymentTokenCallerSession) GetWithdrawRequest(requestId [32]byte) (struct {
+	User        common.Address
+	Amount      *big.Int
+	BankAccount string
+	Timestamp   *big.Int
+	Processed   bool
+}, error) {
+	return _PaymentToken.Contract.GetWithdrawRequest(&_PaymentToken.CallOpts, requestId)
+}
+
+// MinBurnAmount is a free data retrieval call binding the contract method 0x839975bb.
+//
+// Solidity: function minBurnAmount() view returns(uint256)
+func (_PaymentToken *PaymentTokenCaller) MinBurnAmount(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _PaymentToken.contract.Call(opts, &out, "minBurnAmount")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MinBurnAmount is a free data retrieval call binding the contract method 0x839975bb.
+//
+// Solidity: function minBurnAmount() view returns(uint256)
+func (_PaymentToken *PaymentTokenSession) MinBurnAmount() (*big.Int, error) {
+	return _PaymentToken.Contract.MinBurnAmount(&_PaymentToken.CallOpts)
+}
+
+// MinBurnAmount is a free data retrieval call binding the contract method 0x839975bb.
+//
+// Solidity: function minBurnAmount() view returns(uint256)
+func (_PaymentToken *PaymentTokenCallerSession) MinBurnAmount() (*big.Int, error) {
+	return _PaymentToken.Contract.MinBurnAmount(&_PaymentToken.CallOpts)
+}
+
+// MinMintAmount is a free data retrieval call binding the contract method 0x01e9d757.
+//
+// Solidity: function minMintAmount() view returns(uint256)
+func (_PaymentToken *PaymentTokenCaller) MinMintAmount(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _PaymentToken.contract.Call(opts, &out, "minMintAmount")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MinMintAmount is a free data retrieval call binding the contract method 0x01e9d757.
+//
+// Solidity: function minMintAmount() view returns(uint256)
+func (_PaymentToken *PaymentTokenSession) MinMintAmount() (*big.Int, error) {
+	return _PaymentToken.Contract.MinMintAmount(&_PaymentToken.CallOpts)
+}
+
+// MinMintAmount is a free data retrieval call binding the contract method 0x01e9d757.
+//
+// Solidity: function minMintAmount() view returns(uint256)
+func (_PaymentToken *PaymentTokenCallerSession) MinMintAmount() (*big.Int, error) {
+	return _PaymentToken.Contract.MinMintAmount(&_PaymentToken.CallOpts)
+}
+
+// Name is a free data retrieval call binding the contract method 0x06fdde03.
+//
+// Solidity: function name() view returns(string)
+func (_PaymentToken *PaymentTokenCaller) Name(opts *bind.CallOpts) (string, error) {
+	var out []interface{}
+	err := _PaymentToken.contract.Call(opts, &out, "name")
+
+	if err != nil {
+		return *new(string), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+
+	return out0, err
+
+}
+
+// Name is a free data retrieval call binding the contract method 0x06fdde03.
+//
+// Solidity: function name() view returns(string)
+func (_PaymentToken *PaymentTokenSession) Name() (string, error) {
+	return _PaymentToken.Contract.Name(&_PaymentToken.CallOpts)
+}
+
+// Name is a free data retrieval call binding the contract method 0x06fdde03.
+//
+// Solidity: function name() view returns(string)
+func (_PaymentToken *PaymentTokenCallerSession) Name() (string, error) {
+	return _PaymentToken.Contract.Name(&_PaymentToken.CallOpts)
+}
+
+// Symbol is a free data retrieval call binding the contract method 0x95d89b41.
+//
+// Solidity: function symbol() view returns(string)
+func (_PaymentToken *PaymentTokenCaller) Symbol(opts *bind.CallOpts) (string, error) {
+	var out []interface{}
+	err := _PaymentToken.contract.Call(opts, &out, "symbol")
+
+	if err != nil {
+		return *new(string), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+
+	return out0, err
+
+}
+
+// Symbol is a free data retrieval call binding the contract method 0x95d89b41.
+//
+// Solidity: function symbol() view returns(string)
+func (_PaymentToken *PaymentTokenSession) Symbol() (string, error) {
+	return _PaymentToken.Contract.Symbol(&_PaymentToken.CallOpts)
+}
+
+// Symbol is a free data retrieval call binding the contract method 0x95d89b41.
+//
+// Solidity: function symbol() view returns(string)
+func (_PaymentToken *PaymentTokenCallerSession) Symbol() (string, error) {
+	return _PaymentToken.Contract.Symbol(&_PaymentToken.CallOpts)
+}
+
+// TopupRequests is a free data retrieval call binding the contract method 0x75ce2dbf.
+//
+// Solidity: function topupRequests(bytes32 ) view returns(address user, uint256 amount, string paymentProof, uint256 timestamp, bool processed)
+func (_PaymentToken *PaymentTokenCaller) TopupRequests(opts *bind.CallOpts, arg0 [32]byte) (struct {
+	User         common.Address
+	Amount       *big.Int
+	PaymentProof string
+	Timestamp    *big.Int
+	Processed    bool
+}, error) {
+	var out []interface{}
+	err := _PaymentToken.contract.Call(opts, &out, "topupRequests", arg0)
+
+	outstruct := new(struct {
+		User         common.Address
+		Amount       *big.Int
+		PaymentProof string
+		Timestamp    *big.Int
+		Processed    bool
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.User = *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+	outstruct.Amount = *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+	outstruct.PaymentProof = *abi.ConvertType(out[2], new(string)).(*string)
+	outstruct.Timestamp = *abi.ConvertType(out[3], new(*big.Int)).(**big.Int)
+	outstruct.Processed = *abi.ConvertType(out[4], new(bool)).(*bool)
+
+	return *outstruct, err
+
+}
+
+// TopupRequests is a free data retrieval call binding the contract method 0x75ce2dbf.
+//
+// Solidity: function topupRequests(bytes32 ) view returns(address user, uint256 amount, string paymentProof, uint256 timestamp, bool processed)
+func (_PaymentToken *PaymentTokenSession) TopupRequests(arg0 [32]byte) (struct {
+	User         common.Address
+	Amount       *big.Int
+	PaymentProof string
+	Timestamp    *big.Int
+	Processed    bool
+}, error) {
+	return _PaymentToken.Contract.TopupRequests(&_PaymentToken.CallOpts, arg0)
+}
+
+// TopupRequests is a free data retrieval call binding the contract method 0x75ce2dbf.
+//
+// Solidity: function topupRequests(bytes32 ) view returns(address user, uint256 amount, string paymentProof, uint256 timestamp, bool processed)
+func (_PaymentToken *PaymentTokenCallerSession) TopupRequests(arg0 [32]byte) (struct {
+	User         common.Address
+	Amount       *big.Int
+	PaymentProof string
+	Timestamp    *big.Int
+	Processed    bool
+}, error) {
+	return _PaymentToken.Contract.TopupRequests(&_PaymentToken.CallOpts, arg0)
+}
+
+// TotalSupply is a free data retrieval call binding the contract method 0x18160ddd.
+//
+// Solidity: function totalSupply() view returns(uint256)
+func (_PaymentToken *PaymentTokenCaller) TotalSupply(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _PaymentToken.contract.Call(opts, &out, "totalSupply")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TotalSupply is a free data retrieval call binding the contract method 0x18160ddd.
+//
+// Solidity: function totalSupply() view returns(uint256)
+func (_PaymentToken *PaymentTokenSession) TotalSupply() (*big.Int, error) {
+	return _PaymentToken.Contract.TotalSupply(&_PaymentToken.CallOpts)
+}
+
+// TotalSupply is a free data retrieval call binding the contract method 0x18160ddd.
+//
+// Solidity: function totalSupply() view returns(uint256)
+func (_PaymentToken *PaymentTokenCallerSession) TotalSupply() (*big.Int, error) {
+	return _PaymentToken.Contract.TotalSupply(&_PaymentToken.CallOpts)
+}
+
+// UserNonces is a free data retrieval call binding the contract method 0x2f7801f4.
+//
+// Solidity: function userNonces(address ) view returns(uint256)
+func (_PaymentToken *PaymentTokenCaller) UserNonces(opts *bind.CallOpts, arg0 common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _PaymentToken.contract.Call(opts, &out, "userNonces", arg0)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// UserNonces is a free data retrieval call binding the contract method 0x2f7801f4.
+//
+// Solidity: function userNonces(address ) view returns(uint256)
+func (_PaymentToken *PaymentTokenSession) UserNonces(arg0 common.Address) (*big.Int, error) {
+	return _PaymentToken.Contract.UserNonces(&_PaymentToken.CallOpts, arg0)
+}
+
+// UserNonces is a free data retrieval call binding the contract method 0x2f7801f4.
+//
+// Solidity: function userNonces(address ) view returns(uint256)
+func (_PaymentToken *PaymentTokenCallerSession) UserNonces(arg0 common.Address) (*big.Int, error) {
+	return _PaymentToken.Contract.UserNonces(&_PaymentToken.CallOpts, arg0)
+}
+
+// WithdrawRequests is a free data retrieval call binding the contract method 0x026508d1.
+//
+// Solidity: function withdrawRequests(bytes32 ) view returns(address user, uint256 amount, string bankAccount, uint256 timestamp, bool processed)
+func (_PaymentToken *PaymentTokenCaller) WithdrawRequests(opts *bind.CallOpts, arg0 [32]byte) (struct {
+	User        common.Address
+	Amount      *big.Int
+	BankAccount string
+	Timestamp   *big.Int
+	Processed   bool
+}, error) {
+	var out []interface{}
+	err := _PaymentToken.contract.Call(opts, &out, "withdrawRequests", arg0)
+
+	outstruct := new(struct {
+		User        common.Address
+		Amount      *big.Int
+		BankAccount string
+		Timestamp   *big.Int
+		Processed   bool
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.User = *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+	outstruct.Amount = *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+	outstruct.BankAccount = *abi.ConvertType(out[2], new(string)).(*string)
+	outstruct.Timestamp = *abi.ConvertType(out[3], new(*big.Int)).(**big.Int)
+	outstruct.Processed = *abi.ConvertType(out[4], new(bool)).(*bool)
+
+	return *outstruct, err
+
+}
+
+// WithdrawRequests is a free data retrieval call binding the contract method 0x026508d1.
+//
+// Solidity: function withdrawRequests(bytes32 ) view returns(address user, uint256 amount, string bankAccount, uint256 timestamp, bool processed)
+func (_PaymentToken *PaymentTokenSession) WithdrawRequests(arg0 [32]byte) (struct {
+	User        common.Address
+	Amount      *big.Int
+	BankAccount string
+	Timestamp   *big.Int
+	Processed   bool
+}, error) {
+	return _PaymentToken.Contract.WithdrawRequests(&_PaymentToken.CallOpts, arg0)
+}
+
+// WithdrawRequests is a free data retrieval call binding the contract method 0x026508d1.
+//
+// Solidity: function withdrawRequests(bytes32 ) view returns(address user, uint256 amount, string bankAccount, uint256 timestamp, bool processed)
+func (_PaymentToken *PaymentTokenCallerSession) WithdrawRequests(arg0 [32]byte) (struct {
+	User        common.Address
+	Amount      *big.Int
+	BankAccount string
+	Timestamp   *big.Int
+	Processed   bool
+}, error) {
+	return _PaymentToken.Contract.WithdrawRequests(&_PaymentToken.CallOpts, arg0)
+}
+
+// Approve is a paid mutator transaction binding the contract method 0x095ea7b3.
+//
+// Solidity: function approve(address spender, uint256 value) returns(bool)
+func (_PaymentToken *PaymentTokenTransactor) Approve(opts *bind.TransactOpts, spender common.Address, value *big.Int) (*types.Transaction, error) {
+	return _PaymentToken.contract.Transact(opts, "approve", spender, value)
+}
+
+// Approve is a paid mutator transaction binding the contract method 0x095ea7b3.
+//
+// Solidity: function approve(address spender, uint256 value) returns(bool)
+func (_PaymentToken *PaymentTokenSession) Approve(spender common.Address, value *big.Int) (*types.Transaction, error) {
+	return _PaymentToken.Contract.Approve(&_PaymentToken.TransactOpts, spender, value)
+}
+
+// Approve is a paid mutator transaction binding the contract method 0x095ea7b3.
+//
+// Solidity: function approve(address spender, uint256 value) returns(bool)
+func (_PaymentToken *PaymentTokenTransactorSession) Approve(spender common.Address, value *big.Int) (*types.Transaction, error) {
+	return _PaymentToken.Contract.Approve(&_PaymentToken.TransactOpts, spender, value)
+}
+
+// Burn is a paid mutator transaction binding the contract method 0x42966c68.
+//
+// Solidity: function burn(uint256 amount) returns()
+func (_PaymentToken *PaymentTokenTransactor) Burn(opts *bind.TransactOpts, amount *big.Int) (*types.Transaction, error) {
+	return _PaymentToken.contract.Transact(opts, "burn", amount)
+}
+
+// Burn is a paid mutator transaction binding the contract method 0x42966c68.
+//
+// Solidity: function burn(uint256 amount) returns()
+func (_PaymentToken *PaymentTokenSession) Burn(amount *big.Int) (*types.Transaction, error) {
+	return _PaymentToken.Contract.Burn(&_PaymentToken.TransactOpts, amount)
+}
+
+// Burn is a paid mutator transaction binding the contract method 0x42966c68.
+//
+// Solidity: function burn(uint256 amount) returns()
+func (_PaymentToken *PaymentTokenTransactorSession) Burn(amount *big.Int) (*types.Transaction, error) {
+	return _PaymentToken.Contract.Burn(&_PaymentToken.TransactOpts, amount)
+}
+
+// InstantTopup is a paid mutator transaction binding the contract method 0xdf3fec9e.
+//
+// Solidity: function instantTopup(uint256 amount, string paymentProof) returns(bytes32)
+func (_PaymentToken *PaymentTokenTransactor) InstantTopup(opts *bind.TransactOpts, amount *big.Int, paymentProof string) (*types.Transaction, error) {
+	return _PaymentToken.contract.Transact(opts, "instantTopup", amount, paymentProof)
+}
+
+// InstantTopup is a paid mutator transaction binding the contract method 0xdf3fec9e.
+//
+// Solidity: function instantTopup(uint256 amount, string paymentProof) returns(bytes32)
+func (_PaymentToken *PaymentTokenSession) InstantTopup(amount *big.Int, paymentProof string) (*types.Transaction, error) {
+	return _PaymentToken.Contract.InstantTopup(&_PaymentToken.TransactOpts, amount, paymentProof)
+}
+
+// InstantTopup is a paid mutator transaction binding the contract method 0xdf3fec9e.
+//
+// Solidity: function instantTopup(uint256 amount, string paymentProof) returns(bytes32)
+func (_PaymentToken *PaymentTokenTransactorSession) InstantTopup(amount *big.Int, paymentProof string) (*types.Transaction, error) {
+	return _PaymentToken.Contract.InstantTopup(&_PaymentToken.TransactOpts, amount, paymentProof)
+}
+
+// PaymentTransfer is a paid mutator transaction binding the contract method 0x8d46c9a2.
+//
+// Solidity: function paymentTransfer(address to, uint256 amount, string note) returns(bool)
+func (_PaymentToken *PaymentTokenTransactor) PaymentTransfer(opts *bind.TransactOpts, to common.Address, amount *big.Int, note string) (*types.Transaction, error) {
+	return _PaymentToken.contract.Transact(opts, "paymentTransfer", to, amount, note)
+}
+
+// PaymentTransfer is a paid mutator transaction binding the contract method 0x8d46c9a2.
+//
+// Solidity: function paymentTransfer(address to, uint256 amount, string note) returns(bool)
+func (_PaymentToken *PaymentTokenSession) PaymentTransfer(to common.Address, amount *big.Int, note string) (*types.Transaction, error) {
+	return _PaymentToken.Contract.PaymentTransfer(&_PaymentToken.TransactOpts, to, amount, note)
+}
+
+// PaymentTransfer is a paid mutator transaction binding the contract method 0x8d46c9a2.
+//
+// Solidity: function paymentTransfer(address to, uint256 amount, string note) returns(bool)
+func (_PaymentToken *PaymentTokenTransactorSession) PaymentTransfer(to common.Address, amount *big.Int, note string) (*types.Transaction, error) {
+	return _PaymentToken.Contract.PaymentTransfer(&_PaymentToken.TransactOpts, to, amount, note)
+}
+
+// ProcessTopup is a paid mutator transaction binding the contract method 0x57d9a752.
+//
+// Solidity: function processTopup(bytes32 requestId) returns()
+func (_PaymentToken *PaymentTokenTransactor) ProcessTopup(opts *bind.TransactOpts, requestId [32]byte) (*types.Transaction, error) {
+	return _PaymentToken.contract.Transact(opts, "processTopup", requestId)
+}
+
+// ProcessTopup is a paid mutator transaction binding the contract method 0x57d9a752.
+//
+// Solidity: function processTopup(bytes32 requestId) returns()
+func (_PaymentToken *PaymentTokenSession) ProcessTopup(requestId [32]byte) (*types.Transaction, error) {
+	return _PaymentToken.Contract.ProcessTopup(&_PaymentToken.TransactOpts, requestId)
+}
+
+// ProcessTopup is a paid mutator transaction binding the contract method 0x57d9a752.
+//
+// Solidity: function processTopup(bytes32 requestId) returns()
+func (_PaymentToken *PaymentTokenTransactorSession) ProcessTopup(requestId [32]byte) (*types.Transaction, error) {
+	return _PaymentToken.Contract.ProcessTopup(&_PaymentToken.TransactOpts, requestId)
+}
+
+// RequestTopup is a paid mutator transaction binding the contract method 0x2716df0c.
+//
+// Solidity: function requestTopup(uint256 amount, string paymentProof) returns(bytes32)
+func (_PaymentToken *PaymentTokenTransactor) RequestTopup(opts *bind.TransactOpts, amount *big.Int, paymentProof string) (*types.Transaction, error) {
+	return _PaymentToken.contract.Transact(opts, "requestTopup", amount, paymentProof)
+}
+
+// RequestTopup is a paid mutator transaction binding the contract method 0x2716df0c.
+//
+// Solidity: function requestTopup(uint256 amount, string paymentProof) returns(bytes32)
+func (_PaymentToken *PaymentTokenSession) RequestTopup(amount *big.Int, paymentProof string) (*types.Transaction, error) {
+	return _PaymentToken.Contract.RequestTopup(&_PaymentToken.TransactOpts, amount, paymentProof)
+}
+
+// RequestTopup is a paid mutator transaction binding the contract method 0x2716df0c.
+//
+// Solidity: function requestTopup(uint256 amount, string paymentProof) returns(bytes32)
+func (_PaymentToken *PaymentTokenTransactorSession) RequestTopup(amount *big.Int, paymentProof string) (*types.Transaction, error) {
+	return _PaymentToken.Contract.RequestTopup(&_PaymentToken.TransactOpts, amount, paymentProof)
+}
+
+// RequestWithdraw is a paid mutator transaction binding the contract method 0x0d57afa6.
+//
+// Solidity: function requestWithdraw(uint256 amount, string bankAccount) returns(bytes32)
+func (_PaymentToken *PaymentTokenTransactor) RequestWithdraw(opts *bind.TransactOpts, amount *big.Int, bankAccount string) (*types.Transaction, error) {
+	return _PaymentToken.contract.Transact(opts, "requestWithdraw", amount, bankAccount)
+}
+
+// RequestWithdraw is a paid mutator transaction binding the contract method 0x0d57afa6.
+//
+// Solidity: function requestWithdraw(uint256 amount, string bankAccount) returns(bytes32)
+func (_PaymentToken *PaymentTokenSession) RequestWithdraw(amount *big.Int, bankAccount string) (*types.Transaction, error) {
+	return _PaymentToken.Contract.RequestWithdraw(&_PaymentToken.TransactOpts, amount, bankAccount)
+}
+
+// RequestWithdraw is a paid mutator transaction binding the contract method 0x0d57afa6.
+//
+// Solidity: function requestWithdraw(uint256 amount, string bankAccount) returns(bytes32)
+func (_PaymentToken *PaymentTokenTransactorSession) RequestWithdraw(amount *big.Int, bankAccount string) (*types.Transaction, error) {
+	return _PaymentToken.Contract.RequestWithdraw(&_PaymentToken.TransactOpts, amount, bankAccount)
+}
+
+// Transfer is a paid mutator transaction binding the contract method 0xa9059cbb.
+//
+// Solidity: function transfer(address to, uint256 value) returns(bool)
+func (_PaymentToken *PaymentTokenTransactor) Transfer(opts *bind.TransactOpts, to common.Address, value *big.Int) (*types.Transaction, error) {
+	return _PaymentToken.contract.Transact(opts, "transfer", to, value)
+}
+
+// Transfer is a paid mutator transaction binding the contract method 0xa9059cbb.
+//
+// Solidity: function transfer(address to, uint256 value) returns(bool)
+func (_PaymentToken *PaymentTokenSession) Transfer(to common.Address, value *big.Int) (*types.Transaction, error) {
+	return _PaymentToken.Contract.Transfer(&_PaymentToken.TransactOpts, to, value)
+}
+
+// Transfer is a paid mutator transaction binding the contract method 0xa9059cbb.
+//
+// Solidity: function transfer(address to, uint256 value) returns(bool)
+func (_PaymentToken *PaymentTokenTransactorSession) Transfer(to common.Address, value *big.Int) (*types.Transaction, error) {
+	return _PaymentToken.Contract.Transfer(&_PaymentToken.TransactOpts, to, value)
+}
+
+// TransferFrom is a paid mutator transaction binding the contract method 0x23b872dd.
+//
+// Solidity: function transferFrom(address from, address to, uint256 value) returns(bool)
+func (_PaymentToken *PaymentTokenTransactor) TransferFrom(opts *bind.TransactOpts, from common.Address, to common.Address, value *big.Int) (*types.Transaction, error) {
+	return _PaymentToken.contract.Transact(opts, "transferFrom", from, to, value)
+}
+
+// TransferFrom is a paid mutator transaction binding the contract method 0x23b872dd.
+//
+// Solidity: function transferFrom(address from, address to, uint256 value) returns(bool)
+func (_PaymentToken *PaymentTokenSession) TransferFrom(from common.Address, to common.Address, value *big.Int) (*types.Transaction, error) {
+	return _PaymentToken.Contract.TransferFrom(&_PaymentToken.TransactOpts, from, to, value)
+}
+
+// TransferFrom is a paid mutator transaction binding the contract method 0x23b872dd.
+//
+// Solidity: function transferFrom(address from, address to, uint256 value) returns(bool)
+func (_PaymentToken *PaymentTokenTransactorSession) TransferFrom(from common.Address, to common.Address, value *big.Int) (*types.Transaction, error) {
+	return _PaymentToken.Contract.TransferFrom(&_PaymentToken.TransactOpts, from, to, value)
+}
+
+// UpdateExchangeRate is a paid mutator transaction binding the contract method 0xb9e205ae.
+//
+// Solidity: function updateExchangeRate(uint256 newRate) returns()
+func (_PaymentToken *PaymentTokenTransactor) UpdateExchangeRate(opts *bind.TransactOpts, newRate *big.Int) (*types.Transaction, error) {
+	return _PaymentToken.contract.Transact(opts, "updateExchangeRate", newRate)
+}
+
+// UpdateExchangeRate is a paid mutator transaction binding the contract method 0xb9e205ae.
+//
+// Solidity: function updateExchangeRate(uint256 newRate) returns()
+func (_PaymentToken *PaymentTokenSession) UpdateExchangeRate(newRate *big.Int) (*types.Transaction, error) {
+	return _PaymentToken.Contract.UpdateExchangeRate(&_PaymentToken.TransactOpts, newRate)
+}
+
+// UpdateExchangeRate is a paid mutator transaction binding the contract method 0xb9e205ae.
+//
+// Solidity: function updateExchangeRate(uint256 newRate) returns()
+func (_PaymentToken *PaymentTokenTransactorSession) UpdateExchangeRate(newRate *big.Int) (*types.Transaction, error) {
+	return _PaymentToken.Contract.UpdateExchangeRate(&_PaymentToken.TransactOpts, newRate)
+}
+
+// PaymentTokenApprovalIterator is returned from FilterApproval and is used to iterate over the raw logs and unpacked data for Approval events raised by the PaymentToken contract.
+type PaymentTokenApprovalIterator struct {
+	Event *PaymentTokenApproval // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *PaymentTokenApprovalIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(PaymentTokenApproval)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(PaymentTokenApproval)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *PaymentTokenApprovalIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *PaymentTokenApprovalIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// PaymentTokenApproval represents a Approval event raised by the PaymentToken contract.
+type PaymentTokenApproval struct {
+	Owner   common.Address
+	Spender common.Address
+	Value   *big.Int
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterApproval is a free log retrieval operation binding the contract event 0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925.
+//
+// Solidity: event Approval(address indexed owner, address indexed spender, uint256 value)
+func (_PaymentToken *PaymentTokenFilterer) FilterApproval(opts *bind.FilterOpts, owner []common.Address, spender []common.Address) (*PaymentTokenApprovalIterator, error) {
+
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+	var spenderRule []interface{}
+	for _, spenderItem := range spender {
+		spenderRule = append(spenderRule, spenderItem)
+	}
+
+	logs, sub, err := _PaymentToken.contract.FilterLogs(opts, "Approval", ownerRule, spenderRule)
+	if err != nil {
+		return nil, err
+	}
+	return &PaymentTokenApprovalIterator{contract: _PaymentToken.contract, event: "Approval", logs: logs, sub: sub}, nil
+}
+
+// WatchApproval is a free log subscription operation binding the contract event 0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925.
+//
+// Solidity: event Approval(address indexed owner, address indexed spender, uint256 value)
+func (_PaymentToken *PaymentTokenFilterer) WatchApproval(opts *bind.WatchOpts, sink chan<- *PaymentTokenApproval, owner []common.Address, spender []common.Address) (event.Subscription, error) {
+
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+	var spenderRule []interface{}
+	for _, spenderItem := range spender {
+		spenderRule = append(spenderRule, spenderItem)
+	}
+
+	logs, sub, err := _PaymentToken.contract.WatchLogs(opts, "Approval", ownerRule, spenderRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(PaymentTokenApproval)
+				if err := _PaymentToken.contract.UnpackLog(event, "Approval", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseApproval is a log parse operation binding the contract event 0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925.
+//
+// Solidity: event Approval(address indexed owner, address indexed spender, uint256 value)
+func (_PaymentToken *PaymentTokenFilterer) ParseApproval(log types.Log) (*PaymentTokenApproval, error) {
+	event := new(PaymentTokenApproval)
+	if err := _PaymentToken.contract.UnpackLog(event, "Approval", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// PaymentTokenExchangeRateUpdatedIterator is returned from FilterExchangeRateUpdated and is used to iterate over the raw logs and unpacked data for ExchangeRateUpdated events raised by the PaymentToken contract.
+type PaymentTokenExchangeRateUpdatedIterator struct {
+	Event *PaymentTokenExchangeRateUpdated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *PaymentTokenExchangeRateUpdatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(PaymentTokenExchangeRateUpdated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(PaymentTokenExchangeRateUpdated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *PaymentTokenExchangeRateUpdatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *PaymentTokenExchangeRateUpdatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// PaymentTokenExchangeRateUpdated represents a ExchangeRateUpdated event raised by the PaymentToken contract.
+type PaymentTokenExchangeRateUpdated struct {
+	OldRate *big.Int
+	NewRate *big.Int
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterExchangeRateUpdated is a free log retrieval operation binding the contract event 0xc8d1043f24843c0a1c9251fdc30017d84e87498fbcf232af9f86816b5e182bde.
+//
+// Solidity: event ExchangeRateUpdated(uint256 oldRate, uint256 newRate)
+func (_PaymentToken *PaymentTokenFilterer) FilterExchangeRateUpdated(opts *bind.FilterOpts) (*PaymentTokenExchangeRateUpdatedIterator, error) {
+
+	logs, sub, err := _PaymentToken.contract.FilterLogs(opts, "ExchangeRateUpdated")
+	if err != nil {
+		return nil, err
+	}
+	return &PaymentTokenExchangeRateUpdatedIterator{contract: _PaymentToken.contract, event: "ExchangeRateUpdated", logs: logs, sub: sub}, nil
+}
+
+// WatchExchangeRateUpdated is a free log subscription operation binding the contract event 0xc8d1043f24843c0a1c9251fdc30017d84e87498fbcf232af9f86816b5e182bde.
+//
+// Solidity: event ExchangeRateUpdated(uint256 oldRate, uint256 newRate)
+func (_PaymentToken *PaymentTokenFilterer) WatchExchangeRateUpdated(opts *bind.WatchOpts, sink chan<- *PaymentTokenExchangeRateUpdated) (event.Subscription, error) {
+
+	logs, sub, err := _PaymentToken.contract.WatchLogs(opts, "ExchangeRateUpdated")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(PaymentTokenExchangeRateUpdated)
+				if err := _PaymentToken.contract.UnpackLog(event, "ExchangeRateUpdated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseExchangeRateUpdated is a log parse operation binding the contract event 0xc8d1043f24843c0a1c9251fdc30017d84e87498fbcf232af9f86816b5e182bde.
+//
+// Solidity: event ExchangeRateUpdated(uint256 oldRate, uint256 newRate)
+func (_PaymentToken *PaymentTokenFilterer) ParseExchangeRateUpdated(log types.Log) (*PaymentTokenExchangeRateUpdated, error) {
+	event := new(PaymentTokenExchangeRateUpdated)
+	if err := _PaymentToken.contract.UnpackLog(event, "ExchangeRateUpdated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// PaymentTokenPaymentProcessedIterator is returned from FilterPaymentProcessed and is used to iterate over the raw logs and unpacked data for PaymentProcessed events raised by the PaymentToken contract.
+type PaymentTokenPaymentProcessedIterator struct {
+	Event *PaymentTokenPaymentProcessed // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *PaymentTokenPaymentProcessedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(PaymentTokenPaymentProcessed)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(PaymentTokenPaymentProcessed)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *PaymentTokenPaymentProcessedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *PaymentTokenPaymentProcessedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// PaymentTokenPaymentProcessed represents a PaymentProcessed event raised by the PaymentToken contract.
+type PaymentTokenPaymentProcessed struct {
+	From   common.Address
+	To     common.Address
+	Amount *big.Int
+	Note   string
+	Raw    types.Log // Blockchain specific contextual infos
+}
+
+// FilterPaymentProcessed is a free log retrieval operation binding the contract event 0xb5e51a9058e00792fede50f44125bb8bc3f068a8f877644c8a3b3d02c74f56e0.
+//
+// Solidity: event PaymentProcessed(address indexed from, address indexed to, uint256 amount, string note)
+func (_PaymentToken *PaymentTokenFilterer) FilterPaymentProcessed(opts *bind.FilterOpts, from []common.Address, to []common.Address) (*PaymentTokenPaymentProcessedIterator, error) {
+
+	var fromRule []interface{}
+	for _, fromItem := range from {
+		fromRule = append(fromRule, fromItem)
+	}
+	var toRule []interface{}
+	for _, toItem := range to {
+		toRule = append(toRule, toItem)
+	}
+
+	logs, sub, err := _PaymentToken.contract.FilterLogs(opts, "PaymentProcessed", fromRule, toRule)
+	if err != nil {
+		return nil, err
+	}
+	return &PaymentTokenPaymentProcessedIterator{contract: _PaymentToken.contract, event: "PaymentProcessed", logs: logs, sub: sub}, nil
+}
+
+// WatchPaymentProcessed is a free log subscription operation binding the contract event 0xb5e51a9058e00792fede50f44125bb8bc3f068a8f877644c8a3b3d02c74f56e0.
+//
+// Solidity: event PaymentProcessed(address indexed from, address indexed to, uint256 amount, string note)
+func (_PaymentToken *PaymentTokenFilterer) WatchPaymentProcessed(opts *bind.WatchOpts, sink chan<- *PaymentTokenPaymentProcessed, from []common.Address, to []common.Address) (event.Subscription, error) {
+
+	var fromRule []interface{}
+	for _, fromItem := range from {
+		fromRule = append(fromRule, fromItem)
+	}
+	var toRule []interface{}
+	for _, toItem := range to {
+		toRule = append(toRule, toItem)
+	}
+
+	logs, sub, err := _PaymentToken.contract.WatchLogs(opts, "PaymentProcessed", fromRule, toRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(PaymentTokenPaymentProcessed)
+				if err := _PaymentToken.contract.UnpackLog(event, "PaymentProcessed", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParsePaymentProcessed is a log parse operation binding the contract event 0xb5e51a9058e00792fede50f44125bb8bc3f068a8f877644c8a3b3d02c74f56e0.
+//
+// Solidity: event PaymentProcessed(address indexed from, address indexed to, uint256 amount, string note)
+func (_PaymentToken *PaymentTokenFilterer) ParsePaymentProcessed(log types.Log) (*PaymentTokenPaymentProcessed, error) {
+	event := new(PaymentTokenPaymentProcessed)
+	if err := _PaymentToken.contract.UnpackLog(event, "PaymentProcessed", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// PaymentTokenTokensBurnedIterator is returned from FilterTokensBurned and is used to iterate over the raw logs and unpacked data for TokensBurned events raised by the PaymentToken contract.
+type PaymentTokenTokensBurnedIterator struct {
+	Event *PaymentTokenTokensBurned // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *PaymentTokenTokensBurnedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(PaymentTokenTokensBurned)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(PaymentTokenTokensBurned)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *PaymentTokenTokensBurnedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *PaymentTokenTokensBurnedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// PaymentTokenTokensBurned represents a TokensBurned event raised by the PaymentToken contract.
+type PaymentTokenTokensBurned struct {
+	From      common.Address
+	Amount    *big.Int
+	RequestId [32]byte
+	Raw       types.Log // Blockchain specific contextual infos
+}
+
+// FilterTokensBurned is a free log retrieval operation binding the contract event 0x52916471973ae53f679d702015168c0a34628d9d95a48de6bd2093661e39a7c3.
+//
+// Solidity: event TokensBurned(address indexed from, uint256 amount, bytes32 indexed requestId)
+func (_PaymentToken *PaymentTokenFilterer) FilterTokensBurned(opts *bind.FilterOpts, from []common.Address, requestId [][32]byte) (*PaymentTokenTokensBurnedIterator, error) {
+
+	var fromRule []interface{}
+	for _, fromItem := range from {
+		fromRule = append(fromRule, fromItem)
+	}
+
+	var requestIdRule []interface{}
+	for _, requestIdItem := range requestId {
+		requestIdRule = append(requestIdRule, requestIdItem)
+	}
+
+	logs, sub, err := _PaymentToken.contract.FilterLogs(opts, "TokensBurned", fromRule, requestIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return &PaymentTokenTokensBurnedIterator{contract: _PaymentToken.contract, event: "TokensBurned", logs: logs, sub: sub}, nil
+}
+
+// WatchTokensBurned is a free log subscription operation binding the contract event 0x52916471973ae53f679d702015168c0a34628d9d95a48de6bd2093661e39a7c3.
+//
+// Solidity: event TokensBurned(address indexed from, uint256 amount, bytes32 indexed requestId)
+func (_PaymentToken *PaymentTokenFilterer) WatchTokensBurned(opts *bind.WatchOpts, sink chan<- *PaymentTokenTokensBurned, from []common.Address, requestId [][32]byte) (event.Subscription, error) {
+
+	var fromRule []interface{}
+	for _, fromItem := range from {
+		fromRule = append(fromRule, fromItem)
+	}
+
+	var requestIdRule []interface{}
+	for _, requestIdItem := range requestId {
+		requestIdRule = append(requestIdRule, requestIdItem)
+	}
+
+	logs, sub, err := _PaymentToken.contract.WatchLogs(opts, "TokensBurned", fromRule, requestIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(PaymentTokenTokensBurned)
+				if err := _PaymentToken.contract.UnpackLog(event, "TokensBurned", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseTokensBurned is a log parse operation binding the contract event 0x52916471973ae53f679d702015168c0a34628d9d95a48de6bd2093661e39a7c3.
+//
+// Solidity: event TokensBurned(address indexed from, uint256 amount, bytes32 indexed requestId)
+func (_PaymentToken *PaymentTokenFilterer) ParseTokensBurned(log types.Log) (*PaymentTokenTokensBurned, error) {
+	event := new(PaymentTokenTokensBurned)
+	if err := _PaymentToken.contract.UnpackLog(event, "TokensBurned", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// PaymentTokenTokensMintedIterator is returned from FilterTokensMinted and is used to iterate over the raw logs and unpacked data for TokensMinted events raised by the PaymentToken contract.
+type PaymentTokenTokensMintedIterator struct {
+	Event *PaymentTokenTokensMinted // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *PaymentTokenTokensMintedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(PaymentTokenTokensMinted)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(PaymentTokenTokensMinted)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *PaymentTokenTokensMintedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *PaymentTokenTokensMintedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// PaymentTokenTokensMinted represents a TokensMinted event raised by the PaymentToken contract.
+type PaymentTokenTokensMinted struct {
+	To        common.Address
+	Amount    *big.Int
+	RequestId [32]byte
+	Raw       types.Log // Blockchain specific contextual infos
+}
+
+// FilterTokensMinted is a free log retrieval operation binding the contract event 0x7369d801113c713520ca653010d32c6bcd6778f0ffb3df0bc9efb8d85b687e44.
+//
+// Solidity: event TokensMinted(address indexed to, uint256 amount, bytes32 indexed requestId)
+func (_PaymentToken *PaymentTokenFilterer) FilterTokensMinted(opts *bind.FilterOpts, to []common.Address, requestId [][32]byte) (*PaymentTokenTokensMintedIterator, error) {
+
+	var toRule []interface{}
+	for _, toItem := range to {
+		toRule = append(toRule, toItem)
+	}
+
+	var requestIdRule []interface{}
+	for _, requestIdItem := range requestId {
+		requestIdRule = append(requestIdRule, requestIdItem)
+	}
+
+	logs, sub, err := _PaymentToken.contract.FilterLogs(opts, "TokensMinted", toRule, requestIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return &PaymentTokenTokensMintedIterator{contract: _PaymentToken.contract, event: "TokensMinted", logs: logs, sub: sub}, nil
+}
+
+// WatchTokensMinted is a free log subscription operation binding the contract event 0x7369d801113c713520ca653010d32c6bcd6778f0ffb3df0bc9efb8d85b687e44.
+//
+// Solidity: event TokensMinted(address indexed to, uint256 amount, bytes32 indexed requestId)
+func (_PaymentToken *PaymentTokenFilterer) WatchTokensMinted(opts *bind.WatchOpts, sink chan<- *PaymentTokenTokensMinted, to []common.Address, requestId [][32]byte) (event.Subscription, error) {
+
+	var toRule []interface{}
+	for _, toItem := range to {
+		toRule = append(toRule, toItem)
+	}
+
+	var requestIdRule []interface{}
+	for _, requestIdItem := range requestId {
+		requestIdRule = append(requestIdRule, requestIdItem)
+	}
+
+	logs, sub, err := _PaymentToken.contract.WatchLogs(opts, "TokensMinted", toRule, requestIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(PaymentTokenTokensMinted)
+				if err := _PaymentToken.contract.UnpackLog(event, "TokensMinted", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseTokensMinted is a log parse operation binding the contract event 0x7369d801113c713520ca653010d32c6bcd6778f0ffb3df0bc9efb8d85b687e44.
+//
+// Solidity: event TokensMinted(address indexed to, uint256 amount, bytes32 indexed requestId)
+func (_PaymentToken *PaymentTokenFilterer) ParseTokensMinted(log types.Log) (*PaymentTokenTokensMinted, error) {
+	event := new(PaymentTokenTokensMinted)
+	if err := _PaymentToken.contract.UnpackLog(event, "TokensMinted", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// PaymentTokenTopupRequestedIterator is returned from FilterTopupRequested and is used to iterate over the raw logs and unpacked data for TopupRequested events raised by the PaymentToken contract.
+type PaymentTokenTopupRequestedIterator struct {
+	Event *PaymentTokenTopupRequested // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *PaymentTokenTopupRequestedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(PaymentTokenTopupRequested)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(PaymentTokenTopupRequested)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *PaymentTokenTopupRequestedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *PaymentTokenTopupRequestedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// PaymentTokenTopupRequested represents a TopupRequested event raised by the PaymentToken contract.
+type PaymentTokenTopupRequested struct {
+	User         common.Address
+	Amount       *big.Int
+	RequestId    [32]byte
+	PaymentProof string
+	Raw          types.Log // Blockchain specific contextual infos
+}
+
+// FilterTopupRequested is a free log retrieval operation binding the contract event 0xb800527edbac2daac0aedefbae480e2bbe821c07864940cc8ab39fd4e42d2b14.
+//
+// Solidity: event TopupRequested(address indexed user, uint256 amount, bytes32 indexed requestId, string paymentProof)
+func (_PaymentToken *PaymentTokenFilterer) FilterTopupRequested(opts *bind.FilterOpts, user []common.Address, requestId [][32]byte) (*PaymentTokenTopupRequestedIterator, error) {
+
+	var userRule []interface{}
+	for _, userItem := range user {
+		userRule = append(userRule, userItem)
+	}
+
+	var requestIdRule []interface{}
+	for _, requestIdItem := range requestId {
+		requestIdRule = append(requestIdRule, requestIdItem)
+	}
+
+	logs, sub, err := _PaymentToken.contract.FilterLogs(opts, "TopupRequested", userRule, requestIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return &PaymentTokenTopupRequestedIterator{contract: _PaymentToken.contract, event: "TopupRequested", logs: logs, sub: sub}, nil
+}
+
+// WatchTopupRequested is a free log subscription operation binding the contract event 0xb800527edbac2daac0aedefbae480e2bbe821c07864940cc8ab39fd4e42d2b14.
+//
+// Solidity: event TopupRequested(address indexed user, uint256 amount, bytes32 indexed requestId, string paymentProof)
+func (_PaymentToken *PaymentTokenFilterer) WatchTopupRequested(opts *bind.WatchOpts, sink chan<- *PaymentTokenTopupRequested, user []common.Address, requestId [][32]byte) (event.Subscription, error) {
+
+	var userRule []interface{}
+	for _, userItem := range user {
+		userRule = append(userRule, userItem)
+	}
+
+	var requestIdRule []interface{}
+	for _, requestIdItem := range requestId {
+		requestIdRule = append(requestIdRule, requestIdItem)
+	}
+
+	logs, sub, err := _PaymentToken.contract.WatchLogs(opts, "TopupRequested", userRule, requestIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(PaymentTokenTopupRequested)
+				if err := _PaymentToken.contract.UnpackLog(event, "TopupRequested", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseTopupRequested is a log parse operation binding the contract event 0xb800527edbac2daac0aedefbae480e2bbe821c07864940cc8ab39fd4e42d2b14.
+//
+// Solidity: event TopupRequested(address indexed user, uint256 amount, bytes32 indexed requestId, string paymentProof)
+func (_PaymentToken *PaymentTokenFilterer) ParseTopupRequested(log types.Log) (*PaymentTokenTopupRequested, error) {
+	event := new(PaymentTokenTopupRequested)
+	if err := _PaymentToken.contract.UnpackLog(event, "TopupRequested", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// PaymentTokenTransferIterator is returned from FilterTransfer and is used to iterate over the raw logs and unpacked data for Transfer events raised by the PaymentToken contract.
+type PaymentTokenTransferIterator struct {
+	Event *PaymentTokenTransfer // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *PaymentTokenTransferIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(PaymentTokenTransfer)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(PaymentTokenTransfer)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *PaymentTokenTransferIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *PaymentTokenTransferIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// PaymentTokenTransfer represents a Transfer event raised by the PaymentToken contract.
+type PaymentTokenTransfer struct {
+	From  common.Address
+	To    common.Address
+	Value *big.Int
+	Raw   types.Log // Blockchain specific contextual infos
+}
+
+// FilterTransfer is a free log retrieval operation binding the contract event 0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef.
+//
+// Solidity: event Transfer(address indexed from, address indexed to, uint256 value)
+func (_PaymentToken *PaymentTokenFilterer) FilterTransfer(opts *bind.FilterOpts, from []common.Address, to []common.Address) (*PaymentTokenTransferIterator, error) {
+
+	var fromRule []interface{}
+	for _, fromItem := range from {
+		fromRule = append(fromRule, fromItem)
+	}
+	var toRule []interface{}
+	for _, toItem := range to {
+		toRule = append(toRule, toItem)
+	}
+
+	logs, sub, err := _PaymentToken.contract.FilterLogs(opts, "Transfer", fromRule, toRule)
+	if err != nil {
+		return nil, err
+	}
+	return &PaymentTokenTransferIterator{contract: _PaymentToken.contract, event: "Transfer", logs: logs, sub: sub}, nil
+}
+
+// WatchTransfer is a free log subscription operation binding the contract event 0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef.
+//
+// Solidity: event Transfer(address indexed from, address indexed to, uint256 value)
+func (_PaymentToken *PaymentTokenFilterer) WatchTransfer(opts *bind.WatchOpts, sink chan<- *PaymentTokenTransfer, from []common.Address, to []common.Address) (event.Subscription, error) {
+
+	var fromRule []interface{}
+	for _, fromItem := range from {
+		fromRule = append(fromRule, fromItem)
+	}
+	var toRule []interface{}
+	for _, toItem := range to {
+		toRule = append(toRule, toItem)
+	}
+
+	logs, sub, err := _PaymentToken.contract.WatchLogs(opts, "Transfer", fromRule, toRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(PaymentTokenTransfer)
+				if err := _PaymentToken.contract.UnpackLog(event, "Transfer", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseTransfer is a log parse operation binding the contract event 0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef.
+//
+// Solidity: event Transfer(address indexed from, address indexed to, uint256 value)
+func (_PaymentToken *PaymentTokenFilterer) ParseTransfer(log types.Log) (*PaymentTokenTransfer, error) {
+	event := new(PaymentTokenTransfer)
+	if err := _PaymentToken.contract.UnpackLog(event, "Transfer", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// PaymentTokenWithdrawRequestedIterator is returned from FilterWithdrawRequested and is used to iterate over the raw logs and unpacked data for WithdrawRequested events raised by the PaymentToken contract.
+type PaymentTokenWithdrawRequestedIterator struct {
+	Event *PaymentTokenWithdrawRequested // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *PaymentTokenWithdrawRequestedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(PaymentTokenWithdrawRequested)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(PaymentTokenWithdrawRequested)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *PaymentTokenWithdrawRequestedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *PaymentTokenWithdrawRequestedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// PaymentTokenWithdrawRequested represents a WithdrawRequested event raised by the PaymentToken contract.
+type PaymentTokenWithdrawRequested struct {
+	User        common.Address
+	Amount      *big.Int
+	RequestId   [32]byte
+	BankAccount string
+	Raw         types.Log // Blockchain specific contextual infos
+}
+
+// FilterWithdrawRequested is a free log retrieval operation binding the contract event 0x344657df2d4ba47218fc30cdc36c214eff825ab95e0f5b1064d7ab0eb56b9426.
+//
+// Solidity: event WithdrawRequested(address indexed user, uint256 amount, bytes32 indexed requestId, string bankAccount)
+func (_PaymentToken *PaymentTokenFilterer) FilterWithdrawRequested(opts *bind.FilterOpts, user []common.Address, requestId [][32]byte) (*PaymentTokenWithdrawRequestedIterator, error) {
+
+	var userRule []interface{}
+	for _, userItem := range user {
+		userRule = append(userRule, userItem)
+	}
+
+	var requestIdRule []interface{}
+	for _, requestIdItem := range requestId {
+		requestIdRule = append(requestIdRule, requestIdItem)
+	}
+
+	logs, sub, err := _PaymentToken.contract.FilterLogs(opts, "WithdrawRequested", userRule, requestIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return &PaymentTokenWithdrawRequestedIterator{contract: _PaymentToken.contract, event: "WithdrawRequested", logs: logs, sub: sub}, nil
+}
+
+// WatchWithdrawRequested is a free log subscription operation binding the contract event 0x344657df2d4ba47218fc30cdc36c214eff825ab95e0f5b1064d7ab0eb56b9426.
+//
+// Solidity: event WithdrawRequested(address indexed user, uint256 amount, bytes32 indexed requestId, string bankAccount)
+func (_PaymentToken *PaymentTokenFilterer) WatchWithdrawRequested(opts *bind.WatchOpts, sink chan<- *PaymentTokenWithdrawRequested, user []common.Address, requestId [][32]byte) (event.Subscription, error) {
+
+	var userRule []interface{}
+	for _, userItem := range user {
+		userRule = append(userRule, userItem)
+	}
+
+	var requestIdRule []interface{}
+	for _, requestIdItem := range requestId {
+		requestIdRule = append(requestIdRule, requestIdItem)
+	}
+
+	logs, sub, err := _PaymentToken.contract.WatchLogs(opts, "WithdrawRequested", userRule, requestIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(PaymentTokenWithdrawRequested)
+				if err := _PaymentToken.contract.UnpackLog(event, "WithdrawRequested", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseWithdrawRequested is a log parse operation binding the contract event 0x344657df2d4ba47218fc30cdc36c214eff825ab95e0f5b1064d7ab0eb56b9426.
+//
+// Solidity: event WithdrawRequested(address indexed user, uint256 amount, bytes32 indexed requestId, string bankAccount)
+func (_PaymentToken *PaymentTokenFilterer) ParseWithdrawRequested(log types.Log) (*PaymentTokenWithdrawRequested, error) {
+	event := new(PaymentTokenWithdrawRequested)
+	if err := _PaymentToken.contract.UnpackLog(event, "WithdrawRequested", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
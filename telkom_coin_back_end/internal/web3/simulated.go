@@ -0,0 +1,222 @@
+package web3
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+	"sync"
+
+	"telkom_coin_back_end/config"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// Simulated chain mode (BLOCKCHAIN_NETWORK=simulated) runs go-ethereum's simulated
+// backend inside the process and deploys PaymentToken with the bytecode of the
+// Hardhat artifact (embedded in payment_token.go), so topup, transfer and
+// withdraw work end to end without Ganache.
+
+var (
+	simulatedOnce     sync.Once
+	simulatedInstance *simulatedChain
+	simulatedErr      error
+)
+
+// simulatedChain is the single in-process chain shared by every Web3Client
+type simulatedChain struct {
+	backend         *simulated.Backend
+	client          *instamineClient
+	contractAddress common.Address
+	adminKey        *ecdsa.PrivateKey
+	chainID         *big.Int
+	gasFunding      *big.Int
+	faucetMu        sync.Mutex
+}
+
+// instamineClient mines a block right after every sent transaction, so
+// receipts are available as soon as SendTransaction returns
+type instamineClient struct {
+	simulated.Client
+	backend *simulated.Backend
+	mu      sync.Mutex
+}
+
+// SendTransaction submits the transaction and commits it in a new block
+func (c *instamineClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.Client.SendTransaction(ctx, tx); err != nil {
+		return err
+	}
+	c.backend.Commit()
+	return nil
+}
+
+// IsSimulatedMode reports whether the active network is the in-process chain
+func IsSimulatedMode() bool {
+	return config.ActiveNetwork().Simulated
+}
+
+// getSimulatedChain starts the simulated chain on first use
+func getSimulatedChain() (*simulatedChain, error) {
+	simulatedOnce.Do(func() {
+		simulatedInstance, simulatedErr = newSimulatedChain()
+	})
+	return simulatedInstance, simulatedErr
+}
+
+func newSimulatedChain() (*simulatedChain, error) {
+	adminKey, err := loadSimulatedAdminKey()
+	if err != nil {
+		return nil, err
+	}
+	adminAddress := crypto.PubkeyToAddress(adminKey.PublicKey)
+
+	gasFunding, err := parseEther("SIMULATED_GAS_FUNDING", config.AppConfig.SimulatedGasFunding)
+	if err != nil {
+		return nil, err
+	}
+
+	// Admin account is the deployer and the gas faucet for user wallets
+	genesisBalance := new(big.Int).Mul(big.NewInt(1_000_000), big.NewInt(params.Ether))
+	backend := simulated.NewBackend(types.GenesisAlloc{
+		adminAddress: {Balance: genesisBalance},
+	})
+	client := &instamineClient{Client: backend.Client(), backend: backend}
+
+	chainID, err := client.ChainID(context.Background())
+	if err != nil {
+		backend.Close()
+		return nil, fmt.Errorf("failed to get simulated chain ID: %v", err)
+	}
+
+	contractAddress, err := deployPaymentToken(client, adminKey, chainID)
+	if err != nil {
+		backend.Close()
+		return nil, err
+	}
+
+	log.Printf("🧪 Simulated chain started (chain ID %s), admin %s", chainID.String(), adminAddress.Hex())
+	log.Printf("🧪 PaymentToken deployed at %s", contractAddress.Hex())
+
+	return &simulatedChain{
+		backend:         backend,
+		client:          client,
+		contractAddress: contractAddress,
+		adminKey:        adminKey,
+		chainID:         chainID,
+		gasFunding:      gasFunding,
+	}, nil
+}
+
+// loadSimulatedAdminKey uses ADMIN_PRIVATE_KEY when set, otherwise a throwaway key
+func loadSimulatedAdminKey() (*ecdsa.PrivateKey, error) {
+	privateKeyHex := strings.TrimPrefix(config.AppConfig.AdminPrivateKey, "0x")
+	if privateKeyHex != "" {
+		privateKey, err := crypto.HexToECDSA(privateKeyHex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ADMIN_PRIVATE_KEY: %v", err)
+		}
+		return privateKey, nil
+	}
+
+	log.Println("⚠️  ADMIN_PRIVATE_KEY not set, generating a temporary admin key for the simulated chain")
+	return crypto.GenerateKey()
+}
+
+// deployPaymentToken deploys the contract from the generated bindings with the admin key
+func deployPaymentToken(client *instamineClient, adminKey *ecdsa.PrivateKey, chainID *big.Int) (common.Address, error) {
+	initialSupplyStr := config.AppConfig.SimulatedInitialSupply
+	initialSupply, ok := new(big.Int).SetString(initialSupplyStr, 10)
+	if !ok {
+		return common.Address{}, fmt.Errorf("invalid SIMULATED_INITIAL_SUPPLY: %s", initialSupplyStr)
+	}
+
+	auth, err := bind.NewKeyedTransactorWithChainID(adminKey, chainID)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	address, tx, _, err := DeployPaymentToken(auth, client, initialSupply)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to deploy PaymentToken: %v", err)
+	}
+
+	receipt, err := client.TransactionReceipt(context.Background(), tx.Hash())
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to get deployment receipt: %v", err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return common.Address{}, errors.New("PaymentToken deployment reverted")
+	}
+
+	return address, nil
+}
+
+// fundGas sends ETH from the admin account to a wallet that is running low,
+// since freshly generated user wallets have nothing to pay gas with
+func (s *simulatedChain) fundGas(ctx context.Context, account common.Address) error {
+	adminAddress := crypto.PubkeyToAddress(s.adminKey.PublicKey)
+	if account == adminAddress {
+		return nil
+	}
+
+	s.faucetMu.Lock()
+	defer s.faucetMu.Unlock()
+
+	balance, err := s.client.BalanceAt(ctx, account, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get gas balance: %v", err)
+	}
+
+	// Top up once the wallet drops below 10% of the funding amount
+	threshold := new(big.Int).Div(s.gasFunding, big.NewInt(10))
+	if balance.Cmp(threshold) >= 0 {
+		return nil
+	}
+
+	gasPrice, err := s.client.SuggestGasPrice(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to suggest gas price: %v", err)
+	}
+
+	// Admin also signs processTopup, so the faucet shares its nonce manager
+	nonce, err := sharedNonceManager.Acquire(ctx, s.client, adminAddress)
+	if err != nil {
+		return fmt.Errorf("failed to get faucet nonce: %v", err)
+	}
+
+	tx := types.NewTransaction(nonce, account, s.gasFunding, params.TxGas, gasPrice, nil)
+	signedTx, err := types.SignTx(tx, types.LatestSignerForChainID(s.chainID), s.adminKey)
+	if err != nil {
+		sharedNonceManager.Release(adminAddress, nonce)
+		return fmt.Errorf("failed to sign faucet transaction: %v", err)
+	}
+
+	if err := s.client.SendTransaction(ctx, signedTx); err != nil {
+		sharedNonceManager.SendFailed(ctx, s.client, adminAddress, nonce, err)
+		return fmt.Errorf("failed to send faucet transaction: %v", err)
+	}
+
+	log.Printf("⛽ Funded %s with gas on the simulated chain", account.Hex())
+	return nil
+}
+
+// parseEther parses the whole-ETH amount of setting key and returns it in wei
+func parseEther(key, value string) (*big.Int, error) {
+	amount, ok := new(big.Int).SetString(value, 10)
+	if !ok || amount.Sign() <= 0 {
+		return nil, fmt.Errorf("invalid %s: %s", key, value)
+	}
+	return amount.Mul(amount, big.NewInt(params.Ether)), nil
+}
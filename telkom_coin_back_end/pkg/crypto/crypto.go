@@ -19,7 +19,7 @@ import (
 
 // ------------------------ Wallet ------------------------
 
-// GenerateWalletKeypair dummy wallet generator (replace with real crypto later)
+// GenerateWalletKeypair creates a secp256k1 key pair and returns the address and hex private key
 func GenerateWalletKeypair() (string, string, error) {
 	privKey, err := crypto.GenerateKey()
 	if err != nil {